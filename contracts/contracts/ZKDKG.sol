@@ -4,9 +4,9 @@ pragma solidity ^0.8.0;
 import "./ShareVerifier.sol";
 import "./KeyVerifier.sol";
 
-// TODO Implement staking
 contract ZKDKG {
-    uint public constant STAKE = 0 ether;
+    // Value each participant deposits with its registration, 0 to run without staking
+    uint public immutable STAKE;
 
     uint16 public immutable noParticipants;
     uint16 public immutable minimumThreshold;
@@ -20,6 +20,15 @@ contract ZKDKG {
     address[] public addresses;
     address[] private disqualified;
 
+    // Stakes are locked until the protocol completes or aborts, when the remaining participants get them back
+    // together with an equal part of the stakes slashed from excluded participants, see payNodes and settle
+    mapping(address => uint) public stakes;
+    mapping(address => uint) public balances;
+    uint public slashed;
+    mapping(address => bool) private excluded;
+    bool private isAborted;
+    bool public isSettled;
+
     mapping(address => bytes32) public commitmentHashes;
     mapping(address => bytes32) public shareHashes;
     uint[] public firstCoefficients;
@@ -34,15 +43,27 @@ contract ZKDKG {
     uint private constant FIELD_A = 168700;
     uint private constant FIELD_D = 168696;
 
+    // Parameters of the Tonelli-Shanks algorithm: FIELD_ORDER - 1 = 2^SQRT_S * SQRT_Q with SQRT_Q odd,
+    // and SQRT_Z = 5^SQRT_Q, where 5 is the smallest quadratic non-residue
+    uint private constant SQRT_S = 28;
+    uint private constant SQRT_Q = 81540058820840996586704275553141814055101440848469862132140264610111;
+    uint private constant SQRT_Z = 19103219067921713944291392827692070036145651957329286315305642004821462161904;
+
+    // Bit of a compressed point holding the least significant bit of the x coordinate
+    uint private constant SIGN_BIT = 1 << 255;
+
     // Used for setting expiries to a far distant point in time
     uint64 private constant POINT_IN_FUTURE = 7258118400;
 
+    // Number of periods the registration stays open, after which the stakes of an incomplete registration can be refunded
+    uint64 private constant REGISTRATION_PERIODS = 10;
+
     bool private immutable isEvaluation;
 
     uint16 private noBroadcasts = 0;
 
-    ShareVerifier private shareVerifier;
-    KeyVerifier private keyVerifier;
+    ShareVerifier public shareVerifier;
+    KeyVerifier public keyVerifier;
 
     mapping(address => Dispute) private disputes;
     address[] private disputed;
@@ -52,26 +73,12 @@ contract ZKDKG {
 
         uint[2] publicKey;
         /**
-         * The public key could be stored in compressed form, reducing the array to a single unsigned integer.
-         * For gas efficiency and code complexity reasons this is not done here.
+         * The public key is stored in uncompressed form, so that its coordinates can be passed to the verifiers directly.
          *
-         * If the point were in compressed Twisted Edwards form, decompression would require submod, divmod and sqrt operations
-         * due to the need to compute x = sqrt((1 - y^2) / (1 - dy^2)).
-         * submod(x, y, p) can be rewritten as addmod(p - (y % p), x, p) and divmod is equivalent to the multiplication of the modular inverse
-         * (see https://github.com/witnet/elliptic-curve-solidity/blob/b6886bb08333ccf6883ac42827d62c1bfdb37d44/contracts/EllipticCurve.sol#L22).
-         * The "hack" to compute the square root of an integer without fractional exponents doesn't apply for the Baby Jubjub curve.
-         * It relies on the identity that sqrt(r) = r^(1/2) is congruent r^((p + 1) / 4) modulo p if p mod 4 = 3, where r and p are unsigned integers.
-         * The order p_B of the Baby Jubjub curve doesn't satisfy this condition, therefore a more sophisticated algorithm (like the Tonelli-Shanks algorithm)
-         * would have to be used.
-         *
-         * If the point were in compressed Weierstrass form, only a method for computing the square root would be additionally required.
-         * As seen before, due to the defining characteristics of the Baby Jubjub curve, this is not easily achievable.
-         * Also, an extra conversion would have to be made on the Zokrates side because it needs points of a Twisted Edwards curve for
-         * its computations.
-         *
-         * Therefore, the point is being passed in uncompressed form.
-         * To circumvent this overhead one could require participants to submit a proof to the register call or implement a square root algorithm.
-         * We consider both of these solutions to be too complex for the problem at hand.
+         * Participants may nevertheless submit it in compressed form through registerCompressed, which saves calldata.
+         * Decompression computes x = sqrt((1 - y^2) / (a - dy^2)), where division is the multiplication with the modular inverse.
+         * The "hack" to compute the square root as r^((p + 1) / 4) only applies if p mod 4 = 3, which the order p_B of the
+         * underlying field of Baby Jubjub doesn't satisfy. Therefore the Tonelli-Shanks algorithm is used, see sqrt.
         **/
     }
 
@@ -89,6 +96,7 @@ contract ZKDKG {
         BROADCAST_DISPUTE
     }
 
+    event Registration(address sender, uint16 index, uint[2] possessionProof);
     event DisputeShare(uint16 disputerIndex, uint16 disputeeIndex);
     event BroadcastSharesLog(address sender, uint16 broadcasterIndex);
     event RegistrationEndLog();
@@ -97,13 +105,15 @@ contract ZKDKG {
     event Abortion();
     event Reset();
     event Exclusion(uint16 index);
+    event Slashing(uint16 index, uint amount);
 
     constructor(
         address _shareVerifier,
         address _keyVerifier,
         uint16 _noParticipants,
         uint16 _userThreshold,
-        uint16 _periodLength
+        uint16 _periodLength,
+        uint _stake
     ) {
         uint16 _minimumThreshold = _noParticipants / 2 + 1;
 
@@ -119,23 +129,39 @@ contract ZKDKG {
         minimumThreshold = _minimumThreshold;
         userThreshold = _userThreshold;
         periodLength = _periodLength;
+        STAKE = _stake;
         isEvaluation = _periodLength == 0;
 
         firstCoefficients = new uint[](_noParticipants);
 
         // Avoid higher costs for the last participant that calls register
         phase = Phase.REGISTER;
-        phaseEnd = type(uint64).max;
+        phaseEnd = isEvaluation ? POINT_IN_FUTURE : uint64(block.timestamp) + REGISTRATION_PERIODS * _periodLength;
+    }
+
+    /// @param possessionProof Schnorr proof (c, s) of the knowledge of the secret key, bound to the sender and this contract.
+    /// It is too costly to verify on-chain, so it is only emitted for the other participants to verify before dealing shares.
+    function register(uint[2] calldata publicKey, uint[2] calldata possessionProof) public payable {
+        addParticipant(publicKey, possessionProof);
     }
 
-    function register(uint[2] calldata publicKey) public payable {
+    /// @param compressedKey y coordinate of the public key with the least significant bit of the x coordinate as its most significant bit
+    /// @param possessionProof see register
+    function registerCompressed(uint compressedKey, uint[2] calldata possessionProof) public payable {
+        addParticipant(decompress(compressedKey), possessionProof);
+    }
+
+    function addParticipant(uint[2] memory publicKey, uint[2] calldata possessionProof) private {
         require(msg.value == STAKE, "value too low");
-        require(phase == Phase.REGISTER, "registration phase is over");
+        require(phase == Phase.REGISTER && block.timestamp <= phaseEnd, "registration phase is over");
         require(!isRegistered(msg.sender), "already registered");
 
         addresses.push(msg.sender);
+        stakes[msg.sender] = msg.value;
         participants[msg.sender] = Participant(uint16(addresses.length), publicKey);
 
+        emit Registration(msg.sender, uint16(addresses.length), possessionProof);
+
         if (addresses.length == noParticipants) {
             phaseEnd = isEvaluation ? POINT_IN_FUTURE : uint64(block.timestamp) + periodLength;
             phase = Phase.BROADCAST_SUBMIT;
@@ -242,6 +268,11 @@ contract ZKDKG {
         disputed.pop();
 
         excludeNode(dispute.disputerIndex);
+
+        // Settle the abortion, or pass on the stake of a participant excluded after the settlement
+        if (isAborted || isSettled) {
+            payNodes();
+        }
     }
 
     function submitPublicKey(uint[2] calldata _publicKey, KeyVerifier.Proof calldata proof) external {
@@ -283,7 +314,66 @@ contract ZKDKG {
      * the dependence on the stored stake, i.e. it is not possible to distribute the reward s.t. [1] and [2] hold
      * for every stake.
      */
-    function payNodes() private {}
+    /// Credits the remaining participants with their stakes and an equal part of the slashed ones, to be withdrawn through withdraw.
+    /// It is called once all exclusions of a transaction are done. Stakes slashed after the settlement, e.g. by the expiry of
+    /// a dispute still pending at the abortion, are passed on to the remaining participants the same way.
+    function payNodes() private {
+        isSettled = true;
+
+        uint remaining = 0;
+        for (uint i = 0; i < addresses.length; i++) {
+            if (!excluded[addresses[i]]) {
+                remaining++;
+            }
+        }
+        if (remaining == 0) {
+            return;
+        }
+
+        // The remainder of the division goes to the remaining participant with the lowest index
+        uint reward = slashed / remaining;
+        uint remainder = slashed - reward * remaining;
+        slashed = 0;
+        for (uint i = 0; i < addresses.length; i++) {
+            address addr = addresses[i];
+            if (excluded[addr]) {
+                continue;
+            }
+            balances[addr] += stakes[addr] + reward + remainder;
+            delete stakes[addr];
+            remainder = 0;
+        }
+    }
+
+    /// Settles the stakes of a run that stalled, which anyone can call once the current phase expired:
+    /// the registration didn't fill, not every participant broadcast its shares, or nobody submitted the public key
+    /// within a period after the dispute phase, e.g. because expired disputes aborted the protocol.
+    /// Missing broadcasts aren't slashed, as participants refuse to deal if a proof of possession fails, which the
+    /// contract doesn't check. The protocol is aborted and the stakes are paid out like after any other abortion.
+    function settle() external {
+        require(!isSettled, "already settled");
+        require(block.timestamp > phaseEnd, "phase still ongoing");
+
+        if (phase == Phase.BROADCAST_DISPUTE) {
+            removeExpiredDisputes();
+            require(isAborted || block.timestamp > uint(phaseEnd) + periodLength, "public key submission still ongoing");
+        }
+
+        if (!isAborted) {
+            isAborted = true;
+            emit Abortion();
+        }
+        payNodes();
+    }
+
+    function withdraw() external {
+        uint amount = balances[msg.sender];
+        require(amount > 0, "nothing to withdraw");
+
+        balances[msg.sender] = 0;
+        (bool success, ) = payable(msg.sender).call{value: amount}("");
+        require(success, "transfer failed");
+    }
 
     function reset() private {
 
@@ -326,10 +416,22 @@ contract ZKDKG {
 
         firstCoefficients[index - 1] = INFINITY;
         disqualified.push(addr);
+        excluded[addr] = true;
 
         emit Exclusion(index);
 
-        if (noParticipants - disqualified.length < userThreshold) {
+        // Once settled, the stake was credited to the balance, which is forfeited unless already withdrawn
+        uint amount = stakes[addr] + balances[addr];
+        if (amount > 0) {
+            delete stakes[addr];
+            delete balances[addr];
+            slashed += amount;
+            emit Slashing(index, amount);
+        }
+
+        // The stakes are only settled after all exclusions of the transaction, see payNodes
+        if (!isAborted && noParticipants - disqualified.length < userThreshold) {
+            isAborted = true;
             emit Abortion();
         }
     }
@@ -348,6 +450,7 @@ contract ZKDKG {
             excludeNode(participants[addr].index);
             delete disputes[addr];
         }
+        delete disputed;
     }
 
     /// @dev Check whether point (x,y) is on the Baby Jubjub curve.
@@ -370,6 +473,84 @@ contract ZKDKG {
         return lhs == rhs;
     }
 
+    /// @param compressed compressed point, see registerCompressed
+    /// @return the affine coordinates of the point
+    function decompress(uint compressed) private view returns (uint[2] memory) {
+        uint y = compressed & (SIGN_BIT - 1);
+        require(y < FIELD_ORDER, "non-canonical y coordinate");
+
+        uint yy = mulmod(y, y, FIELD_ORDER);
+        uint u = addmod(1, FIELD_ORDER - yy, FIELD_ORDER);
+        // Never zero because a / d is not a square
+        uint v = addmod(FIELD_A, FIELD_ORDER - mulmod(FIELD_D, yy, FIELD_ORDER), FIELD_ORDER);
+
+        (uint x, bool isSquare) = sqrt(mulmod(u, expmod(v, FIELD_ORDER - 2), FIELD_ORDER));
+        require(isSquare, "public key not on curve");
+
+        if (((x & 1) == 1) != ((compressed & SIGN_BIT) != 0)) {
+            require(x != 0, "non-canonical x coordinate sign");
+            x = FIELD_ORDER - x;
+        }
+
+        return [x, y];
+    }
+
+    /// @dev Tonelli-Shanks algorithm, mirrored by FieldSqrt of the Go client
+    /// @return a square root of a and true if a is a square, (0, false) otherwise
+    function sqrt(uint a) private view returns (uint, bool) {
+        if (a == 0) {
+            return (0, true);
+        }
+
+        uint m = SQRT_S;
+        uint c = SQRT_Z;
+        uint t = expmod(a, SQRT_Q);
+        uint r = expmod(a, (SQRT_Q + 1) / 2);
+
+        while (t != 1) {
+            // Find the least i with t^(2^i) = 1, which is less than m iff a is a square
+            uint i = 0;
+            uint b = t;
+            while (b != 1) {
+                if (i + 1 == m) {
+                    return (0, false);
+                }
+                b = mulmod(b, b, FIELD_ORDER);
+                i++;
+            }
+
+            b = c;
+            for (uint j = 0; j < m - i - 1; j++) {
+                b = mulmod(b, b, FIELD_ORDER);
+            }
+
+            m = i;
+            c = mulmod(b, b, FIELD_ORDER);
+            t = mulmod(t, c, FIELD_ORDER);
+            r = mulmod(r, b, FIELD_ORDER);
+        }
+
+        return (r, true);
+    }
+
+    /// @return base^exponent modulo the field order, computed by the modexp precompile
+    function expmod(uint base, uint exponent) private view returns (uint result) {
+        uint modulus = FIELD_ORDER;
+        assembly {
+            let p := mload(0x40)
+            mstore(p, 0x20)
+            mstore(add(p, 0x20), 0x20)
+            mstore(add(p, 0x40), 0x20)
+            mstore(add(p, 0x60), base)
+            mstore(add(p, 0x80), exponent)
+            mstore(add(p, 0xa0), modulus)
+            if iszero(staticcall(gas(), 0x05, p, 0xc0, p, 0x20)) {
+                revert(0, 0)
+            }
+            result := mload(p)
+        }
+    }
+
     function isPublicKeyValid() private view returns (bool) {
         uint[2] memory pk = participants[msg.sender].publicKey;
 
@@ -3,7 +3,8 @@ import "@nomiclabs/hardhat-ethers";
 
 task("deploy", "Deploy the ZKDKG contract(s)")
     .addPositionalParam("participants", "the number of participants for the distributed key generation", undefined, types.int, false)
-    .setAction(async ({participants}, env, _) => {
+    .addOptionalParam("stake", "the stake in wei each participant deposits at registration", "0", types.string)
+    .setAction(async ({participants, stake}, env, _) => {
         await env.run("compile");
 
         const KEYVERIFIER = await env.ethers.getContractFactory("KeyVerifier");
@@ -26,6 +27,7 @@ task("deploy", "Deploy the ZKDKG contract(s)")
             participants,
             Math.floor(2 / 3 * (participants + 1)),
             0,
+            stake,
         );
 
         await zkDKG.deployed();
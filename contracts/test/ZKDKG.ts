@@ -0,0 +1,167 @@
+import assert from "assert";
+import {ethers, network} from "hardhat";
+import type {Contract, ContractTransaction} from "ethers";
+import type {SignerWithAddress} from "@nomiclabs/hardhat-ethers/signers";
+
+const PERIOD = 60;
+
+// The point at infinity (0, 1) is on the curve, which is all the contract checks of the keys
+const PUBLIC_KEY = [0, 1];
+const POSSESSION_PROOF = [0, 0];
+const PROOF = {a: {X: 0, Y: 0}, b: {X: [0, 0], Y: [0, 0]}, c: {X: 0, Y: 0}};
+
+// Deploys the contract with verifiers accepting every proof and runs it until the dispute phase,
+// unless fewer participants register or broadcast their shares
+async function deploy(participants: number, userThreshold: number, stake: number, registrants = participants, broadcasters = registrants) {
+    const verifier = await (await ethers.getContractFactory("AcceptingVerifier")).deploy();
+    const zkDKG = await (await ethers.getContractFactory("ZKDKG")).deploy(
+        verifier.address,
+        verifier.address,
+        participants,
+        userThreshold,
+        PERIOD,
+        stake,
+    );
+
+    const signers = (await ethers.getSigners()).slice(0, participants);
+    for (const signer of signers.slice(0, registrants)) {
+        await zkDKG.connect(signer).register(PUBLIC_KEY, POSSESSION_PROOF, {value: stake});
+    }
+
+    const commitments = Array(Math.floor(participants / 2) + 1).fill(1);
+    const shares = Array.from({length: participants - 1}, (_, i) => i + 1);
+    for (const signer of signers.slice(0, broadcasters)) {
+        await zkDKG.connect(signer).broadcastShares(commitments, shares);
+    }
+
+    return {zkDKG, signers, shares};
+}
+
+async function increaseTime(seconds: number) {
+    await network.provider.send("evm_increaseTime", [seconds]);
+    await network.provider.send("evm_mine");
+}
+
+async function endPhase() {
+    await increaseTime(2 * PERIOD);
+}
+
+// Counts the events of the given name the transaction emitted
+async function events(tx: Promise<ContractTransaction>, name: string) {
+    return (await (await tx).wait()).events!.filter(e => e.event === name).length;
+}
+
+async function balances(zkDKG: Contract, signers: SignerWithAddress[]) {
+    return Promise.all(signers.map(async signer => (await zkDKG.balances(signer.address)).toNumber()));
+}
+
+// Checks that every wei the contract holds is credited to a participant, so nothing is locked
+async function assertNothingLocked(zkDKG: Contract, signers: SignerWithAddress[]) {
+    assert.strictEqual((await zkDKG.slashed()).toNumber(), 0);
+    let credited = 0;
+    for (const signer of signers) {
+        assert.strictEqual((await zkDKG.stakes(signer.address)).toNumber(), 0);
+        credited += (await zkDKG.balances(signer.address)).toNumber();
+    }
+    assert.strictEqual((await ethers.provider.getBalance(zkDKG.address)).toNumber(), credited);
+}
+
+describe("ZKDKG", () => {
+    it("settles the stakes after all disputes expiring in the same submission", async () => {
+        const {zkDKG, signers, shares} = await deploy(4, 4, 1000);
+
+        await zkDKG.connect(signers[2]).disputeShare(1, shares);
+        await zkDKG.connect(signers[3]).disputeShare(2, shares);
+        await endPhase();
+
+        // The first expired dispute aborts the protocol, the second one must still slash its dealer
+        const receipt = await (await zkDKG.connect(signers[2]).submitPublicKey(PUBLIC_KEY, PROOF)).wait();
+        assert.strictEqual(receipt.events.filter((e: {event?: string}) => e.event === "Abortion").length, 1);
+        assert.strictEqual(receipt.events.filter((e: {event?: string}) => e.event === "Slashing").length, 2);
+
+        assert.deepStrictEqual(await balances(zkDKG, signers), [0, 0, 2000, 2000]);
+        await assertNothingLocked(zkDKG, signers);
+
+        await zkDKG.connect(signers[2]).withdraw();
+        assert.strictEqual((await zkDKG.balances(signers[2].address)).toNumber(), 0);
+        await assert.rejects(zkDKG.connect(signers[0]).withdraw(), /nothing to withdraw/);
+    });
+
+    it("credits the remainder of the slashed stakes", async () => {
+        const {zkDKG, signers, shares} = await deploy(4, 3, 1000);
+
+        await zkDKG.connect(signers[1]).disputeShare(1, shares);
+        await endPhase();
+        await zkDKG.connect(signers[1]).submitPublicKey(PUBLIC_KEY, PROOF);
+
+        assert.deepStrictEqual(await balances(zkDKG, signers), [0, 1334, 1333, 1333]);
+        await assertNothingLocked(zkDKG, signers);
+    });
+
+    it("passes on stakes slashed after the abortion", async () => {
+        const {zkDKG, signers, shares} = await deploy(4, 4, 600);
+
+        await zkDKG.connect(signers[2]).disputeShare(1, shares);
+        await zkDKG.connect(signers[3]).disputeShare(2, shares);
+
+        // The defense excludes the disputer, which aborts the protocol
+        await zkDKG.connect(signers[0]).defendShare(PROOF);
+        assert.deepStrictEqual(await balances(zkDKG, signers), [800, 800, 0, 800]);
+
+        // The dispute against the second dealer expires after the settlement
+        await endPhase();
+        await zkDKG.connect(signers[0]).submitPublicKey(PUBLIC_KEY, PROOF);
+
+        assert.deepStrictEqual(await balances(zkDKG, signers), [1200, 0, 0, 1200]);
+        await assertNothingLocked(zkDKG, signers);
+    });
+
+    it("refunds the stakes of a registration that didn't fill", async () => {
+        const {zkDKG, signers} = await deploy(4, 3, 1000, 2, 0);
+
+        await assert.rejects(zkDKG.settle(), /phase still ongoing/);
+        await increaseTime(10 * PERIOD + 1);
+        await assert.rejects(zkDKG.connect(signers[2]).register(PUBLIC_KEY, POSSESSION_PROOF, {value: 1000}), /registration phase is over/);
+
+        assert.strictEqual(await events(zkDKG.connect(signers[3]).settle(), "Abortion"), 1);
+        assert.deepStrictEqual(await balances(zkDKG, signers), [1000, 1000, 0, 0]);
+        await assertNothingLocked(zkDKG, signers);
+        await assert.rejects(zkDKG.settle(), /already settled/);
+    });
+
+    it("refunds all stakes if a participant didn't broadcast", async () => {
+        const {zkDKG, signers} = await deploy(4, 3, 1000, 4, 3);
+
+        await assert.rejects(zkDKG.settle(), /phase still ongoing/);
+        await endPhase();
+
+        // The contract can't tell a missing broadcast from a refusal to deal to a key without proof of possession
+        const tx = zkDKG.settle();
+        assert.strictEqual(await events(tx, "Slashing"), 0);
+        assert.deepStrictEqual(await balances(zkDKG, signers), [1000, 1000, 1000, 1000]);
+        await assertNothingLocked(zkDKG, signers);
+    });
+
+    it("settles an abortion by expired disputes without a submission of the public key", async () => {
+        const {zkDKG, signers, shares} = await deploy(4, 4, 1000);
+
+        await zkDKG.connect(signers[1]).disputeShare(1, shares);
+        await endPhase();
+
+        assert.strictEqual(await events(zkDKG.settle(), "Abortion"), 1);
+        assert.deepStrictEqual(await balances(zkDKG, signers), [0, 1334, 1333, 1333]);
+        await assertNothingLocked(zkDKG, signers);
+    });
+
+    it("settles a run whose public key wasn't submitted", async () => {
+        const {zkDKG, signers} = await deploy(4, 3, 1000);
+
+        await increaseTime(PERIOD + 1);
+        await assert.rejects(zkDKG.settle(), /public key submission still ongoing/);
+        await increaseTime(PERIOD);
+
+        assert.strictEqual(await events(zkDKG.settle(), "Abortion"), 1);
+        assert.deepStrictEqual(await balances(zkDKG, signers), [1000, 1000, 1000, 1000]);
+        await assertNothingLocked(zkDKG, signers);
+    });
+});
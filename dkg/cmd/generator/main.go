@@ -3,15 +3,14 @@ package main
 import (
 	"client/internal/pkg/group/curve25519"
 	"client/pkg/dkg"
+	"client/pkg/zk"
 	"context"
 	"flag"
 	"fmt"
 	"math/big"
 	"os"
 
-	"github.com/ethereum/go-ethereum/crypto"
 	log "github.com/sirupsen/logrus"
-	"github.com/spf13/viper"
 	"go.dedis.ch/kyber/v3"
 )
 
@@ -21,35 +20,34 @@ func main() {
 	participants := flag.Int64("participants", 10, "the number of participants for the distributed key generation")
 	flag.Parse()
 
-	viper.SetConfigFile(*configFile)
-	viper.SetConfigType("json")
-	if err := viper.ReadInConfig(); err != nil {
-		exit("Read config: %w", err)
-	}
-
-	var config dkg.Config
-	err := viper.Unmarshal(&config)
+	config, err := dkg.LoadConfig(*configFile)
 	if err != nil {
-		exit("Unmarshal config into struct, %w", err)
+		exit("Load config: %v", err)
 	}
 
 	var pipe *os.File = nil
 	if *idPipe != "" {
 		if pipe, err = os.OpenFile(*idPipe, os.O_WRONLY, os.ModeNamedPipe); err != nil {
-			exit("Open pipe: %w", err)
+			exit("Open pipe: %v", err)
 		}
 	}
 
 	prover, err := dkg.NewProver(config.MountSource, pipe)
 	if err != nil {
-		exit("Create prover: %w", err)
+		exit("Create prover: %v", err)
 	}
 
 	defer prover.Close()
 
-	curve := &curve25519.ProjectiveCurve{}
-	curve.Init(dkg.ParamBabyJubJub(), false)
-	suite := &curve25519.SuiteCurve25519{ProjectiveCurve: *curve}
+	params := &dkg.Params{
+		NoParticipants:   uint16(*participants),
+		MinimumThreshold: dkg.MinimumThreshold(uint16(*participants)),
+	}
+	if err := params.CheckCircuits(prover); err != nil {
+		exit("Artifacts in %s don't match the number of participants: %v", config.MountSource, err)
+	}
+
+	suite := curve25519.NewBlakeSHA256BabyJubJub(false)
 
 	success := true
 	if err := measurePolyEval(prover, int(*participants), suite, config.DkgPrivateKey); err != nil {
@@ -67,21 +65,12 @@ func main() {
 	}
 }
 
-func measurePolyEval(prover *dkg.Prover, participants int, suite *curve25519.SuiteCurve25519, privateKey string) error {
-	threshold := participants / 2 + 1
-	args := make([]*big.Int, 0)
-	pointsHashInput := make([]byte, 0)
-
-	for i := 0; i < threshold; i++ {
-		point := suite.Point().Pick(suite.RandomStream()).(*curve25519.ProjPoint)
-		commitX, commitY := point.GetXY()
-		args = append(args, &commitX.V, &commitY.V)
+func measurePolyEval(prover *dkg.Prover, participants int, suite *curve25519.SuiteBabyJubJub, privateKey string) error {
+	threshold := int(dkg.MinimumThreshold(uint16(participants)))
 
-		compressed, err := point.MarshalBinary()
-		if err != nil {
-			return fmt.Errorf("marshal commit: %w", err)
-		}
-		pointsHashInput = append(pointsHashInput, compressed...)
+	commits := make([]kyber.Point, threshold)
+	for i := range commits {
+		commits[i] = suite.Point().Pick(suite.RandomStream())
 	}
 
 	long, err := dkg.HexToScalar(suite, privateKey)
@@ -89,47 +78,25 @@ func measurePolyEval(prover *dkg.Prover, participants int, suite *curve25519.Sui
 		return fmt.Errorf("hex to scalar: %w", err)
 	}
 
-	sk, _ := long.MarshalBinary()
-	args = append(args, new(big.Int).SetBytes(sk))
-
-	pubProofer := suite.Point().Mul(long, nil).(*curve25519.ProjPoint)
-	pubProoferX, pubProoferY := pubProofer.GetXY()
-	args = append(args, &pubProoferX.V, &pubProoferY.V)
-
-	pubDisputer, _ := suite.Point().Pick(suite.RandomStream()).(*curve25519.ProjPoint)
-	pubDisputerX, pubDisputerY := pubDisputer.GetXY()
-	args = append(args, &pubDisputerX.V, &pubDisputerY.V)
-
-	index := big.NewInt(1)
-	args = append(args, index)
-
 	share, _ := suite.Scalar().Pick(suite.RandomStream()).MarshalBinary()
-	shareBig := new(big.Int).SetBytes(share)
-	args = append(args, shareBig)
-
-	pointsHash := crypto.Keccak256(pointsHashInput)
-
-	hashInput := make([]byte, 0)
-
-	hashInput = append(hashInput, pointsHash...)
 
-	buf := make([]byte, 32)
-
-	hashInput = append(hashInput, pubProoferX.V.FillBytes(buf)...)
-	hashInput = append(hashInput, pubProoferY.V.FillBytes(buf)...)
-	hashInput = append(hashInput, pubDisputerX.V.FillBytes(buf)...)
-	hashInput = append(hashInput, pubDisputerY.V.FillBytes(buf)...)
-
-	hashInput = append(hashInput, index.FillBytes(buf)...)
-	hashInput = append(hashInput, shareBig.FillBytes(buf)...)
-
-	hash := dkg.TruncateHash(crypto.Keccak256(hashInput))
+	input := &zk.PolyEvalInput{
+		Commits:        commits,
+		SecretKey:      long,
+		PubKeyProofer:  suite.Point().Mul(long, nil),
+		PubKeyDisputer: suite.Point().Pick(suite.RandomStream()),
+		Index:          1,
+		EncryptedShare: new(big.Int).SetBytes(share),
+	}
 
-	args = append(args, new(big.Int).SetBytes(hash))
+	witness, err := input.Witness()
+	if err != nil {
+		return fmt.Errorf("poly eval witness: %w", err)
+	}
 
-	log.Infof("Args: %v", args)
+	log.Infof("Witness: %v", witness)
 
-	if err := prover.ComputeWitness(context.Background(), dkg.EvalPolyProof, args); err != nil {
+	if err := prover.ComputeWitness(context.Background(), dkg.EvalPolyProof, witness); err != nil {
 		return fmt.Errorf("compute witness: %w", err)
 	}
 
@@ -140,35 +107,22 @@ func measurePolyEval(prover *dkg.Prover, participants int, suite *curve25519.Sui
 	return nil
 }
 
-func measureKeyDeriv(prover *dkg.Prover, participants int, suite *curve25519.SuiteCurve25519) error {
-	args := make([]*big.Int, 0)
-	commits := make([]kyber.Point, participants)
-
-	for i := 0; i < len(commits); i++ {
-		commits[i] = suite.Point().Pick(suite.RandomStream())
+func measureKeyDeriv(prover *dkg.Prover, participants int, suite *curve25519.SuiteBabyJubJub) error {
+	input := &zk.KeyDerivInput{
+		FirstCoefficients: make([]kyber.Point, participants),
 	}
-
-	firstCoefficients := make([]byte, 0)
-	for i := 0; i < participants; i++ {
-		commit := suite.Point().Pick(suite.RandomStream()).(*curve25519.ProjPoint)
-		coeffX, coeffY := commit.GetXY()
-
-		coeffBin, err := commit.MarshalBinary()
-		if err != nil {
-			return fmt.Errorf("marshal coefficient: %w", err)
-		}
-		firstCoefficients = append(firstCoefficients, coeffBin...)
-
-		args = append(args, &coeffX.V, &coeffY.V)
+	for i := range input.FirstCoefficients {
+		input.FirstCoefficients[i] = suite.Point().Pick(suite.RandomStream())
 	}
 
-	hash := dkg.TruncateHash(crypto.Keccak256(firstCoefficients))
-
-	args = append(args, new(big.Int).SetBytes(hash))
+	witness, err := input.Witness()
+	if err != nil {
+		return fmt.Errorf("key deriv witness: %w", err)
+	}
 
-	log.Infof("Args: %v", args)
+	log.Infof("Witness: %v", witness)
 
-	if err := prover.ComputeWitness(context.Background(), dkg.KeyDerivProof, args); err != nil {
+	if err := prover.ComputeWitness(context.Background(), dkg.KeyDerivProof, witness); err != nil {
 		return fmt.Errorf("compute witness: %w", err)
 	}
 
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"client/pkg/artifacts"
+	"context"
+	"errors"
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+)
+
+func artifactsCommand(args []string) error {
+	fs, _ := newFlagSet("artifacts record|verify|fetch", false)
+	dir := fs.String("dir", "", "directory of the artifacts, e.g. build/<participants>/zk")
+	participants := fs.Int("participants", 0, "the number of participants the artifacts are built for (record, fetch)")
+	from := fs.String("from", "", "directory or http(s) URL of the mirror to fetch from (fetch)")
+
+	if len(args) == 0 {
+		fs.Usage()
+		return errors.New("missing subcommand")
+	}
+	fs.Parse(args[1:])
+
+	if *dir == "" {
+		return errors.New("missing -dir")
+	}
+	d := artifacts.Dir(*dir)
+
+	switch args[0] {
+	case "record":
+		if _, err := d.Record(*participants); err != nil {
+			return fmt.Errorf("record: %w", err)
+		}
+	case "verify":
+		m, err := d.Verify()
+		if err != nil {
+			return fmt.Errorf("verify: %w", err)
+		}
+		log.Infof("Artifacts for %d participants are intact", m.Participants)
+	case "fetch":
+		if *from == "" {
+			return errors.New("missing -from")
+		}
+		if _, err := d.Fetch(context.Background(), artifacts.NewSource(*from), *participants); err != nil {
+			return fmt.Errorf("fetch: %w", err)
+		}
+	default:
+		return fmt.Errorf("unknown subcommand %q", args[0])
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"client/pkg/dkg"
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/crypto"
+	log "github.com/sirupsen/logrus"
+)
+
+func deploy(args []string) error {
+	fs, configFile := newFlagSet("deploy", true)
+	participants := fs.Uint("participants", 0, "the number of participants")
+	userThreshold := fs.Uint("user-threshold", 0, "the number of remaining participants below which the protocol aborts (default two thirds of the participants)")
+	periodLength := fs.Uint("period", 0, "the length of the submission and dispute periods in seconds, 0 for evaluation mode")
+	stake := fs.String("stake", "0", "the stake in wei each participant deposits at registration")
+	bytecode := fs.String("bytecode", "", "directory of the contracts compiled by scripts/build.sh, e.g. build/<participants>/contracts")
+	out := fs.String("o", "", "filename of the deployment manifest (default the Deployment of the config)")
+	fs.Parse(args)
+
+	if *participants == 0 || *participants > 0xffff || *userThreshold > 0xffff || *periodLength > 0xffff {
+		return errors.New("-participants, -user-threshold and -period have to fit into uint16, with at least one participant")
+	}
+	if *bytecode == "" {
+		return errors.New("missing -bytecode")
+	}
+
+	// The manifest referenced by the config doesn't exist before the first deployment
+	config, err := dkg.ReadConfig(*configFile)
+	if err != nil {
+		return err
+	}
+	if *out == "" {
+		*out = config.Deployment
+	}
+	if *out == "" {
+		return errors.New("missing -o and no Deployment in the config")
+	}
+
+	params := dkg.DeploymentParams{
+		NoParticipants: uint16(*participants),
+		UserThreshold:  uint16(*userThreshold),
+		PeriodLength:   uint16(*periodLength),
+	}
+	var ok bool
+	if params.Stake, ok = new(big.Int).SetString(*stake, 10); !ok {
+		return fmt.Errorf("invalid -stake %q", *stake)
+	}
+	if params.UserThreshold == 0 {
+		params.UserThreshold = dkg.DefaultUserThreshold(params.NoParticipants)
+	}
+
+	ctx := context.Background()
+	client, err := dkg.DialEndpoints(ctx, config.Endpoints())
+	if err != nil {
+		return fmt.Errorf("dial eth client: %w", err)
+	}
+	chainID, err := client.ChainID(ctx)
+	if err != nil {
+		return fmt.Errorf("chainID: %w", err)
+	}
+	key, err := crypto.HexToECDSA(config.EthereumPrivateKey)
+	if err != nil {
+		return fmt.Errorf("hex to ecdsa: %w", err)
+	}
+	opts, err := bind.NewKeyedTransactorWithChainID(key, chainID)
+	if err != nil {
+		return fmt.Errorf("transactor: %w", err)
+	}
+
+	deployment, err := dkg.Deploy(ctx, client, opts, *bytecode, params)
+	if err != nil {
+		return err
+	}
+	deployment.ChainID = chainID
+	if err := deployment.Write(*out); err != nil {
+		return err
+	}
+
+	log.Infof("zkDKG deployed to %s in block %d, wrote %s", deployment.ZKDKG, deployment.Block, *out)
+	return nil
+}
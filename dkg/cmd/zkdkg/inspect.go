@@ -0,0 +1,90 @@
+package main
+
+import (
+	"client/internal/pkg/group/curve25519"
+	"client/pkg/dkg"
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+type inspectOutput struct {
+	Method string                 `json:"method"`
+	Inputs map[string]interface{} `json:"inputs"`
+
+	// Coordinates of the commitments of broadcastShares
+	Commitments [][2]*big.Int `json:"commitments,omitempty"`
+}
+
+func inspect(args []string) error {
+	fs, configFile := newFlagSet("inspect [tx hash]", true)
+	data := fs.String("data", "", "hex-encoded calldata to decode instead of fetching a transaction")
+	fs.Parse(args)
+
+	var calldata []byte
+	switch {
+	case *data != "":
+		b, err := hexutil.Decode(*data)
+		if err != nil {
+			return fmt.Errorf("decode calldata: %w", err)
+		}
+		calldata = b
+	case fs.NArg() == 1:
+		config, err := dkg.LoadConfig(*configFile)
+		if err != nil {
+			return err
+		}
+		client, _, err := dial(config)
+		if err != nil {
+			return err
+		}
+		tx, err := client.TransactionByHash(context.Background(), common.HexToHash(fs.Arg(0)))
+		if err != nil {
+			return fmt.Errorf("transaction by hash: %w", err)
+		}
+		calldata = tx.Data()
+	default:
+		fs.Usage()
+		return errors.New("expected a transaction hash or -data")
+	}
+
+	contractAbi, err := abi.JSON(strings.NewReader(dkg.ZKDKGContractABI))
+	if err != nil {
+		return fmt.Errorf("parse abi: %w", err)
+	}
+	method, inputs, err := dkg.DecodeCall(contractAbi, calldata)
+	if err != nil {
+		return err
+	}
+
+	out := &inspectOutput{
+		Method: method.Name,
+		Inputs: make(map[string]interface{}, len(inputs)),
+	}
+	for i, arg := range method.Inputs {
+		out.Inputs[arg.Name] = inputs[i]
+	}
+
+	if method.Name == "broadcastShares" {
+		suite := curve25519.NewBlakeSHA256BabyJubJub(false)
+		commitments, err := dkg.BigToPoints(suite, inputs[0].([]*big.Int))
+		if err != nil {
+			return fmt.Errorf("decode commitments: %w", err)
+		}
+		for i, c := range commitments {
+			xy, err := dkg.PointToBigUncompressed(c)
+			if err != nil {
+				return fmt.Errorf("commitment %d: %w", i, err)
+			}
+			out.Commitments = append(out.Commitments, xy)
+		}
+	}
+
+	return printJSON(out)
+}
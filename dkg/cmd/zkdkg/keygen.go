@@ -0,0 +1,50 @@
+package main
+
+import (
+	"client/internal/pkg/group/curve25519"
+	"client/pkg/dkg"
+	"client/pkg/zk"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+)
+
+type keygenOutput struct {
+	// Hex-encoded private key, as expected by DkgPrivateKey of the config
+	PrivateKey string `json:"privateKey"`
+
+	// Public key arguments of register and registerCompressed of the contract, which also take a proof of possession
+	PublicKey           [2]*big.Int `json:"publicKey"`
+	CompressedPublicKey *big.Int    `json:"compressedPublicKey"`
+}
+
+func keygen(args []string) error {
+	fs, _ := newFlagSet("keygen", false)
+	fs.Parse(args)
+
+	suite := curve25519.NewBlakeSHA256BabyJubJub(false)
+	priv := suite.Scalar().Pick(suite.RandomStream())
+
+	b, err := priv.MarshalBinary()
+	if err != nil {
+		return fmt.Errorf("marshal private key: %w", err)
+	}
+	pub, err := dkg.PointToBigUncompressed(suite.Point().Mul(priv, nil))
+	if err != nil {
+		return fmt.Errorf("public key coordinates: %w", err)
+	}
+
+	return printJSON(&keygenOutput{
+		PrivateKey:          hex.EncodeToString(b),
+		PublicKey:           pub,
+		CompressedPublicKey: zk.Compress(pub[0], pub[1]),
+	})
+}
+
+func printJSON(v interface{}) error {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
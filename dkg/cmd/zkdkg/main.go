@@ -0,0 +1,64 @@
+// Command zkdkg is the command line interface of the zkDKG client.
+package main
+
+import (
+	"client/pkg/dkg"
+	"flag"
+	"fmt"
+	"os"
+
+	log "github.com/sirupsen/logrus"
+)
+
+type command struct {
+	name  string
+	usage string
+	run   func(args []string) error
+}
+
+var commands = []command{
+	{"run", "take part in the distributed key generation", run},
+	{"deploy", "deploy the verifier and zkDKG contracts and write a deployment manifest", deploy},
+	{"keygen", "create a DKG key and print its public key in contract format", keygen},
+	{"status", "show the state of a deployed ZKDKG contract", status},
+	{"inspect", "decode the inputs of a transaction to the ZKDKG contract", inspect},
+	{"artifacts", "record, verify or fetch the proving artifacts", artifactsCommand},
+}
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "usage: zkdkg <command> [flags]\n\nCommands:\n")
+	for _, c := range commands {
+		fmt.Fprintf(os.Stderr, "  %-10s %s\n", c.name, c.usage)
+	}
+	fmt.Fprintf(os.Stderr, "\nRun 'zkdkg <command> -h' for the flags of a command.\n")
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+
+	for _, c := range commands {
+		if c.name == os.Args[1] {
+			if err := c.run(os.Args[2:]); err != nil {
+				log.Errorf("%s: %v", c.name, err)
+				os.Exit(dkg.ExitCode(err))
+			}
+			return
+		}
+	}
+
+	usage()
+	os.Exit(2)
+}
+
+// newFlagSet returns the flag set of a command, with the config file flag if withConfig is set.
+func newFlagSet(name string, withConfig bool) (*flag.FlagSet, *string) {
+	fs := flag.NewFlagSet("zkdkg "+name, flag.ExitOnError)
+	var configFile *string
+	if withConfig {
+		configFile = fs.String("c", "./configs/config.json", "filename of the config file")
+	}
+	return fs, configFile
+}
@@ -0,0 +1,8 @@
+package main
+
+import "client/internal/pkg/node"
+
+func run(args []string) error {
+	fs, _ := newFlagSet("run", false)
+	return node.Run(fs, args)
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"client/pkg/dkg"
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func status(args []string) error {
+	fs, configFile := newFlagSet("status", true)
+	fs.Parse(args)
+
+	config, err := dkg.LoadConfig(*configFile)
+	if err != nil {
+		return err
+	}
+	_, contract, err := dial(config)
+	if err != nil {
+		return err
+	}
+
+	s, err := dkg.QueryStatus(context.Background(), contract, config.DeploymentBlock)
+	if err != nil {
+		return fmt.Errorf("query status: %w", err)
+	}
+	return printJSON(s)
+}
+
+func dial(config *dkg.Config) (*dkg.Endpoints, *dkg.ZKDKGContract, error) {
+	client, err := dkg.DialEndpoints(context.Background(), config.Endpoints())
+	if err != nil {
+		return nil, nil, fmt.Errorf("dial eth client: %w", err)
+	}
+
+	contract, err := dkg.NewZKDKGContract(common.HexToAddress(config.ContractAddress), client)
+	if err != nil {
+		return nil, nil, fmt.Errorf("zkDKG contract: %w", err)
+	}
+	return client, contract, nil
+}
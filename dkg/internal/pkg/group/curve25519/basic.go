@@ -4,12 +4,12 @@
 package curve25519
 
 import (
+	"client/internal/pkg/group/internal/marshalling"
 	"crypto/cipher"
 	"io"
 	"math/big"
 
 	"go.dedis.ch/kyber/v3"
-	"go.dedis.ch/kyber/v3/group/internal/marshalling"
 	"go.dedis.ch/kyber/v3/group/mod"
 )
 
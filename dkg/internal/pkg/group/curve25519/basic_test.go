@@ -60,6 +60,29 @@ func TestCompareBasicProjectiveE521(t *testing.T) {
 	}
 }
 
+// Fuzz BasicCurve versus the other implementations
+
+func basicFuzzGroups(p *Param) []fuzzGroup {
+	basic := new(BasicCurve).Init(p, false)
+	return append(fuzzGroups(p, false), fuzzGroup{"basic", basic, &basic.curve})
+}
+
+func FuzzUnmarshalBinaryBasic25519(f *testing.F) {
+	fuzzUnmarshalBinary(f, basicFuzzGroups(Param25519()))
+}
+
+func FuzzUnmarshalBinaryBasicBabyJubJub(f *testing.F) {
+	fuzzUnmarshalBinary(f, basicFuzzGroups(ParamBabyJubJub()))
+}
+
+func FuzzPointRoundTripBasic25519(f *testing.F) {
+	fuzzPointRoundTrip(f, basicFuzzGroups(Param25519()))
+}
+
+func FuzzPointRoundTripBasicBabyJubJub(f *testing.F) {
+	fuzzPointRoundTrip(f, basicFuzzGroups(ParamBabyJubJub()))
+}
+
 // Benchmark contrasting implementations of the Ed25519 curve
 
 var basicBench = test.NewGroupBench(new(BasicCurve).Init(Param25519(), false))
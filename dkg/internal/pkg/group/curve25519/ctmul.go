@@ -0,0 +1,236 @@
+package curve25519
+
+import (
+	"math/big"
+	"sync"
+
+	"client/internal/pkg/group/fr"
+
+	"go.dedis.ch/kyber/v3"
+)
+
+// Window width of the constant-time scalar multiplication.
+const ctWidth = 4
+
+// Number of windows covering a 256-bit scalar.
+const ctWindows = 256 / ctWidth
+
+// Twisted Edwards point in projective coordinates (X:Y:Z) over the BN254 scalar field
+type frPoint struct {
+	X, Y, Z fr.Element
+}
+
+// Scalar multiplication with fixed-limb arithmetic for curves over the BN254 scalar field,
+// like BabyJubJub. In the constant-time variants every scalar is processed as 256 bits in fixed windows, table entries are selected
+// by scanning the whole table, and the addition formulas are complete,
+// so neither the running time nor the memory access pattern depend on the scalar.
+type ctCurve struct {
+	a, d fr.Element
+	mod  *big.Int // Multiple of the order of every point on the curve
+
+	once sync.Once
+	base [ctWindows][1 << ctWidth]frPoint // Entry [i][j] holds j * 2^(ctWidth*i) * B
+}
+
+// newCTCurve returns the fixed-limb arithmetic for the curve,
+// or nil if the curve isn't defined over the BN254 scalar field
+// or its addition formulas aren't complete.
+func newCTCurve(p *Param) *ctCurve {
+	if p.P.Cmp(fr.Modulus()) != 0 {
+		return nil
+	}
+	// Complete if a is a square and d is a nonsquare
+	if big.Jacobi(&p.A, &p.P) != 1 || big.Jacobi(&p.D, &p.P) != -1 {
+		return nil
+	}
+
+	ct := new(ctCurve)
+	ct.a.SetBigInt(&p.A)
+	ct.d.SetBigInt(&p.D)
+	ct.mod = new(big.Int).Mul(&p.Q, big.NewInt(int64(p.R)))
+	return ct
+}
+
+func (ct *ctCurve) null() frPoint {
+	var P frPoint
+	P.Y.SetOne()
+	P.Z.SetOne()
+	return P
+}
+
+// Same formulas as ProjPoint.Add
+func (ct *ctCurve) add(P, P1, P2 *frPoint) {
+	X1, Y1, Z1 := &P1.X, &P1.Y, &P1.Z
+	X2, Y2, Z2 := &P2.X, &P2.Y, &P2.Z
+	var A, B, C, D, E, F, G, X3, Y3, Z3 fr.Element
+
+	A.Mul(Z1, Z2)
+	B.Square(&A)
+	C.Mul(X1, X2)
+	D.Mul(Y1, Y2)
+	E.Mul(&C, &D).Mul(&ct.d, &E)
+	F.Sub(&B, &E)
+	G.Add(&B, &E)
+	X3.Add(X1, Y1).Mul(&X3, Z3.Add(X2, Y2)).Sub(&X3, &C).Sub(&X3, &D).
+		Mul(&F, &X3).Mul(&A, &X3)
+	Y3.Mul(&ct.a, &C).Sub(&D, &Y3).Mul(&G, &Y3).Mul(&A, &Y3)
+	Z3.Mul(&F, &G)
+
+	P.X, P.Y, P.Z = X3, Y3, Z3
+}
+
+// Same formulas as ProjPoint.double
+func (ct *ctCurve) double(P *frPoint) {
+	var B, C, D, E, F, H, J fr.Element
+
+	B.Add(&P.X, &P.Y).Square(&B)
+	C.Square(&P.X)
+	D.Square(&P.Y)
+	E.Mul(&ct.a, &C)
+	F.Add(&E, &D)
+	H.Square(&P.Z)
+	J.Double(&H).Sub(&F, &J)
+	P.X.Sub(&B, &C).Sub(&P.X, &D).Mul(&P.X, &J)
+	P.Y.Sub(&E, &D).Mul(&F, &P.Y)
+	P.Z.Mul(&F, &J)
+}
+
+// lookup sets P to table[idx] without revealing idx through memory accesses.
+func (ct *ctCurve) lookup(P *frPoint, table *[1 << ctWidth]frPoint, idx uint64) {
+	for i := range table {
+		// 1 iff i == idx
+		eq := int(1 ^ ((uint64(i)^idx)|-(uint64(i)^idx))>>63)
+		P.X.Select(eq, &table[i].X, &P.X)
+		P.Y.Select(eq, &table[i].Y, &P.Y)
+		P.Z.Select(eq, &table[i].Z, &P.Z)
+	}
+}
+
+// limbs returns v as a 256-bit scalar, least-significant limb first.
+// Only scalars exceeding 256 bits are reduced, taking variable time.
+func (ct *ctCurve) limbs(v *big.Int) [4]uint64 {
+	if v.BitLen() > 256 {
+		v = new(big.Int).Mod(v, ct.mod)
+	}
+	var k [4]uint64
+	b := v.FillBytes(make([]byte, 32))
+	for i := 0; i < 32; i++ {
+		k[i/8] |= uint64(b[31-i]) << (8 * (i % 8))
+	}
+	return k
+}
+
+func digit(k *[4]uint64, i int) uint64 {
+	return (k[i*ctWidth/64] >> (i * ctWidth % 64)) & (1<<ctWidth - 1)
+}
+
+// mul returns v*G.
+func (ct *ctCurve) mul(G *frPoint, v *big.Int) frPoint {
+	k := ct.limbs(v)
+
+	var table [1 << ctWidth]frPoint
+	table[0] = ct.null()
+	table[1] = *G
+	for i := 2; i < len(table); i++ {
+		ct.add(&table[i], &table[i-1], G)
+	}
+
+	T := ct.null()
+	var R frPoint
+	for i := ctWindows - 1; i >= 0; i-- {
+		for j := 0; j < ctWidth; j++ {
+			ct.double(&T)
+		}
+		ct.lookup(&R, &table, digit(&k, i))
+		ct.add(&T, &T, &R)
+	}
+	return T
+}
+
+// initBase computes the table of multiples of B on the first call.
+func (ct *ctCurve) initBase(B *frPoint) {
+	ct.once.Do(func() {
+		P := *B
+		for i := range ct.base {
+			row := &ct.base[i]
+			row[0] = ct.null()
+			row[1] = P
+			for j := 2; j < len(row); j++ {
+				ct.add(&row[j], &row[j-1], &P)
+			}
+			for j := 0; j < ctWidth; j++ {
+				ct.double(&P)
+			}
+		}
+	})
+}
+
+// mulBase returns v*B.
+func (ct *ctCurve) mulBase(B *frPoint, v *big.Int) frPoint {
+	ct.initBase(B)
+
+	k := ct.limbs(v)
+	T := ct.null()
+	var R frPoint
+	for i := range ct.base {
+		ct.lookup(&R, &ct.base[i], digit(&k, i))
+		ct.add(&T, &T, &R)
+	}
+	return T
+}
+
+// mulBaseVartime returns v*B, skipping zero digits and indexing the table directly.
+func (ct *ctCurve) mulBaseVartime(B *frPoint, v *big.Int) frPoint {
+	ct.initBase(B)
+
+	k := ct.limbs(v)
+	T := ct.null()
+	for i := range ct.base {
+		if d := digit(&k, i); d != 0 {
+			ct.add(&T, &T, &ct.base[i][d])
+		}
+	}
+	return T
+}
+
+// mulVartime returns v*G using the non-adjacent form of v.
+func (ct *ctCurve) mulVartime(G *frPoint, v *big.Int) frPoint {
+	naf := wnaf(v, wnafWidth)
+
+	// Odd multiples G, 3G, 5G, ... of the base
+	var odd [1 << (wnafWidth - 2)]frPoint
+	odd[0] = *G
+	twice := *G
+	ct.double(&twice)
+	for i := 1; i < len(odd); i++ {
+		ct.add(&odd[i], &odd[i-1], &twice)
+	}
+
+	T := ct.null()
+	for i := len(naf) - 1; i >= 0; i-- {
+		ct.double(&T)
+		if d := naf[i]; d > 0 {
+			ct.add(&T, &T, &odd[d>>1])
+		} else if d < 0 {
+			R := odd[(-d)>>1]
+			R.X.Neg(&R.X)
+			ct.add(&T, &T, &R)
+		}
+	}
+	return T
+}
+
+// PublicMultiplier is implemented by points whose Mul runs in constant time,
+// and which offer a faster variable-time multiplication for public scalars.
+type PublicMultiplier interface {
+	MulPublic(s kyber.Scalar, G kyber.Point) kyber.Point
+}
+
+// MulPublic sets P to s*G in variable time if supported and returns P.
+// It must only be used if the scalar isn't secret, e.g. when verifying.
+func MulPublic(P kyber.Point, s kyber.Scalar, G kyber.Point) kyber.Point {
+	if pm, ok := P.(PublicMultiplier); ok {
+		return pm.MulPublic(s, G)
+	}
+	return P.Mul(s, G)
+}
@@ -32,11 +32,15 @@ type curve struct {
 	zero, one mod.Int     // Constant ModInts with correct modulus
 	a, d      mod.Int     // Curve equation parameters as ModInts
 	full      bool        // True if we're using the full group
+	checked   bool        // True if decoding checks prime-order subgroup membership
 
 	order  mod.Int // Order of appropriate subgroup as a ModInt
 	cofact mod.Int // Group's cofactor as a ModInt
 
 	null kyber.Point // Identity point for this group
+
+	fixed *fixedBase // Lazily precomputed multiples of the base point
+	ct    *ctCurve   // Constant-time arithmetic, nil if unsupported by the curve
 }
 
 func (c *curve) String() string {
@@ -89,6 +93,8 @@ func (c *curve) init(self kyber.Group, p *Param, fullGroup bool,
 	c.Param = *p
 	c.full = fullGroup
 	c.null = null
+	c.fixed = new(fixedBase)
+	c.ct = newCTCurve(p)
 
 	// Edwards curve parameters as ModInts for convenience
 	c.a.Init(&p.A, &p.P)
@@ -194,18 +200,10 @@ func (c *curve) encodePoint(x, y *mod.Int) []byte {
 	return b
 }
 
-/*func (c *curve) encodePoint(x, y *mod.Int) []byte {
-
-	// Encode the y-coordinate
-	bY, _ := y.MarshalBinary()
-	bX, _ := x.MarshalBinary()
-
-	return append(bX, bY...)
-}*/
-
 // Decode an Edwards curve point into the given x,y coordinates.
 // Returns an error if the input does not denote a valid curve point.
-// Note that this does NOT check if the point is in the prime-order subgroup:
+// Note that this does NOT check if the point is in the prime-order subgroup
+// unless enabled with SetSubgroupCheck:
 // an adversary could create an encoding denoting a point
 // on the twist of the curve, or in a larger subgroup.
 // However, the "safecurves" criteria (http://safecurves.cr.yp.to)
@@ -213,7 +211,12 @@ func (c *curve) encodePoint(x, y *mod.Int) []byte {
 // other than the tiny ones represented by the cofactor;
 // hence Diffie-Hellman exchange can be done without subgroup checking
 // without exposing more than the least-significant bits of the scalar.
+// Curves that don't meet these criteria or whose points are checked
+// in zero-knowledge proofs, like BabyJubJub, should enable the check.
 func (c *curve) decodePoint(bb []byte, x, y *mod.Int) error {
+	if len(bb) != c.PointLen() {
+		return errors.New("invalid elliptic curve point encoding length")
+	}
 	b := make([]byte, len(bb))
 	copy(b, bb)
 
@@ -224,27 +227,45 @@ func (c *curve) decodePoint(bb []byte, x, y *mod.Int) error {
 	// Extract the y-coordinate
 	y.V.SetBytes(b)
 	y.M = &c.P
+	if y.V.Cmp(&c.P) >= 0 {
+		return errors.New("non-canonical elliptic curve point encoding")
+	}
 
 	// Compute the corresponding x-coordinate
 	if !c.solveForX(x, y) {
 		return errors.New("invalid elliptic curve point")
 	}
 	if c.coordSign(x) != xsign {
+		if x.V.Sign() == 0 {
+			// x = 0 has no negative, so it must be encoded with a clear sign bit
+			return errors.New("non-canonical elliptic curve point encoding")
+		}
 		x.Neg(x)
 	}
 
+	if c.checked && !c.inSubgroup(x, y) {
+		return errors.New("elliptic curve point not in prime-order subgroup")
+	}
+
 	return nil
 }
 
-/*func (c *curve) decodePoint(bb []byte, x, y *mod.Int) error {
-	x.SetBytes(bb[:32])
-	y.SetBytes(bb[32:])
+// SetSubgroupCheck sets whether decoding rejects points
+// outside of the prime-order subgroup, e.g. small-order points.
+func (c *curve) SetSubgroupCheck(check bool) {
+	c.checked = check
+}
 
-	if !c.onCurve(x, y) {
-		return errors.New("invalid point")
-	}
-	return nil
-}*/
+// Test if the point with the given coordinates lies in the prime-order subgroup
+// by multiplying it by the subgroup order. The point must be on the curve.
+func (c *curve) inSubgroup(x, y *mod.Int) bool {
+	var q mod.Int
+	q.V.Set(&c.Q)
+
+	P := c.self.Point().(point)
+	P.initXY(&x.V, &y.V, c.self)
+	return MulPublic(c.self.Point(), &q, P).Equal(c.null)
+}
 
 // Given a y-coordinate, solve for the x-coordinate on the curve,
 // using the characteristic equation rewritten as:
@@ -337,6 +358,10 @@ func (c *curve) embed(P point, data []byte, rand cipher.Stream) {
 		xsign := b[0] >> 7                    // save x-coordinate sign bit
 		b[0] &^= 0xff << uint(c.P.BitLen()&7) // clear high bits
 
+		if data != nil && new(big.Int).SetBytes(b).Cmp(&c.P) >= 0 {
+			continue // reducing y would corrupt the data
+		}
+
 		y.M = &c.P // set y-coordinate
 		y.SetBytes(b)
 
@@ -389,6 +414,7 @@ func (c *curve) embed(P point, data []byte, rand cipher.Stream) {
 // or an error if embedded data is invalid or not present.
 func (c *curve) data(x, y *mod.Int) ([]byte, error) {
 	b := c.encodePoint(x, y)
+	reverse(b, b) // Convert to little-endian form, see embed
 	dl := int(b[0])
 	if dl > c.embedLen() {
 		return nil, errors.New("invalid embedded data length")
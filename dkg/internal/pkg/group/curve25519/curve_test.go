@@ -3,7 +3,9 @@ package curve25519
 import (
 	"testing"
 
+	"go.dedis.ch/kyber/v3"
 	"go.dedis.ch/kyber/v3/group/edwards25519"
+	"go.dedis.ch/kyber/v3/share"
 	"go.dedis.ch/kyber/v3/util/test"
 )
 
@@ -45,6 +47,10 @@ func TestE521(t *testing.T) {
 	test.GroupTest(t, new(ExtendedCurve).Init(ParamE521(), false))
 }
 
+func TestBabyJubJub(t *testing.T) {
+	test.GroupTest(t, new(ExtendedCurve).Init(ParamBabyJubJub(), false))
+}
+
 func TestSetBytesBE(t *testing.T) {
 	g := new(ExtendedCurve).Init(ParamE521(), false)
 	s := g.Scalar()
@@ -79,6 +85,16 @@ func TestFullOrderE521(t *testing.T) {
 	test.GroupTest(t, new(ExtendedCurve).Init(ParamE521(), true))
 }
 
+func TestFullOrderBabyJubJub(t *testing.T) {
+	test.GroupTest(t, new(ExtendedCurve).Init(ParamBabyJubJub(), true))
+}
+
+// Test the suites of each curve
+
+func TestSuiteBabyJubJub(t *testing.T) {
+	test.SuiteTest(t, NewBlakeSHA256BabyJubJub(false))
+}
+
 // Test ExtendedCurve versus ProjectiveCurve implementations
 
 func TestCompareProjectiveExtended25519(t *testing.T) {
@@ -105,6 +121,24 @@ func TestCompareProjectiveExtendedE521(t *testing.T) {
 		new(ExtendedCurve).Init(ParamE521(), false))
 }
 
+// Test FrCurve versus ProjectiveCurve implementations of BabyJubJub
+
+func TestFrCurve(t *testing.T) {
+	test.GroupTest(t, new(FrCurve).Init(ParamBabyJubJub(), false))
+}
+
+func TestCompareProjectiveFr(t *testing.T) {
+	test.CompareGroups(t, testSuite.XOF,
+		new(ProjectiveCurve).Init(ParamBabyJubJub(), false),
+		new(FrCurve).Init(ParamBabyJubJub(), false))
+}
+
+func TestCompareProjectiveFrFull(t *testing.T) {
+	test.CompareGroups(t, testSuite.XOF,
+		new(ProjectiveCurve).Init(ParamBabyJubJub(), true),
+		new(FrCurve).Init(ParamBabyJubJub(), true))
+}
+
 // Test Ed25519 versus ExtendedCurve implementations of Curve25519.
 func TestCompareEd25519(t *testing.T) {
 	test.CompareGroups(t, testSuite.XOF,
@@ -141,3 +175,126 @@ func BenchmarkPointDecodeOptimized(b *testing.B)  { optBench.PointDecode(b.N) }
 func BenchmarkPointPickProjective(b *testing.B) { projBench.PointPick(b.N) }
 func BenchmarkPointPickExtended(b *testing.B)   { extBench.PointPick(b.N) }
 func BenchmarkPointPickOptimized(b *testing.B)  { optBench.PointPick(b.N) }
+
+// Benchmark contrasting implementations of the BabyJubJub curve
+
+var bjjProjBench = test.NewGroupBench(new(ProjectiveCurve).Init(ParamBabyJubJub(), false))
+var bjjFrBench = test.NewGroupBench(new(FrCurve).Init(ParamBabyJubJub(), false))
+
+func BenchmarkPointAddBabyJubJubProjective(b *testing.B) { bjjProjBench.PointAdd(b.N) }
+func BenchmarkPointAddBabyJubJubFr(b *testing.B)         { bjjFrBench.PointAdd(b.N) }
+
+func BenchmarkPointMulBabyJubJubProjective(b *testing.B) { bjjProjBench.PointMul(b.N) }
+func BenchmarkPointMulBabyJubJubFr(b *testing.B)         { bjjFrBench.PointMul(b.N) }
+
+func BenchmarkPointBaseMulBabyJubJubProjective(b *testing.B) { bjjProjBench.PointBaseMul(b.N) }
+func BenchmarkPointBaseMulBabyJubJubFr(b *testing.B)         { bjjFrBench.PointBaseMul(b.N) }
+
+func BenchmarkPointEncodeBabyJubJubProjective(b *testing.B) { bjjProjBench.PointEncode(b.N) }
+func BenchmarkPointEncodeBabyJubJubFr(b *testing.B)         { bjjFrBench.PointEncode(b.N) }
+
+// Share generation and verification as done in the DKG with 16 participants
+
+func benchmarkShares(b *testing.B, g kyber.Group) {
+	const n, threshold = 16, 9
+	stream := testSuite.XOF([]byte("shares"))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		priPoly := share.NewPriPoly(g, threshold, nil, stream)
+		pubPoly := priPoly.Commit(nil)
+		for _, s := range priPoly.Shares(n) {
+			if !pubPoly.Check(s) {
+				b.Fatal("invalid share")
+			}
+		}
+	}
+}
+
+func BenchmarkSharesBabyJubJubProjective(b *testing.B) {
+	benchmarkShares(b, new(ProjectiveCurve).Init(ParamBabyJubJub(), false))
+}
+
+func BenchmarkSharesBabyJubJubFr(b *testing.B) {
+	benchmarkShares(b, new(FrCurve).Init(ParamBabyJubJub(), false))
+}
+
+// Benchmark multi-scalar multiplication against summing up single multiplications
+
+func benchmarkMultiScalarMul(b *testing.B, g kyber.Group, n int) {
+	scalars, points := randomMultiScalarMulInput(g, n)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := MultiScalarMul(scalars, points); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func benchmarkNaiveMultiScalarMul(b *testing.B, g kyber.Group, n int) {
+	scalars, points := randomMultiScalarMulInput(g, n)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		naiveMultiScalarMul(scalars, points)
+	}
+}
+
+var projCurve = new(ProjectiveCurve).Init(Param25519(), false)
+var extCurve = new(ExtendedCurve).Init(Param25519(), false)
+
+func BenchmarkMultiScalarMulProjective16(b *testing.B)  { benchmarkMultiScalarMul(b, projCurve, 16) }
+func BenchmarkMultiScalarMulProjective256(b *testing.B) { benchmarkMultiScalarMul(b, projCurve, 256) }
+func BenchmarkMultiScalarMulExtended16(b *testing.B)    { benchmarkMultiScalarMul(b, extCurve, 16) }
+func BenchmarkMultiScalarMulExtended256(b *testing.B)   { benchmarkMultiScalarMul(b, extCurve, 256) }
+
+func BenchmarkNaiveMultiScalarMulProjective16(b *testing.B) {
+	benchmarkNaiveMultiScalarMul(b, projCurve, 16)
+}
+func BenchmarkNaiveMultiScalarMulProjective256(b *testing.B) {
+	benchmarkNaiveMultiScalarMul(b, projCurve, 256)
+}
+func BenchmarkNaiveMultiScalarMulExtended16(b *testing.B) {
+	benchmarkNaiveMultiScalarMul(b, extCurve, 16)
+}
+func BenchmarkNaiveMultiScalarMulExtended256(b *testing.B) {
+	benchmarkNaiveMultiScalarMul(b, extCurve, 256)
+}
+
+// Benchmark constant-time against variable-time multiplication with secret scalars
+
+var bjjCurve = new(ProjectiveCurve).Init(ParamBabyJubJub(), false)
+
+func BenchmarkMulConstantTime(b *testing.B) {
+	s := bjjCurve.Scalar().Pick(testSuite.RandomStream())
+	P := bjjCurve.Point().Pick(testSuite.RandomStream())
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		P.Mul(s, P)
+	}
+}
+
+func BenchmarkMulPublic(b *testing.B) {
+	s := bjjCurve.Scalar().Pick(testSuite.RandomStream())
+	P := bjjCurve.Point().Pick(testSuite.RandomStream())
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		P.(PublicMultiplier).MulPublic(s, P)
+	}
+}
+
+func BenchmarkBaseMulConstantTime(b *testing.B) {
+	s := bjjCurve.Scalar().Pick(testSuite.RandomStream())
+	P := bjjCurve.Point()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		P.Mul(s, nil)
+	}
+}
+
+func BenchmarkBaseMulPublic(b *testing.B) {
+	s := bjjCurve.Scalar().Pick(testSuite.RandomStream())
+	P := bjjCurve.Point()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		P.(PublicMultiplier).MulPublic(s, nil)
+	}
+}
@@ -1,6 +1,7 @@
 package curve25519
 
 import (
+	"client/internal/pkg/group/fr"
 	"client/internal/pkg/group/internal/marshalling"
 	"crypto/cipher"
 	"encoding/hex"
@@ -225,34 +226,80 @@ func (P *extPoint) double() {
 	Z1.Mul(&F, &G)
 }
 
-// Multiply point p by scalar s using the repeated doubling method.
-//
-// Currently doesn't implement the optimization of
-// switching between projective and extended coordinates during
-// scalar multiplication.
-//
+// Multiply point p by scalar s.
+// On curves over the BN254 scalar field like BabyJubJub this runs in constant time,
+// so it is safe to use with secret scalars. On other curves it is the same as MulPublic.
 func (P *extPoint) Mul(s kyber.Scalar, G kyber.Point) kyber.Point {
-	v := s.(*mod.Int).V
-	if G == nil {
-		return P.Base().Mul(s, P)
+	ct := P.c.ct
+	if ct == nil {
+		return P.MulPublic(s, G)
 	}
-	T := P
-	if G == P { // Must use temporary for in-place multiply
-		T = &extPoint{}
+
+	v := &s.(*mod.Int).V
+	var R frPoint
+	if G == nil {
+		B := P.c.base.toFr()
+		R = ct.mulBase(&B, v)
+	} else {
+		Q := G.(*extPoint).toFr()
+		R = ct.mul(&Q, v)
 	}
-	T.Set(&P.c.null) // Initialize to identity element (0,1)
-	for i := v.BitLen() - 1; i >= 0; i-- {
-		T.double()
-		if v.Bit(i) != 0 {
-			T.Add(T, G)
+	P.setFr(&R)
+	return P
+}
+
+// MulPublic multiplies point p by scalar s in variable time.
+// Multiples of the standard base point are computed from a precomputed table,
+// any other point is multiplied using the non-adjacent form of the scalar.
+func (P *extPoint) MulPublic(s kyber.Scalar, G kyber.Point) kyber.Point {
+	v := &s.(*mod.Int).V
+	if ct := P.c.ct; ct != nil {
+		var R frPoint
+		if G == nil {
+			B := P.c.base.toFr()
+			R = ct.mulBaseVartime(&B, v)
+		} else {
+			Q := G.(*extPoint).toFr()
+			R = ct.mulVartime(&Q, v)
 		}
+		P.setFr(&R)
+		return P
 	}
-	if T != P {
-		P.Set(T)
+
+	if G == nil {
+		P.c.fixed.init(&P.c.base, &P.c.order.V)
+		P.c.fixed.mul(P, &P.c.null, v)
+		return P
 	}
+	// The multiples of G are precomputed before P is written,
+	// so no temporary is needed for in-place multiplication.
+	mulWNAF(P, G.(*extPoint), &P.c.null, v)
 	return P
 }
 
+// toFr converts P to the representation used by constant-time arithmetic.
+func (P *extPoint) toFr() frPoint {
+	var R frPoint
+	R.X.SetBigInt(&P.X.V)
+	R.Y.SetBigInt(&P.Y.V)
+	R.Z.SetBigInt(&P.Z.V)
+	return R
+}
+
+// setFr sets P to the point R computed by constant-time arithmetic.
+func (P *extPoint) setFr(R *frPoint) {
+	// Extended coordinates (XZ:YZ:Z^2:XY) of (X:Y:Z)
+	var X, Y, Z, T fr.Element
+	X.Mul(&R.X, &R.Z)
+	Y.Mul(&R.Y, &R.Z)
+	Z.Square(&R.Z)
+	T.Mul(&R.X, &R.Y)
+	P.X.Init(X.BigInt(&P.X.V), &P.c.P)
+	P.Y.Init(Y.BigInt(&P.Y.V), &P.c.P)
+	P.Z.Init(Z.BigInt(&P.Z.V), &P.c.P)
+	P.T.Init(T.BigInt(&P.T.V), &P.c.P)
+}
+
 // ExtendedCurve implements Twisted Edwards curves
 // using projective coordinate representation (X:Y:Z),
 // satisfying the identities x = X/Z, y = Y/Z.
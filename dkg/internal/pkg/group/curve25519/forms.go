@@ -0,0 +1,242 @@
+package curve25519
+
+import (
+	"errors"
+	"math/big"
+
+	"go.dedis.ch/kyber/v3"
+)
+
+// Birational maps between the twisted Edwards form of a curve
+//
+//	a*x^2 + y^2 = 1 + d*x^2*y^2
+//
+// and the Montgomery form
+//
+//	B*v^2 = u^3 + A*u^2 + u
+//
+// and short Weierstrass form
+//
+//	y^2 = x^3 + a*x + b
+//
+// of the same curve, see Bernstein et al, "Twisted Edwards Curves", section 3,
+// http://eprint.iacr.org/2008/013.pdf
+//
+// The identity (0,1) of the Edwards curve corresponds to the point at infinity
+// of the other forms, and (0,-1) to the Montgomery point (0,0).
+// Montgomery points with v = 0 or u = -1 otherwise have no affine Edwards counterpart,
+// which can't happen for complete twisted Edwards curves like all curves in this package.
+
+// MontgomeryParam defines a Montgomery curve B*v^2 = u^3 + A*u^2 + u.
+type MontgomeryParam struct {
+	P    big.Int // Prime defining the underlying field
+	A, B big.Int // Montgomery curve equation parameters
+}
+
+// WeierstrassParam defines a short Weierstrass curve y^2 = x^3 + a*x + b.
+type WeierstrassParam struct {
+	P    big.Int // Prime defining the underlying field
+	A, B big.Int // Weierstrass curve equation parameters
+}
+
+// AffinePoint is a point in affine coordinates.
+// Inf denotes the point at infinity of Montgomery and Weierstrass curves,
+// in which case the coordinates are ignored.
+type AffinePoint struct {
+	X, Y big.Int
+	Inf  bool
+}
+
+var errNoEdwardsPoint = errors.New("point has no twisted Edwards counterpart")
+
+// Montgomery returns the parameters of the birationally equivalent Montgomery curve,
+// A = 2(a+d)/(a-d) and B = 4/(a-d).
+func (p *Param) Montgomery() *MontgomeryParam {
+	m := new(MontgomeryParam)
+	m.P.Set(&p.P)
+
+	inv := new(big.Int).Sub(&p.A, &p.D)
+	inv.Mod(inv, &p.P).ModInverse(inv, &p.P)
+
+	m.A.Add(&p.A, &p.D).Lsh(&m.A, 1).Mul(&m.A, inv).Mod(&m.A, &p.P)
+	m.B.Lsh(inv, 2).Mod(&m.B, &p.P)
+	return m
+}
+
+// Weierstrass returns the parameters of the birationally equivalent short Weierstrass curve.
+func (p *Param) Weierstrass() *WeierstrassParam {
+	return p.Montgomery().Weierstrass()
+}
+
+// Edwards returns the twisted Edwards equation parameters a = (A+2)/B and d = (A-2)/B
+// of the birationally equivalent twisted Edwards curve.
+func (m *MontgomeryParam) Edwards() (a, d *big.Int) {
+	bInv := new(big.Int).ModInverse(&m.B, &m.P)
+	a = new(big.Int).Add(&m.A, big.NewInt(2))
+	a.Mul(a, bInv).Mod(a, &m.P)
+	d = new(big.Int).Sub(&m.A, big.NewInt(2))
+	d.Mul(d, bInv).Mod(d, &m.P)
+	return a, d
+}
+
+// Weierstrass returns the parameters of the birationally equivalent short Weierstrass curve,
+// a = (3-A^2)/(3B^2) and b = (2A^3-9A)/(27B^3).
+func (m *MontgomeryParam) Weierstrass() *WeierstrassParam {
+	p := &m.P
+	w := new(WeierstrassParam)
+	w.P.Set(p)
+
+	AA := new(big.Int).Mul(&m.A, &m.A)
+	BB := new(big.Int).Mul(&m.B, &m.B)
+
+	w.A.Sub(big.NewInt(3), AA)
+	w.A.Mul(&w.A, inverse(new(big.Int).Mul(big.NewInt(3), BB), p)).Mod(&w.A, p)
+
+	w.B.Mul(AA, big.NewInt(2)).Sub(&w.B, big.NewInt(9)).Mul(&w.B, &m.A)
+	w.B.Mul(&w.B, inverse(new(big.Int).Mul(big.NewInt(27), BB.Mul(BB, &m.B)), p)).Mod(&w.B, p)
+	return w
+}
+
+// OnCurve returns true if the point lies on the Montgomery curve.
+func (m *MontgomeryParam) OnCurve(P *AffinePoint) bool {
+	if P.Inf {
+		return true
+	}
+	// B*v^2 == u*(u*(u + A) + 1)
+	l := new(big.Int).Mul(&P.Y, &P.Y)
+	l.Mul(l, &m.B).Mod(l, &m.P)
+	r := new(big.Int).Add(&P.X, &m.A)
+	r.Mul(r, &P.X).Add(r, one).Mul(r, &P.X).Mod(r, &m.P)
+	return l.Cmp(r) == 0
+}
+
+// OnCurve returns true if the point lies on the short Weierstrass curve.
+func (w *WeierstrassParam) OnCurve(P *AffinePoint) bool {
+	if P.Inf {
+		return true
+	}
+	// y^2 == x*(x^2 + a) + b
+	l := new(big.Int).Mul(&P.Y, &P.Y)
+	l.Mod(l, &w.P)
+	r := new(big.Int).Mul(&P.X, &P.X)
+	r.Add(r, &w.A).Mul(r, &P.X).Add(r, &w.B).Mod(r, &w.P)
+	return l.Cmp(r) == 0
+}
+
+// EdwardsToMontgomery maps a point (x,y) of the twisted Edwards curve
+// to the point (u,v) = ((1+y)/(1-y), (1+y)/((1-y)x)) of the Montgomery curve.
+func (p *Param) EdwardsToMontgomery(x, y *big.Int) *AffinePoint {
+	M := new(AffinePoint)
+	if x.Sign() == 0 {
+		// The identity maps to infinity, the point (0,-1) of order 2 to (0,0)
+		M.Inf = y.Cmp(one) == 0
+		return M
+	}
+
+	M.X.Sub(one, y).Mod(&M.X, &p.P)
+	M.X.ModInverse(&M.X, &p.P).Mul(&M.X, new(big.Int).Add(one, y)).Mod(&M.X, &p.P)
+	M.Y.ModInverse(x, &p.P).Mul(&M.Y, &M.X).Mod(&M.Y, &p.P)
+	return M
+}
+
+// MontgomeryToEdwards maps a point (u,v) of the Montgomery curve
+// to the point (x,y) = (u/v, (u-1)/(u+1)) of the twisted Edwards curve.
+func (p *Param) MontgomeryToEdwards(M *AffinePoint) (x, y *big.Int, err error) {
+	x, y = new(big.Int), new(big.Int)
+	if M.Inf {
+		return x, y.SetInt64(1), nil
+	}
+	if M.X.Sign() == 0 && M.Y.Sign() == 0 {
+		return x, y.Sub(&p.P, one), nil
+	}
+
+	u1 := new(big.Int).Add(&M.X, one)
+	u1.Mod(u1, &p.P)
+	if M.Y.Sign() == 0 || u1.Sign() == 0 {
+		return nil, nil, errNoEdwardsPoint
+	}
+
+	x.ModInverse(&M.Y, &p.P).Mul(x, &M.X).Mod(x, &p.P)
+	y.ModInverse(u1, &p.P).Mul(y, new(big.Int).Sub(&M.X, one)).Mod(y, &p.P)
+	return x, y, nil
+}
+
+// MontgomeryToWeierstrass maps a point (u,v) of the Montgomery curve
+// to the point (u/B + A/(3B), v/B) of the short Weierstrass curve.
+func (m *MontgomeryParam) MontgomeryToWeierstrass(M *AffinePoint) *AffinePoint {
+	W := new(AffinePoint)
+	if M.Inf {
+		W.Inf = true
+		return W
+	}
+
+	bInv := inverse(&m.B, &m.P)
+	W.X.Mul(&m.A, inverse(big.NewInt(3), &m.P)).Add(&W.X, &M.X).Mul(&W.X, bInv).Mod(&W.X, &m.P)
+	W.Y.Mul(&M.Y, bInv).Mod(&W.Y, &m.P)
+	return W
+}
+
+// WeierstrassToMontgomery maps a point (x,y) of the short Weierstrass curve
+// to the point (B*x - A/3, B*y) of the Montgomery curve.
+func (m *MontgomeryParam) WeierstrassToMontgomery(W *AffinePoint) *AffinePoint {
+	M := new(AffinePoint)
+	if W.Inf {
+		M.Inf = true
+		return M
+	}
+
+	M.X.Mul(&m.A, inverse(big.NewInt(3), &m.P))
+	M.X.Sub(new(big.Int).Mul(&m.B, &W.X), &M.X).Mod(&M.X, &m.P)
+	M.Y.Mul(&m.B, &W.Y).Mod(&M.Y, &m.P)
+	return M
+}
+
+// Montgomery returns the point of the birationally equivalent Montgomery curve
+// corresponding to P, see Param.Montgomery for the curve parameters.
+func (c *curve) Montgomery(P kyber.Point) *AffinePoint {
+	x, y := P.(point).GetXY()
+	return c.Param.EdwardsToMontgomery(&x.V, &y.V)
+}
+
+// Weierstrass returns the point of the birationally equivalent short Weierstrass curve
+// corresponding to P, see Param.Weierstrass for the curve parameters.
+func (c *curve) Weierstrass(P kyber.Point) *AffinePoint {
+	return c.Param.Montgomery().MontgomeryToWeierstrass(c.Montgomery(P))
+}
+
+// FromMontgomery returns the point corresponding to a point of the Montgomery curve.
+// Like decoding, it fails if the point isn't on the curve,
+// or if subgroup checks are enabled and it isn't in the prime-order subgroup.
+func (c *curve) FromMontgomery(M *AffinePoint) (kyber.Point, error) {
+	if !c.Param.Montgomery().OnCurve(M) {
+		return nil, errors.New("point not on Montgomery curve")
+	}
+	x, y, err := c.Param.MontgomeryToEdwards(M)
+	if err != nil {
+		return nil, err
+	}
+
+	P := c.self.Point().(point)
+	P.initXY(x, y, c.self)
+	if c.checked {
+		if xm, ym := P.GetXY(); !c.inSubgroup(xm, ym) {
+			return nil, errors.New("elliptic curve point not in prime-order subgroup")
+		}
+	}
+	return P, nil
+}
+
+// FromWeierstrass returns the point corresponding to a point of the short Weierstrass curve,
+// with the same checks as FromMontgomery.
+func (c *curve) FromWeierstrass(W *AffinePoint) (kyber.Point, error) {
+	m := c.Param.Montgomery()
+	if !m.Weierstrass().OnCurve(W) {
+		return nil, errors.New("point not on Weierstrass curve")
+	}
+	return c.FromMontgomery(m.WeierstrassToMontgomery(W))
+}
+
+func inverse(v, p *big.Int) *big.Int {
+	i := new(big.Int).Mod(v, p)
+	return i.ModInverse(i, p)
+}
@@ -0,0 +1,149 @@
+package curve25519
+
+import (
+	"math/big"
+	"testing"
+
+	"go.dedis.ch/kyber/v3"
+)
+
+var formTestParams = []*Param{
+	Param1174(),
+	Param25519(),
+	ParamE382(),
+	Param41417(),
+	ParamE521(),
+	ParamBabyJubJub(),
+}
+
+// Affine point addition on a short Weierstrass curve for cross-checking the group law
+func weierstrassAdd(w *WeierstrassParam, P, Q *AffinePoint) *AffinePoint {
+	if P.Inf {
+		return Q
+	}
+	if Q.Inf {
+		return P
+	}
+	p := &w.P
+	lambda := new(big.Int)
+	if P.X.Cmp(&Q.X) == 0 {
+		if lambda.Add(&P.Y, &Q.Y).Mod(lambda, p).Sign() == 0 {
+			return &AffinePoint{Inf: true}
+		}
+		// (3x^2 + a) / 2y
+		lambda.Mul(&P.X, &P.X).Mul(lambda, big.NewInt(3)).Add(lambda, &w.A)
+		lambda.Mul(lambda, inverse(new(big.Int).Lsh(&P.Y, 1), p))
+	} else {
+		lambda.Sub(&Q.Y, &P.Y).Mul(lambda, inverse(new(big.Int).Sub(&Q.X, &P.X), p))
+	}
+	lambda.Mod(lambda, p)
+
+	R := new(AffinePoint)
+	R.X.Mul(lambda, lambda).Sub(&R.X, &P.X).Sub(&R.X, &Q.X).Mod(&R.X, p)
+	R.Y.Sub(&P.X, &R.X).Mul(&R.Y, lambda).Sub(&R.Y, &P.Y).Mod(&R.Y, p)
+	return R
+}
+
+func TestFormParams(t *testing.T) {
+	for _, p := range formTestParams {
+		m := p.Montgomery()
+		a, d := m.Edwards()
+		if a.Cmp(new(big.Int).Mod(&p.A, &p.P)) != 0 || d.Cmp(new(big.Int).Mod(&p.D, &p.P)) != 0 {
+			t.Errorf("%s: Edwards parameters don't round-trip", p)
+		}
+	}
+
+	// BabyJubJub's Montgomery form as given in EIP-2494
+	m := ParamBabyJubJub().Montgomery()
+	if m.A.Int64() != 168698 || m.B.Int64() != 1 {
+		t.Errorf("unexpected Montgomery form of BabyJubJub: A = %s, B = %s", &m.A, &m.B)
+	}
+}
+
+func TestFormRoundTrip(t *testing.T) {
+	for _, p := range formTestParams {
+		g := new(ExtendedCurve).Init(p, false)
+		m := p.Montgomery()
+		w := p.Weierstrass()
+		stream := testSuite.XOF([]byte(p.Name))
+
+		points := []kyber.Point{g.Point().Null()}
+		for i := 0; i < 10; i++ {
+			points = append(points, g.Point().Pick(stream))
+		}
+
+		for _, P := range points {
+			M := g.Montgomery(P)
+			if !m.OnCurve(M) {
+				t.Fatalf("%s: Montgomery point of %s not on curve", p, P)
+			}
+			W := g.Weierstrass(P)
+			if !w.OnCurve(W) {
+				t.Fatalf("%s: Weierstrass point of %s not on curve", p, P)
+			}
+
+			Q, err := g.FromMontgomery(M)
+			if err != nil {
+				t.Fatalf("%s: %v", p, err)
+			}
+			if !P.Equal(Q) {
+				t.Fatalf("%s: Montgomery round trip of %s failed", p, P)
+			}
+			Q, err = g.FromWeierstrass(W)
+			if err != nil {
+				t.Fatalf("%s: %v", p, err)
+			}
+			if !P.Equal(Q) {
+				t.Fatalf("%s: Weierstrass round trip of %s failed", p, P)
+			}
+		}
+
+		// The maps are group homomorphisms
+		for i := 1; i < len(points)-1; i++ {
+			P, Q := points[i], points[i+1]
+			sum := weierstrassAdd(w, g.Weierstrass(P), g.Weierstrass(Q))
+			expected := g.Weierstrass(g.Point().Add(P, Q))
+			if sum.X.Cmp(&expected.X) != 0 || sum.Y.Cmp(&expected.Y) != 0 {
+				t.Fatalf("%s: Weierstrass addition differs", p)
+			}
+			double := weierstrassAdd(w, g.Weierstrass(P), g.Weierstrass(P))
+			expected = g.Weierstrass(g.Point().Add(P, P))
+			if double.X.Cmp(&expected.X) != 0 || double.Y.Cmp(&expected.Y) != 0 {
+				t.Fatalf("%s: Weierstrass doubling differs", p)
+			}
+		}
+	}
+}
+
+func TestFormSpecialPoints(t *testing.T) {
+	p := ParamBabyJubJub()
+	g := new(ExtendedCurve).Init(p, true)
+
+	if M := g.Montgomery(g.Point().Null()); !M.Inf {
+		t.Fatal("identity doesn't map to infinity")
+	}
+
+	// (0,-1) of order 2 maps to (0,0)
+	M := p.EdwardsToMontgomery(zero, new(big.Int).Sub(&p.P, one))
+	if M.Inf || M.X.Sign() != 0 || M.Y.Sign() != 0 {
+		t.Fatal("(0,-1) doesn't map to (0,0)")
+	}
+	x, y, err := p.MontgomeryToEdwards(M)
+	if err != nil || x.Sign() != 0 || y.Cmp(new(big.Int).Sub(&p.P, one)) != 0 {
+		t.Fatal("(0,0) doesn't map to (0,-1)")
+	}
+
+	// Subgroup checks apply
+	suite := NewBlakeSHA256BabyJubJub(false)
+	if _, err := suite.FromMontgomery(M); err == nil {
+		t.Fatal("point of order 2 accepted")
+	}
+	if _, err := suite.FromMontgomery(&AffinePoint{}); err == nil {
+		t.Fatal("point not on curve accepted")
+	}
+	W := p.Montgomery().MontgomeryToWeierstrass(M)
+	W.Y.SetInt64(1)
+	if _, err := suite.FromWeierstrass(W); err == nil {
+		t.Fatal("point not on curve accepted")
+	}
+}
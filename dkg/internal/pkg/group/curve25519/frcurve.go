@@ -0,0 +1,205 @@
+package curve25519
+
+import (
+	"crypto/cipher"
+	"io"
+	"math/big"
+
+	"client/internal/pkg/group/fr"
+	"client/internal/pkg/group/internal/marshalling"
+
+	"go.dedis.ch/kyber/v3"
+	"go.dedis.ch/kyber/v3/group/mod"
+)
+
+type frProjPoint struct {
+	frPoint
+	c *FrCurve
+}
+
+func (P *frProjPoint) initXY(x, y *big.Int, c kyber.Group) {
+	P.c = c.(*FrCurve)
+	P.X.SetBigInt(x)
+	P.Y.SetBigInt(y)
+	P.Z.SetOne()
+}
+
+func (P *frProjPoint) GetXY() (x, y *mod.Int) {
+	P.normalize()
+	x = mod.NewInt(P.X.BigInt(new(big.Int)), &P.c.P)
+	y = mod.NewInt(P.Y.BigInt(new(big.Int)), &P.c.P)
+	return x, y
+}
+
+func (P *frProjPoint) String() string {
+	x, y := P.GetXY()
+	return P.c.pointString(x, y)
+}
+
+func (P *frProjPoint) MarshalSize() int {
+	return P.c.PointLen()
+}
+
+func (P *frProjPoint) MarshalBinary() ([]byte, error) {
+	x, y := P.GetXY()
+	return P.c.encodePoint(x, y), nil
+}
+
+func (P *frProjPoint) UnmarshalBinary(b []byte) error {
+	var x, y mod.Int
+	if err := P.c.decodePoint(b, &x, &y); err != nil {
+		return err
+	}
+	P.initXY(&x.V, &y.V, P.c)
+	return nil
+}
+
+func (P *frProjPoint) MarshalTo(w io.Writer) (int, error) {
+	return marshalling.PointMarshalTo(P, w)
+}
+
+func (P *frProjPoint) UnmarshalFrom(r io.Reader) (int, error) {
+	return marshalling.PointUnmarshalFrom(P, r)
+}
+
+// Equality test for two Points on the same curve,
+// comparing cross products as in ProjPoint.Equal.
+func (P *frProjPoint) Equal(CP2 kyber.Point) bool {
+	P2 := CP2.(*frProjPoint)
+	var t1, t2 fr.Element
+	xeq := t1.Mul(&P.X, &P2.Z).Equal(t2.Mul(&P2.X, &P.Z))
+	yeq := t1.Mul(&P.Y, &P2.Z).Equal(t2.Mul(&P2.Y, &P.Z))
+	return xeq&yeq == 1
+}
+
+func (P *frProjPoint) Set(CP2 kyber.Point) kyber.Point {
+	P2 := CP2.(*frProjPoint)
+	*P = *P2
+	return P
+}
+
+func (P *frProjPoint) Clone() kyber.Point {
+	P2 := *P
+	return &P2
+}
+
+func (P *frProjPoint) Null() kyber.Point {
+	P.Set(&P.c.null)
+	return P
+}
+
+func (P *frProjPoint) Base() kyber.Point {
+	P.Set(&P.c.base)
+	return P
+}
+
+func (P *frProjPoint) EmbedLen() int {
+	return P.c.embedLen()
+}
+
+// Normalize the point's representation to Z=1.
+func (P *frProjPoint) normalize() {
+	P.Z.Inverse(&P.Z)
+	P.X.Mul(&P.X, &P.Z)
+	P.Y.Mul(&P.Y, &P.Z)
+	P.Z.SetOne()
+}
+
+func (P *frProjPoint) Embed(data []byte, rand cipher.Stream) kyber.Point {
+	P.c.embed(P, data, rand)
+	return P
+}
+
+func (P *frProjPoint) Pick(rand cipher.Stream) kyber.Point {
+	return P.Embed(nil, rand)
+}
+
+// Extract embedded data from a point group element
+func (P *frProjPoint) Data() ([]byte, error) {
+	x, y := P.GetXY()
+	return P.c.data(x, y)
+}
+
+func (P *frProjPoint) Add(CP1, CP2 kyber.Point) kyber.Point {
+	P1 := CP1.(*frProjPoint)
+	P2 := CP2.(*frProjPoint)
+	P.c = P1.c
+	P.c.ct.add(&P.frPoint, &P1.frPoint, &P2.frPoint)
+	return P
+}
+
+func (P *frProjPoint) Sub(CP1, CP2 kyber.Point) kyber.Point {
+	P1 := CP1.(*frProjPoint)
+	P2 := CP2.(*frProjPoint)
+	N := P2.frPoint
+	N.X.Neg(&N.X)
+	P.c = P1.c
+	P.c.ct.add(&P.frPoint, &P1.frPoint, &N)
+	return P
+}
+
+// Find the negative of point A.
+// For Edwards curves, the negative of (x,y) is (-x,y).
+func (P *frProjPoint) Neg(CA kyber.Point) kyber.Point {
+	A := CA.(*frProjPoint)
+	P.c = A.c
+	P.X.Neg(&A.X)
+	P.Y = A.Y
+	P.Z = A.Z
+	return P
+}
+
+func (P *frProjPoint) double() {
+	P.c.ct.double(&P.frPoint)
+}
+
+// Multiply point p by scalar s in constant time.
+func (P *frProjPoint) Mul(s kyber.Scalar, G kyber.Point) kyber.Point {
+	v := &s.(*mod.Int).V
+	if G == nil {
+		P.frPoint = P.c.ct.mulBase(&P.c.base.frPoint, v)
+	} else {
+		P.frPoint = P.c.ct.mul(&G.(*frProjPoint).frPoint, v)
+	}
+	return P
+}
+
+// MulPublic multiplies point p by scalar s in variable time.
+func (P *frProjPoint) MulPublic(s kyber.Scalar, G kyber.Point) kyber.Point {
+	v := &s.(*mod.Int).V
+	if G == nil {
+		P.frPoint = P.c.ct.mulBaseVartime(&P.c.base.frPoint, v)
+	} else {
+		P.frPoint = P.c.ct.mulVartime(&G.(*frProjPoint).frPoint, v)
+	}
+	return P
+}
+
+// FrCurve implements Twisted Edwards curves over the BN254 scalar field,
+// like BabyJubJub, using projective coordinates with fixed-limb field elements.
+// It is equivalent to ProjectiveCurve, but avoids the allocations
+// and variable-time arithmetic of big.Int on every point operation.
+type FrCurve struct {
+	curve             // generic Edwards curve functionality
+	null  frProjPoint // Constant identity/null point (0,1)
+	base  frProjPoint // Standard base point
+}
+
+// Point creates a new Point on this curve.
+func (c *FrCurve) Point() kyber.Point {
+	P := new(frProjPoint)
+	P.c = c
+	P.Set(&c.null)
+	return P
+}
+
+// Init initializes the curve with given parameters.
+// It panics if the curve isn't defined over the BN254 scalar field
+// or its addition formulas aren't complete.
+func (c *FrCurve) Init(p *Param, fullGroup bool) *FrCurve {
+	if newCTCurve(p) == nil {
+		panic("unsupported curve " + p.String())
+	}
+	c.curve.init(c, p, fullGroup, &c.null, &c.base)
+	return c
+}
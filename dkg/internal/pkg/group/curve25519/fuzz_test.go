@@ -0,0 +1,157 @@
+package curve25519
+
+import (
+	"bytes"
+	"testing"
+
+	"go.dedis.ch/kyber/v3"
+	"go.dedis.ch/kyber/v3/group/mod"
+)
+
+// Point encodings reach the client as untrusted on-chain input,
+// so decoding arbitrary bytes must never panic and must only accept canonical encodings.
+
+type fuzzGroup struct {
+	name string
+	g    kyber.Group
+	c    *curve
+}
+
+// fuzzGroups returns the representations of a curve that have to agree on all encodings.
+func fuzzGroups(p *Param, fullGroup bool) []fuzzGroup {
+	proj := new(ProjectiveCurve).Init(p, fullGroup)
+	ext := new(ExtendedCurve).Init(p, fullGroup)
+	groups := []fuzzGroup{
+		{"projective", proj, &proj.curve},
+		{"extended", ext, &ext.curve},
+	}
+	if newCTCurve(p) != nil {
+		fr := new(FrCurve).Init(p, fullGroup)
+		groups = append(groups, fuzzGroup{"fr", fr, &fr.curve})
+	}
+	return groups
+}
+
+func fuzzSeedPoints(f *testing.F, g kyber.Group) {
+	f.Add(make([]byte, g.PointLen()))
+	f.Add(bytes.Repeat([]byte{0xff}, g.PointLen()))
+	f.Add([]byte{})
+	f.Add([]byte{0x80})
+	for _, p := range []kyber.Point{g.Point().Null(), g.Point().Base(), g.Point().Neg(g.Point().Base())} {
+		b, err := p.MarshalBinary()
+		if err != nil {
+			f.Fatal(err)
+		}
+		f.Add(b)
+		f.Add(b[1:])
+		f.Add(append(b, 0))
+
+		// Identity with the sign bit set, a non-canonical encoding
+		b[0] |= 0x80
+		f.Add(b)
+	}
+}
+
+// checkDecoded verifies the properties of a successfully decoded point:
+// it lies on the curve, possibly in the prime-order subgroup, and its encoding round-trips.
+func checkDecoded(t *testing.T, c *curve, name string, P kyber.Point, b []byte) {
+	x, y := P.(point).GetXY()
+	if !c.onCurve(x, y) {
+		t.Fatalf("%s: decoded point %s not on curve", name, P)
+	}
+	if c.checked && !c.inSubgroup(x, y) {
+		t.Fatalf("%s: decoded point %s not in subgroup", name, P)
+	}
+
+	e, err := P.MarshalBinary()
+	if err != nil {
+		t.Fatalf("%s: marshal: %v", name, err)
+	}
+	if !bytes.Equal(e, b) {
+		t.Fatalf("%s: non-canonical encoding %x accepted, encodes as %x", name, b, e)
+	}
+}
+
+func fuzzUnmarshalBinary(f *testing.F, groups []fuzzGroup) {
+	fuzzSeedPoints(f, groups[0].g)
+	f.Fuzz(func(t *testing.T, b []byte) {
+		var firstErr error
+		for i, fg := range groups {
+			// Every accepted encoding is checked to round-trip, so all representations decode the same point
+			P := fg.g.Point()
+			err := P.UnmarshalBinary(b)
+			if i == 0 {
+				firstErr = err
+			} else if (err == nil) != (firstErr == nil) {
+				t.Fatalf("%s: representations disagree on %x: %v, %v", fg.name, b, err, firstErr)
+			}
+			if err == nil {
+				checkDecoded(t, fg.c, fg.name, P, b)
+			}
+		}
+	})
+}
+
+func FuzzUnmarshalBinaryBabyJubJub(f *testing.F) {
+	groups := fuzzGroups(ParamBabyJubJub(), false)
+	for _, fg := range groups {
+		fg.c.SetSubgroupCheck(true)
+	}
+	fuzzUnmarshalBinary(f, groups)
+}
+
+func FuzzUnmarshalBinaryBabyJubJubFull(f *testing.F) {
+	fuzzUnmarshalBinary(f, fuzzGroups(ParamBabyJubJub(), true))
+}
+
+func FuzzUnmarshalBinary25519(f *testing.F) {
+	fuzzUnmarshalBinary(f, fuzzGroups(Param25519(), false))
+}
+
+func FuzzUnmarshalBinaryE382(f *testing.F) {
+	fuzzUnmarshalBinary(f, fuzzGroups(ParamE382(), false))
+}
+
+// fuzzPointRoundTrip checks that all representations compute the same point s*B
+// for the scalar s given by the fuzzed bytes, encode it identically
+// and decode each other's encodings.
+func fuzzPointRoundTrip(f *testing.F, groups []fuzzGroup) {
+	f.Add([]byte{})
+	f.Add([]byte{1})
+	f.Add(bytes.Repeat([]byte{0xff}, 64))
+	f.Add(groups[0].g.Scalar().(*mod.Int).M.Bytes())
+	f.Fuzz(func(t *testing.T, s []byte) {
+		var enc []byte
+		for _, fg := range groups {
+			P := fg.g.Point().Mul(fg.g.Scalar().SetBytes(s), nil)
+			b, err := P.MarshalBinary()
+			if err != nil {
+				t.Fatalf("%s: marshal: %v", fg.name, err)
+			}
+			if enc == nil {
+				enc = b
+			} else if !bytes.Equal(enc, b) {
+				t.Fatalf("%s: encoding %x differs from %x", fg.name, b, enc)
+			}
+		}
+
+		for _, fg := range groups {
+			P := fg.g.Point()
+			if err := P.UnmarshalBinary(enc); err != nil {
+				t.Fatalf("%s: unmarshal %x: %v", fg.name, enc, err)
+			}
+			if !P.Equal(fg.g.Point().Mul(fg.g.Scalar().SetBytes(s), nil)) {
+				t.Fatalf("%s: decoded point differs", fg.name)
+			}
+			checkDecoded(t, fg.c, fg.name, P, enc)
+		}
+	})
+}
+
+func FuzzPointRoundTripBabyJubJub(f *testing.F) {
+	fuzzPointRoundTrip(f, fuzzGroups(ParamBabyJubJub(), false))
+}
+
+func FuzzPointRoundTrip25519(f *testing.F) {
+	fuzzPointRoundTrip(f, fuzzGroups(Param25519(), false))
+}
@@ -0,0 +1,157 @@
+package curve25519
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"math/big"
+
+	"go.dedis.ch/kyber/v3"
+)
+
+// Security level in bits targeted by hashing to the field,
+// which determines the number of bytes drawn per field element.
+const hashSecurityBits = 128
+
+// HashToPoint deterministically hashes msg to a point of the prime-order subgroup,
+// following the hash_to_curve construction of RFC 9380 with expand_message_xmd
+// using SHA-256, the Elligator 2 map on the Montgomery form of the curve,
+// and clearing the cofactor by multiplication.
+// The domain separation tag dst must be unique to the application and protocol,
+// see RFC 9380 section 3.1, and at most 255 bytes long.
+// The curve parameters must define the non-square Elligator2u.
+func (c *curve) HashToPoint(dst, msg []byte) (kyber.Point, error) {
+	if c.Elligator2u.Sign() == 0 {
+		return nil, errors.New("curve doesn't support Elligator 2")
+	}
+
+	u, err := c.hashToField(dst, msg, 2)
+	if err != nil {
+		return nil, err
+	}
+
+	P := c.self.Point()
+	P.Add(c.mapToPoint(u[0]), c.mapToPoint(u[1]))
+	return MulPublic(P, &c.cofact, P), nil
+}
+
+// hashToField hashes msg to count elements of the base field, see RFC 9380 section 5.2.
+func (c *curve) hashToField(dst, msg []byte, count int) ([]*big.Int, error) {
+	l := (c.P.BitLen() + hashSecurityBits + 7) / 8
+	b, err := expandMessageXMD(dst, msg, count*l)
+	if err != nil {
+		return nil, err
+	}
+
+	u := make([]*big.Int, count)
+	for i := range u {
+		u[i] = new(big.Int).SetBytes(b[i*l : (i+1)*l])
+		u[i].Mod(u[i], &c.P)
+	}
+	return u, nil
+}
+
+// expandMessageXMD implements expand_message_xmd of RFC 9380 section 5.3.1 with SHA-256.
+func expandMessageXMD(dst, msg []byte, length int) ([]byte, error) {
+	const bInBytes, sInBytes = sha256.Size, sha256.BlockSize
+
+	ell := (length + bInBytes - 1) / bInBytes
+	if ell > 255 || length > 65535 {
+		return nil, errors.New("requested too many bytes")
+	}
+	if len(dst) > 255 {
+		return nil, errors.New("domain separation tag too long")
+	}
+	dstPrime := append(append([]byte{}, dst...), byte(len(dst)))
+
+	var lengthBytes [2]byte
+	binary.BigEndian.PutUint16(lengthBytes[:], uint16(length))
+
+	h := sha256.New()
+	h.Write(make([]byte, sInBytes))
+	h.Write(msg)
+	h.Write(lengthBytes[:])
+	h.Write([]byte{0})
+	h.Write(dstPrime)
+	b0 := h.Sum(nil)
+
+	h.Reset()
+	h.Write(b0)
+	h.Write([]byte{1})
+	h.Write(dstPrime)
+	bi := h.Sum(nil)
+
+	uniform := append(make([]byte, 0, ell*bInBytes), bi...)
+	for i := 2; i <= ell; i++ {
+		for j := range bi {
+			bi[j] ^= b0[j]
+		}
+		h.Reset()
+		h.Write(bi)
+		h.Write([]byte{byte(i)})
+		h.Write(dstPrime)
+		bi = h.Sum(bi[:0])
+		uniform = append(uniform, bi...)
+	}
+	return uniform[:length], nil
+}
+
+// mapToPoint maps a field element to a point on the curve using Elligator 2
+// on the birationally equivalent Montgomery curve K*t^2 = s^3 + J*s^2 + s,
+// see RFC 9380 section 6.7.1 and Param.Montgomery.
+// The resulting point is not necessarily in the prime-order subgroup.
+func (c *curve) mapToPoint(u *big.Int) kyber.Point {
+	p := &c.P
+	m := c.Param.Montgomery()
+	J, K := &m.A, &m.B
+
+	// c1 = J/K, c2 = 1/K^2
+	kInv := new(big.Int).ModInverse(K, p)
+	c1 := new(big.Int).Mul(J, kInv)
+	c1.Mod(c1, p)
+	c2 := new(big.Int).Mul(kInv, kInv)
+	c2.Mod(c2, p)
+
+	tv1 := new(big.Int).Mul(u, u)
+	tv1.Mul(tv1, &c.Elligator2u).Mod(tv1, p)
+	if new(big.Int).Add(tv1, one).Cmp(p) == 0 {
+		tv1.SetInt64(0)
+	}
+
+	// x1 = -c1 / (tv1 + 1)
+	x1 := new(big.Int).Add(tv1, one)
+	x1.ModInverse(x1, p).Mul(x1, c1).Neg(x1).Mod(x1, p)
+
+	// gx1 = x1^3 + c1*x1^2 + c2*x1 = x1 * ((x1 + c1)*x1 + c2)
+	gx1 := new(big.Int).Add(x1, c1)
+	gx1.Mul(gx1, x1).Add(gx1, c2).Mul(gx1, x1).Mod(gx1, p)
+
+	x, y2 := x1, gx1
+	if big.Jacobi(gx1, p) < 0 {
+		// x2 = -x1 - c1, gx2 = tv1 * gx1
+		x = new(big.Int).Add(x1, c1)
+		x.Neg(x).Mod(x, p)
+		y2 = new(big.Int).Mul(tv1, gx1)
+		y2.Mod(y2, p)
+	}
+
+	y := new(big.Int).ModSqrt(y2, p)
+	if (big.Jacobi(gx1, p) >= 0) != (y.Bit(0) == 1) {
+		y.Neg(y).Mod(y, p)
+	}
+
+	// (s, t) = (x*K, y*K)
+	var M AffinePoint
+	M.X.Mul(x, K).Mod(&M.X, p)
+	M.Y.Mul(y, K).Mod(&M.Y, p)
+
+	// Points without Edwards counterpart map to the identity
+	ex, ey, err := c.Param.MontgomeryToEdwards(&M)
+	if err != nil {
+		ex, ey = zero, one
+	}
+
+	P := c.self.Point().(point)
+	P.initXY(ex, ey, c.self)
+	return P
+}
@@ -0,0 +1,116 @@
+package curve25519
+
+import (
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"go.dedis.ch/kyber/v3"
+	"go.dedis.ch/kyber/v3/group/mod"
+)
+
+// Test vectors from RFC 9380 appendix K.1
+func TestExpandMessageXMD(t *testing.T) {
+	dst := []byte("QUUX-V01-CS02-with-expander-SHA256-128")
+	vectors := []struct {
+		msg      string
+		length   int
+		expected string
+	}{
+		{"", 0x20, "68a985b87eb6b46952128911f2a4412bbc302a9d759667f87f7a21d803f07235"},
+		{"abc", 0x20, "d8ccab23b5985ccea865c6c97b6e5b8350e794e603b4b97902f53a8a0d605615"},
+		{"abcdef0123456789", 0x20, "eff31487c770a893cfb36f912fbfcbff40d5661771ca4b2cb4eafe524333f5c1"},
+	}
+	for _, v := range vectors {
+		b, err := expandMessageXMD(dst, []byte(v.msg), v.length)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if hex.EncodeToString(b) != v.expected {
+			t.Errorf("msg %q: expected %s, got %x", v.msg, v.expected, b)
+		}
+	}
+}
+
+func testHashToPoint(t *testing.T, g kyber.Group, p *Param) {
+	hasher := g.(interface {
+		HashToPoint(dst, msg []byte) (kyber.Point, error)
+	})
+	dst := []byte("zkDKG-V01-CS01-test")
+
+	var q mod.Int
+	q.V.Set(&p.Q)
+
+	seen := make(map[string]bool)
+	for i := 0; i < 20; i++ {
+		msg := big.NewInt(int64(i)).Bytes()
+		P, err := hasher.HashToPoint(dst, msg)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// Deterministic
+		P2, err := hasher.HashToPoint(dst, msg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !P.Equal(P2) {
+			t.Fatal("hashing isn't deterministic")
+		}
+
+		// In the prime-order subgroup and not the identity
+		if !g.Point().Mul(&q, P).Equal(g.Point().Null()) {
+			t.Fatalf("hash of %x not in prime-order subgroup", msg)
+		}
+		if P.Equal(g.Point().Null()) {
+			t.Fatalf("hash of %x is the identity", msg)
+		}
+
+		// Domain separated
+		P3, err := hasher.HashToPoint([]byte("zkDKG-V01-CS01-other"), msg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if P.Equal(P3) {
+			t.Fatal("hashing isn't domain separated")
+		}
+
+		// Encoding is valid
+		b, _ := P.MarshalBinary()
+		if err := g.Point().UnmarshalBinary(b); err != nil {
+			t.Fatal(err)
+		}
+		if seen[string(b)] {
+			t.Fatal("collision")
+		}
+		seen[string(b)] = true
+	}
+}
+
+func TestHashToPointBabyJubJub(t *testing.T) {
+	p := ParamBabyJubJub()
+	testHashToPoint(t, NewBlakeSHA256BabyJubJub(false), p)
+	testHashToPoint(t, new(ProjectiveCurve).Init(p, false), p)
+	testHashToPoint(t, new(ExtendedCurve).Init(p, true), p)
+}
+
+func TestHashToPoint25519(t *testing.T) {
+	p := Param25519()
+	testHashToPoint(t, new(ExtendedCurve).Init(p, false), p)
+}
+
+func TestHashToPointUnsupported(t *testing.T) {
+	if _, err := new(ExtendedCurve).Init(ParamE382(), false).HashToPoint(nil, nil); err == nil {
+		t.Fatal("expected error for curve without Elligator 2 parameter")
+	}
+}
+
+func TestMapToPointOnCurve(t *testing.T) {
+	c := new(ProjectiveCurve).Init(ParamBabyJubJub(), false)
+	for _, u := range []*big.Int{big.NewInt(0), big.NewInt(1), big.NewInt(5), new(big.Int).Sub(&c.P, one)} {
+		x, y := c.mapToPoint(u).(point).GetXY()
+		if !c.onCurve(x, y) {
+			t.Fatalf("mapping of %s not on curve", u)
+		}
+	}
+}
@@ -0,0 +1,221 @@
+package curve25519
+
+import (
+	"errors"
+	"math/big"
+	"math/bits"
+	"sync"
+
+	"go.dedis.ch/kyber/v3"
+	"go.dedis.ch/kyber/v3/group/mod"
+)
+
+// Window width of the non-adjacent form used for variable-base multiplication.
+// Requires a table of 2^(wnafWidth-2) precomputed odd multiples of the point.
+const wnafWidth = 5
+
+// Window width of the precomputed table used for fixed-base multiplication.
+const fixedBaseWidth = 4
+
+// Extension of point interface for points with optimized doubling
+type mulPoint interface {
+	point
+
+	double()
+}
+
+// wnaf returns the width-w non-adjacent form of v, least-significant digit first.
+// Every nonzero digit is odd and lies within (-2^(w-1), 2^(w-1)),
+// and of any w consecutive digits at most one is nonzero.
+func wnaf(v *big.Int, w uint) []int8 {
+	k := new(big.Int).Set(v)
+	mask := big.Word(1)<<w - 1
+	half := int64(1) << (w - 1)
+
+	naf := make([]int8, 0, k.BitLen()+1)
+	var d big.Int
+	for k.Sign() > 0 {
+		var digit int64
+		if k.Bit(0) == 1 {
+			digit = int64(k.Bits()[0] & mask)
+			if digit >= half {
+				digit -= int64(1) << w
+			}
+			k.Sub(k, d.SetInt64(digit))
+		}
+		naf = append(naf, int8(digit))
+		k.Rsh(k, 1)
+	}
+	return naf
+}
+
+// mulWNAF sets T to v*G using the width-w non-adjacent form of v.
+// T must not be the same point as G.
+func mulWNAF(T, G mulPoint, null kyber.Point, v *big.Int) {
+	naf := wnaf(v, wnafWidth)
+
+	// Odd multiples G, 3G, 5G, ... of the base
+	odd := make([]kyber.Point, 1<<(wnafWidth-2))
+	odd[0] = G.Clone()
+	twice := G.Clone().(mulPoint)
+	twice.double()
+	for i := 1; i < len(odd); i++ {
+		odd[i] = G.Clone().Add(odd[i-1], twice)
+	}
+
+	T.Set(null)
+	for i := len(naf) - 1; i >= 0; i-- {
+		T.double()
+		if d := naf[i]; d > 0 {
+			T.Add(T, odd[d>>1])
+		} else if d < 0 {
+			T.Sub(T, odd[(-d)>>1])
+		}
+	}
+}
+
+// Precomputed multiples of the base point of a curve.
+// Entry [i][j] holds (j+1) * 2^(fixedBaseWidth*i) * B,
+// so multiplication by a scalar only requires one addition per window.
+type fixedBase struct {
+	once  sync.Once
+	table [][]kyber.Point
+	order *big.Int
+}
+
+func (f *fixedBase) init(base mulPoint, order *big.Int) {
+	f.once.Do(func() {
+		f.order = order
+
+		windows := (order.BitLen() + fixedBaseWidth - 1) / fixedBaseWidth
+		f.table = make([][]kyber.Point, windows)
+
+		P := base.Clone().(mulPoint)
+		for i := range f.table {
+			row := make([]kyber.Point, 1<<fixedBaseWidth-1)
+			row[0] = P.Clone()
+			for j := 1; j < len(row); j++ {
+				row[j] = P.Clone().Add(row[j-1], P)
+			}
+			f.table[i] = row
+
+			for j := 0; j < fixedBaseWidth; j++ {
+				P.double()
+			}
+		}
+	})
+}
+
+// mul sets T to v*B.
+func (f *fixedBase) mul(T kyber.Point, null kyber.Point, v *big.Int) {
+	k := v
+	if k.Cmp(f.order) >= 0 {
+		// Multiples of the order vanish, which keeps the scalar within the table
+		k = new(big.Int).Mod(v, f.order)
+	}
+
+	T.Set(null)
+	for i := range f.table {
+		var digit uint
+		for j := 0; j < fixedBaseWidth; j++ {
+			digit |= k.Bit(i*fixedBaseWidth+j) << j
+		}
+		if digit != 0 {
+			T.Add(T, f.table[i][digit-1])
+		}
+	}
+}
+
+// MultiScalarMul computes the sum of scalars[i] * points[i] in variable time
+// using Pippenger's bucket method.
+// All points have to belong to the same group, all scalars to its scalar field.
+func MultiScalarMul(scalars []kyber.Scalar, points []kyber.Point) (kyber.Point, error) {
+	if len(scalars) != len(points) {
+		return nil, errors.New("number of scalars and points differ")
+	}
+	if len(points) == 0 {
+		return nil, errors.New("no points given")
+	}
+
+	values := make([]*big.Int, len(scalars))
+	maxLen := 0
+	for i, s := range scalars {
+		ms, ok := s.(*mod.Int)
+		if !ok {
+			return nil, errors.New("unsupported scalar type")
+		}
+		values[i] = &ms.V
+		if l := ms.V.BitLen(); l > maxLen {
+			maxLen = l
+		}
+	}
+
+	sum := points[0].Clone().Null()
+	if len(points) == 1 {
+		return MulPublic(sum, scalars[0], points[0]), nil
+	}
+
+	c := pippengerWindow(len(points))
+	buckets := make([]kyber.Point, 1<<c-1)
+	for i := range buckets {
+		buckets[i] = sum.Clone()
+	}
+	running := sum.Clone()
+	windowSum := sum.Clone()
+
+	for w := (maxLen+c-1)/c - 1; w >= 0; w-- {
+		for i := 0; i < c; i++ {
+			double(sum)
+		}
+
+		for _, b := range buckets {
+			b.Null()
+		}
+		for i, v := range values {
+			if digit := window(v, w*c, c); digit != 0 {
+				buckets[digit-1].Add(buckets[digit-1], points[i])
+			}
+		}
+
+		// sum_j j * bucket[j] through a running sum from the highest bucket downwards
+		running.Null()
+		windowSum.Null()
+		for j := len(buckets) - 1; j >= 0; j-- {
+			running.Add(running, buckets[j])
+			windowSum.Add(windowSum, running)
+		}
+		sum.Add(sum, windowSum)
+	}
+
+	return sum, nil
+}
+
+// pippengerWindow returns the window width minimizing the number of additions for n points.
+func pippengerWindow(n int) int {
+	c := bits.Len(uint(n)) - 2
+	if c < 2 {
+		return 2
+	}
+	if c > 16 {
+		return 16
+	}
+	return c
+}
+
+// window extracts the c bits of v starting at bit offset.
+func window(v *big.Int, offset, c int) uint {
+	var digit uint
+	for j := 0; j < c; j++ {
+		digit |= v.Bit(offset+j) << j
+	}
+	return digit
+}
+
+// double sets P to 2*P, using the optimized doubling formulas if available.
+func double(P kyber.Point) {
+	if mp, ok := P.(mulPoint); ok {
+		mp.double()
+		return
+	}
+	P.Add(P, P)
+}
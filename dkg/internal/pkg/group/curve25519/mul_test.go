@@ -0,0 +1,251 @@
+package curve25519
+
+import (
+	"math/big"
+	"testing"
+
+	"client/internal/pkg/group/fr"
+
+	"go.dedis.ch/kyber/v3"
+	"go.dedis.ch/kyber/v3/group/mod"
+)
+
+func TestWNAF(t *testing.T) {
+	g := new(ProjectiveCurve).Init(Param25519(), false)
+	for i := 0; i < 100; i++ {
+		v := &g.Scalar().Pick(testSuite.RandomStream()).(*mod.Int).V
+		naf := wnaf(v, wnafWidth)
+
+		sum := new(big.Int)
+		for j := len(naf) - 1; j >= 0; j-- {
+			sum.Lsh(sum, 1).Add(sum, big.NewInt(int64(naf[j])))
+
+			if naf[j] == 0 {
+				continue
+			}
+			if naf[j]%2 == 0 || naf[j] >= 1<<(wnafWidth-1) || naf[j] <= -1<<(wnafWidth-1) {
+				t.Fatalf("invalid digit %d", naf[j])
+			}
+			for k := j + 1; k < j+wnafWidth && k < len(naf); k++ {
+				if naf[k] != 0 {
+					t.Fatalf("adjacent nonzero digits at %d and %d", j, k)
+				}
+			}
+		}
+
+		if sum.Cmp(v) != 0 {
+			t.Fatalf("wNAF of %s evaluates to %s", v, sum)
+		}
+	}
+}
+
+func testBaseMul(t *testing.T, g kyber.Group) {
+	stream := testSuite.XOF([]byte("base mul"))
+	order := new(big.Int).Set(g.Scalar().(*mod.Int).M)
+	for i := 0; i < 20; i++ {
+		s := g.Scalar().Pick(stream)
+		if !g.Point().Mul(s, nil).Equal(g.Point().Mul(s, g.Point().Base())) {
+			t.Fatalf("fixed-base and variable-base multiplication differ for %s", s)
+		}
+	}
+
+	// Scalars exceeding the group order, e.g. field elements of the base field
+	s := mod.NewInt(new(big.Int).Lsh(order, 3), new(big.Int).Lsh(order, 4))
+	s.V.Add(&s.V, big.NewInt(12345))
+	if !g.Point().Mul(s, nil).Equal(g.Point().Mul(g.Scalar().SetInt64(12345), nil)) {
+		t.Fatal("fixed-base multiplication doesn't reduce scalars by the group order")
+	}
+}
+
+func TestBaseMulProjective(t *testing.T) {
+	testBaseMul(t, new(ProjectiveCurve).Init(Param25519(), false))
+	testBaseMul(t, new(ProjectiveCurve).Init(Param1174(), true))
+}
+
+func TestBaseMulExtended(t *testing.T) {
+	testBaseMul(t, new(ExtendedCurve).Init(Param25519(), false))
+	testBaseMul(t, new(ExtendedCurve).Init(ParamE382(), true))
+}
+
+func naiveMultiScalarMul(scalars []kyber.Scalar, points []kyber.Point) kyber.Point {
+	sum := points[0].Clone().Null()
+	for i := range scalars {
+		sum.Add(sum, points[i].Clone().Mul(scalars[i], points[i]))
+	}
+	return sum
+}
+
+func randomMultiScalarMulInput(g kyber.Group, n int) ([]kyber.Scalar, []kyber.Point) {
+	scalars := make([]kyber.Scalar, n)
+	points := make([]kyber.Point, n)
+	for i := range scalars {
+		scalars[i] = g.Scalar().Pick(testSuite.RandomStream())
+		points[i] = g.Point().Pick(testSuite.RandomStream())
+	}
+	return scalars, points
+}
+
+func testMultiScalarMul(t *testing.T, g kyber.Group) {
+	for _, n := range []int{1, 2, 7, 40, 130} {
+		scalars, points := randomMultiScalarMulInput(g, n)
+
+		// Small and zero scalars
+		scalars[0].SetInt64(1)
+		if n > 1 {
+			scalars[1].Zero()
+		}
+
+		actual, err := MultiScalarMul(scalars, points)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if expected := naiveMultiScalarMul(scalars, points); !expected.Equal(actual) {
+			t.Fatalf("n=%d: expected %s, got %s", n, expected, actual)
+		}
+	}
+
+	if _, err := MultiScalarMul(nil, nil); err == nil {
+		t.Fatal("expected error for empty input")
+	}
+	if _, err := MultiScalarMul(make([]kyber.Scalar, 2), make([]kyber.Point, 1)); err == nil {
+		t.Fatal("expected error for mismatching input")
+	}
+}
+
+func TestMultiScalarMulProjective(t *testing.T) {
+	testMultiScalarMul(t, new(ProjectiveCurve).Init(Param25519(), false))
+}
+
+func TestMultiScalarMulExtended(t *testing.T) {
+	testMultiScalarMul(t, new(ExtendedCurve).Init(ParamE521(), false))
+}
+
+func testConstantTimeMul(t *testing.T, g kyber.Group) {
+	stream := testSuite.XOF([]byte("constant time"))
+	order := g.Scalar().(*mod.Int).M
+
+	scalars := []kyber.Scalar{
+		g.Scalar().Zero(),
+		g.Scalar().One(),
+		g.Scalar().SetInt64(-1),
+		// Scalars of other fields, e.g. decrypted shares
+		mod.NewInt64(12345, fr.Modulus()),
+		mod.NewInt(new(big.Int).Lsh(order, 10), new(big.Int).Lsh(order, 11)),
+	}
+	for i := 0; i < 10; i++ {
+		scalars = append(scalars, g.Scalar().Pick(stream))
+	}
+
+	G := g.Point().Pick(stream)
+	for _, s := range scalars {
+		// Reference computed with big.Int arithmetic
+		expected := g.Point()
+		mulWNAF(expected.(mulPoint), G.(mulPoint), g.Point().Null(), &s.(*mod.Int).V)
+
+		if !expected.Equal(g.Point().(PublicMultiplier).MulPublic(s, G)) {
+			t.Fatalf("variable-time multiplication by %s differs", s)
+		}
+		if !expected.Equal(g.Point().Mul(s, G)) {
+			t.Fatalf("variable-base multiplication by %s differs", s)
+		}
+		if !expected.Equal(G.Clone().Mul(s, G)) {
+			t.Fatalf("in-place multiplication by %s differs", s)
+		}
+
+		expected = g.Point().(PublicMultiplier).MulPublic(s, nil)
+		if !expected.Equal(g.Point().Mul(s, nil)) {
+			t.Fatalf("base multiplication by %s differs", s)
+		}
+	}
+}
+
+func TestConstantTimeMulProjective(t *testing.T) {
+	g := new(ProjectiveCurve).Init(ParamBabyJubJub(), false)
+	if g.ct == nil {
+		t.Fatal("constant-time arithmetic not supported")
+	}
+	testConstantTimeMul(t, g)
+
+	if new(ProjectiveCurve).Init(Param25519(), false).ct != nil {
+		t.Fatal("constant-time arithmetic supported for a different field")
+	}
+}
+
+func TestConstantTimeMulExtended(t *testing.T) {
+	testConstantTimeMul(t, new(ExtendedCurve).Init(ParamBabyJubJub(), false))
+}
+
+func TestBabyJubJubBasePoints(t *testing.T) {
+	p := ParamBabyJubJub()
+	g := new(FrCurve).Init(p, true)
+	q := mod.NewInt(&p.Q, g.Scalar().(*mod.Int).M)
+
+	// The full-group base point generates the whole curve of order 8*Q
+	B := g.Point().Base()
+	for _, cofactor := range []int64{1, 2, 4} {
+		s := g.Scalar().Mul(q, g.Scalar().SetInt64(cofactor))
+		if g.Point().Mul(s, B).Equal(g.Point().Null()) {
+			t.Fatalf("full-group base point has order %d*Q", cofactor)
+		}
+	}
+	if !g.Point().Mul(g.Scalar().Mul(q, g.Scalar().SetInt64(8)), B).Equal(g.Point().Null()) {
+		t.Fatal("full-group base point has order other than 8*Q")
+	}
+
+	// The prime-order base point is the one used by ZoKrates
+	prime := NewBlakeSHA256BabyJubJub(false)
+	x, y := prime.Point().Base().(point).GetXY()
+	if x.V.Cmp(&p.PBX) != 0 || y.V.Cmp(&p.PBY) != 0 {
+		t.Fatal("unexpected prime-order base point")
+	}
+}
+
+func TestSubgroupCheck(t *testing.T) {
+	suite := NewBlakeSHA256BabyJubJub(false)
+	unchecked := new(FrCurve).Init(ParamBabyJubJub(), false)
+	full := new(FrCurve).Init(ParamBabyJubJub(), true)
+
+	// (0,-1) has order 2
+	small := full.Point().(point)
+	small.initXY(zero, new(big.Int).Sub(&suite.P, one), full)
+
+	base := suite.Point().Pick(suite.RandomStream())
+	cases := map[string]kyber.Point{
+		"order 2":         small,
+		"full-group base": full.Point().Base(),
+		"order 2*Q":       full.Point().Add(base, small),
+	}
+	for name, P := range cases {
+		b, err := P.MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := suite.Point().UnmarshalBinary(b); err == nil {
+			t.Errorf("%s: point outside of subgroup accepted", name)
+		}
+		if err := unchecked.Point().UnmarshalBinary(b); err != nil {
+			t.Errorf("%s: unchecked decoding failed: %v", name, err)
+		}
+	}
+
+	b, err := base.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	P := suite.Point()
+	if err := P.UnmarshalBinary(b); err != nil {
+		t.Fatal(err)
+	}
+	if !P.Equal(base) {
+		t.Fatal("decoded point differs")
+	}
+
+	// Non-canonical encoding replacing y by y+P
+	nc := new(big.Int).SetBytes(b)
+	nc.SetBit(nc, 255, 0).Add(nc, &suite.P)
+	enc := nc.FillBytes(make([]byte, 32))
+	enc[0] |= b[0] & 0x80
+	if err := suite.Point().UnmarshalBinary(enc); err == nil {
+		t.Fatal("non-canonical encoding accepted")
+	}
+}
@@ -157,3 +157,27 @@ func ParamE521() *Param {
 	p.PBY.SetString("12", 10)
 	return &p
 }
+
+// ParamBabyJubJub defines the BabyJubJub curve over the scalar field of BN254,
+// as specified in EIP-2494: https://eips.ethereum.org/EIPS/eip-2494
+//
+// The base point of the prime-order subgroup is the generator used by the
+// ZoKrates standard library, which differs from the base point B8 of EIP-2494.
+func ParamBabyJubJub() *Param {
+	var p Param
+	p.Name = "Baby Jubjub"
+	p.P.SetString("21888242871839275222246405745257275088548364400416034343698204186575808495617", 10)
+	p.Q.SetString("2736030358979909402780800718157159386076813972158567259200215660948447373041", 10)
+	p.R = 8
+	p.A.SetInt64(168700)
+	p.D.SetInt64(168696)
+	p.FBX.SetString("995203441582195749578291179787384436505546430278305826713579947235728471134", 10)
+	p.FBY.SetString("5472060717959818805561601436314318772137091100104008585924551046643952123905", 10)
+	p.PBX.SetString("16540640123574156134436876038791482806971768689494387082833631921987005038935", 10)
+	p.PBY.SetString("20819045374670962167435360035096875258406992893633759881276124905556507972311", 10)
+
+	// Smallest non-square u for Elligator2
+	p.Elligator2u.SetInt64(5)
+
+	return &p
+}
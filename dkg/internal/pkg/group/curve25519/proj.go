@@ -211,29 +211,73 @@ func (P *ProjPoint) double() {
 	P.Z.Mul(&F, &J)
 }
 
-// Multiply point p by scalar s using the repeated doubling method.
+// Multiply point p by scalar s.
+// On curves over the BN254 scalar field like BabyJubJub this runs in constant time,
+// so it is safe to use with secret scalars. On other curves it is the same as MulPublic.
 func (P *ProjPoint) Mul(s kyber.Scalar, G kyber.Point) kyber.Point {
-	v := s.(*mod.Int).V
-	if G == nil {
-		return P.Base().Mul(s, P)
+	ct := P.c.ct
+	if ct == nil {
+		return P.MulPublic(s, G)
 	}
-	T := P
-	if G == P { // Must use temporary for in-place multiply
-		T = &ProjPoint{}
+
+	v := &s.(*mod.Int).V
+	var R frPoint
+	if G == nil {
+		B := P.c.base.toFr()
+		R = ct.mulBase(&B, v)
+	} else {
+		Q := G.(*ProjPoint).toFr()
+		R = ct.mul(&Q, v)
 	}
-	T.Set(&P.c.null) // Initialize to identity element (0,1)
-	for i := v.BitLen() - 1; i >= 0; i-- {
-		T.double()
-		if v.Bit(i) != 0 {
-			T.Add(T, G)
+	P.setFr(&R)
+	return P
+}
+
+// MulPublic multiplies point p by scalar s in variable time.
+// Multiples of the standard base point are computed from a precomputed table,
+// any other point is multiplied using the non-adjacent form of the scalar.
+func (P *ProjPoint) MulPublic(s kyber.Scalar, G kyber.Point) kyber.Point {
+	v := &s.(*mod.Int).V
+	if ct := P.c.ct; ct != nil {
+		var R frPoint
+		if G == nil {
+			B := P.c.base.toFr()
+			R = ct.mulBaseVartime(&B, v)
+		} else {
+			Q := G.(*ProjPoint).toFr()
+			R = ct.mulVartime(&Q, v)
 		}
+		P.setFr(&R)
+		return P
 	}
-	if T != P {
-		P.Set(T)
+
+	if G == nil {
+		P.c.fixed.init(&P.c.base, &P.c.order.V)
+		P.c.fixed.mul(P, &P.c.null, v)
+		return P
 	}
+	// The multiples of G are precomputed before P is written,
+	// so no temporary is needed for in-place multiplication.
+	mulWNAF(P, G.(*ProjPoint), &P.c.null, v)
 	return P
 }
 
+// toFr converts P to the representation used by constant-time arithmetic.
+func (P *ProjPoint) toFr() frPoint {
+	var R frPoint
+	R.X.SetBigInt(&P.X.V)
+	R.Y.SetBigInt(&P.Y.V)
+	R.Z.SetBigInt(&P.Z.V)
+	return R
+}
+
+// setFr sets P to the point R computed by constant-time arithmetic.
+func (P *ProjPoint) setFr(R *frPoint) {
+	P.X.Init(R.X.BigInt(&P.X.V), &P.c.P)
+	P.Y.Init(R.Y.BigInt(&P.Y.V), &P.c.P)
+	P.Z.Init(R.Z.BigInt(&P.Z.V), &P.c.P)
+}
+
 // ProjectiveCurve implements Twisted Edwards curves
 // using projective coordinate representation (X:Y:Z),
 // satisfying the identities x = X/Z, y = Y/Z.
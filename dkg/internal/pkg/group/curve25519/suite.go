@@ -62,3 +62,53 @@ func NewBlakeSHA256Curve25519(fullGroup bool) *SuiteCurve25519 {
 	suite.Init(Param25519(), fullGroup)
 	return suite
 }
+
+// SuiteBabyJubJub is the suite for the BabyJubJub curve
+type SuiteBabyJubJub struct {
+	FrCurve
+}
+
+// Hash returns the instance associated with the suite
+func (s *SuiteBabyJubJub) Hash() hash.Hash {
+	return sha256.New()
+}
+
+// XOF creates the XOF associated with the suite
+func (s *SuiteBabyJubJub) XOF(seed []byte) kyber.XOF {
+	return blake2xb.New(seed)
+}
+
+func (s *SuiteBabyJubJub) Read(r io.Reader, objs ...interface{}) error {
+	return fixbuf.Read(r, s, objs)
+}
+
+func (s *SuiteBabyJubJub) Write(w io.Writer, objs ...interface{}) error {
+	return fixbuf.Write(w, objs)
+}
+
+// New implements the kyber.encoding interface
+func (s *SuiteBabyJubJub) New(t reflect.Type) interface{} {
+	return marshalling.GroupNew(s, t)
+}
+
+// RandomStream returns a cipher.Stream that returns a key stream
+// from crypto/rand.
+func (s *SuiteBabyJubJub) RandomStream() cipher.Stream {
+	return random.New()
+}
+
+// NewBlakeSHA256BabyJubJub returns a cipher suite based on package
+// go.dedis.ch/kyber/v3/xof/blake2xb, SHA-256, and BabyJubJub.
+//
+// If fullGroup is false, then the group is the prime-order subgroup,
+// and decoding rejects points outside of it.
+//
+// The scalars created by this group implement kyber.Scalar's SetBytes
+// method, interpreting the bytes as a big-endian integer, so as to be
+// compatible with the Go standard library's big.Int type.
+func NewBlakeSHA256BabyJubJub(fullGroup bool) *SuiteBabyJubJub {
+	suite := new(SuiteBabyJubJub)
+	suite.Init(ParamBabyJubJub(), fullGroup)
+	suite.SetSubgroupCheck(!fullGroup)
+	return suite
+}
@@ -0,0 +1,251 @@
+// Package fr contains arithmetic in the scalar field of the BN254 curve,
+// which is the base field of BabyJubJub.
+// Elements are stored as four 64-bit limbs in Montgomery form,
+// and all operations run in constant time unless documented otherwise.
+package fr
+
+import (
+	"math/big"
+	"math/bits"
+)
+
+// Element is a field element in Montgomery form, least-significant limb first.
+// The zero value is the zero element.
+type Element [4]uint64
+
+// Limbs of the field modulus
+var q = Element{0x43e1f593f0000001, 0x2833e84879b97091, 0xb85045b68181585d, 0x30644e72e131a029}
+
+// -q^-1 mod 2^64
+const qInvNeg = 0xc2e1f593efffffff
+
+// Montgomery constants R = 2^256 mod q and R^2 mod q
+var rOne = Element{0xac96341c4ffffffb, 0x36fc76959f60cd29, 0x666ea36f7879462e, 0x0e0a77c19a07df2f}
+var rSquare = Element{0x1bb8e645ae216da7, 0x53fe3ab1e35c59e3, 0x8c49833d53bb8085, 0x0216d0b17f4e44a5}
+
+var modulus, _ = new(big.Int).SetString("21888242871839275222246405745257275088548364400416034343698204186575808495617", 10)
+
+// Modulus returns the field modulus.
+func Modulus() *big.Int {
+	return new(big.Int).Set(modulus)
+}
+
+// SetZero sets z to 0.
+func (z *Element) SetZero() *Element {
+	*z = Element{}
+	return z
+}
+
+// SetOne sets z to 1.
+func (z *Element) SetOne() *Element {
+	*z = rOne
+	return z
+}
+
+// Set sets z to x.
+func (z *Element) Set(x *Element) *Element {
+	*z = *x
+	return z
+}
+
+// SetUint64 sets z to v.
+func (z *Element) SetUint64(v uint64) *Element {
+	*z = Element{v}
+	return z.Mul(z, &rSquare)
+}
+
+// SetBigInt sets z to v mod q.
+// Reducing v and reading its limbs takes time depending on the size of v.
+func (z *Element) SetBigInt(v *big.Int) *Element {
+	if v.Sign() < 0 || v.Cmp(modulus) >= 0 {
+		v = new(big.Int).Mod(v, modulus)
+	}
+	*z = Element{}
+	for i, w := range v.Bits() {
+		// Words are either 32 or 64 bits wide
+		if bits.UintSize == 64 {
+			z[i] = uint64(w)
+		} else {
+			z[i/2] |= uint64(w) << (32 * (i % 2))
+		}
+	}
+	return z.Mul(z, &rSquare)
+}
+
+// BigInt sets res to the value of z and returns res.
+func (z *Element) BigInt(res *big.Int) *big.Int {
+	var r Element
+	r.fromMont(z)
+
+	var b [32]byte
+	for i := 0; i < 4; i++ {
+		for j := 0; j < 8; j++ {
+			b[31-8*i-j] = byte(r[i] >> (8 * j))
+		}
+	}
+	return res.SetBytes(b[:])
+}
+
+// String returns the decimal representation of z.
+func (z *Element) String() string {
+	return z.BigInt(new(big.Int)).String()
+}
+
+// Equal returns 1 if z equals x and 0 otherwise.
+func (z *Element) Equal(x *Element) int {
+	var acc uint64
+	for i := range z {
+		acc |= z[i] ^ x[i]
+	}
+	return isZero(acc)
+}
+
+// IsZero returns 1 if z is zero and 0 otherwise.
+func (z *Element) IsZero() int {
+	return isZero(z[0] | z[1] | z[2] | z[3])
+}
+
+// Select sets z to a if cond is 1 and to b if cond is 0.
+func (z *Element) Select(cond int, a, b *Element) *Element {
+	mask := -uint64(cond)
+	for i := range z {
+		z[i] = b[i] ^ (mask & (a[i] ^ b[i]))
+	}
+	return z
+}
+
+// Add sets z to x+y.
+func (z *Element) Add(x, y *Element) *Element {
+	var t Element
+	var carry uint64
+	t[0], carry = bits.Add64(x[0], y[0], 0)
+	t[1], carry = bits.Add64(x[1], y[1], carry)
+	t[2], carry = bits.Add64(x[2], y[2], carry)
+	t[3], carry = bits.Add64(x[3], y[3], carry)
+	return z.reduce(&t, carry)
+}
+
+// Double sets z to 2x.
+func (z *Element) Double(x *Element) *Element {
+	return z.Add(x, x)
+}
+
+// Sub sets z to x-y.
+func (z *Element) Sub(x, y *Element) *Element {
+	var borrow, carry uint64
+	z[0], borrow = bits.Sub64(x[0], y[0], 0)
+	z[1], borrow = bits.Sub64(x[1], y[1], borrow)
+	z[2], borrow = bits.Sub64(x[2], y[2], borrow)
+	z[3], borrow = bits.Sub64(x[3], y[3], borrow)
+
+	// Add the modulus back on underflow
+	mask := -borrow
+	z[0], carry = bits.Add64(z[0], q[0]&mask, 0)
+	z[1], carry = bits.Add64(z[1], q[1]&mask, carry)
+	z[2], carry = bits.Add64(z[2], q[2]&mask, carry)
+	z[3], _ = bits.Add64(z[3], q[3]&mask, carry)
+	return z
+}
+
+// Neg sets z to -x.
+func (z *Element) Neg(x *Element) *Element {
+	var zero Element
+	return z.Sub(&zero, x)
+}
+
+// Mul sets z to x*y using coarsely integrated operand scanning.
+// The top limb of the modulus leaves enough headroom to skip the final carry word.
+func (z *Element) Mul(x, y *Element) *Element {
+	var t0, t1, t2, t3 uint64
+	for i := 0; i < 4; i++ {
+		// t = (t + x*y[i] + m*q) / 2^64, where m is chosen to clear the lowest limb
+		v := y[i]
+		var A, C uint64
+		A, t0 = madd1(v, x[0], t0)
+		m := t0 * qInvNeg
+		C = madd0(m, q[0], t0)
+		A, t1 = madd2(v, x[1], A, t1)
+		C, t0 = madd2(m, q[1], t1, C)
+		A, t2 = madd2(v, x[2], A, t2)
+		C, t1 = madd2(m, q[2], t2, C)
+		A, t3 = madd2(v, x[3], A, t3)
+		C, t2 = madd2(m, q[3], t3, C)
+		t3 = C + A
+	}
+	return z.reduce(&Element{t0, t1, t2, t3}, 0)
+}
+
+// Square sets z to x*x.
+func (z *Element) Square(x *Element) *Element {
+	return z.Mul(x, x)
+}
+
+// Inverse sets z to the multiplicative inverse of x using Fermat's little theorem.
+// The inverse of zero is zero.
+func (z *Element) Inverse(x *Element) *Element {
+	return z.exp(x, new(big.Int).Sub(modulus, big.NewInt(2)))
+}
+
+// exp sets z to x^e.
+// The running time depends on the exponent, which has to be public.
+func (z *Element) exp(x *Element, e *big.Int) *Element {
+	var b, r Element
+	b.Set(x)
+	r.SetOne()
+	for i := e.BitLen() - 1; i >= 0; i-- {
+		r.Square(&r)
+		if e.Bit(i) == 1 {
+			r.Mul(&r, &b)
+		}
+	}
+	return z.Set(&r)
+}
+
+// fromMont sets z to x*R^-1, converting x out of Montgomery form.
+func (z *Element) fromMont(x *Element) *Element {
+	return z.Mul(x, &Element{1})
+}
+
+// reduce sets z to t mod q, where t + hi*2^256 < 2q.
+func (z *Element) reduce(t *Element, hi uint64) *Element {
+	var s Element
+	var borrow uint64
+	s[0], borrow = bits.Sub64(t[0], q[0], 0)
+	s[1], borrow = bits.Sub64(t[1], q[1], borrow)
+	s[2], borrow = bits.Sub64(t[2], q[2], borrow)
+	s[3], borrow = bits.Sub64(t[3], q[3], borrow)
+	_, borrow = bits.Sub64(hi, 0, borrow)
+
+	// Keep the difference unless the subtraction underflowed
+	return z.Select(int(borrow^1), &s, t)
+}
+
+// madd0 returns the high word of a*b + c.
+func madd0(a, b, c uint64) uint64 {
+	hi, lo := bits.Mul64(a, b)
+	_, carry := bits.Add64(lo, c, 0)
+	return hi + carry
+}
+
+// madd1 returns the high and low word of a*b + c.
+func madd1(a, b, c uint64) (hi, lo uint64) {
+	var carry uint64
+	hi, lo = bits.Mul64(a, b)
+	lo, carry = bits.Add64(lo, c, 0)
+	return hi + carry, lo
+}
+
+// madd2 returns the high and low word of a*b + c + d.
+func madd2(a, b, c, d uint64) (hi, lo uint64) {
+	var carry uint64
+	hi, lo = bits.Mul64(a, b)
+	c, carry = bits.Add64(c, d, 0)
+	hi += carry
+	lo, carry = bits.Add64(lo, c, 0)
+	return hi + carry, lo
+}
+
+// isZero returns 1 if v is zero and 0 otherwise.
+func isZero(v uint64) int {
+	return int(1 ^ (v|-v)>>63)
+}
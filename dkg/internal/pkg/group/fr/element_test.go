@@ -0,0 +1,120 @@
+package fr
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+)
+
+func randomBig(t *testing.T) *big.Int {
+	v, err := rand.Int(rand.Reader, modulus)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return v
+}
+
+func TestConstants(t *testing.T) {
+	R := new(big.Int).Lsh(big.NewInt(1), 256)
+
+	var e Element
+	if e.SetOne().BigInt(new(big.Int)).Cmp(big.NewInt(1)) != 0 {
+		t.Fatal("wrong Montgomery form of one")
+	}
+	if rSquare.BigInt(new(big.Int)).Cmp(new(big.Int).Mod(R, modulus)) != 0 {
+		t.Fatal("wrong Montgomery form of R")
+	}
+	word := new(big.Int).Lsh(big.NewInt(1), 64)
+	prod := new(big.Int).Mul(modulus, new(big.Int).SetUint64(qInvNeg))
+	if prod.Add(prod, big.NewInt(1)).Mod(prod, word).Sign() != 0 {
+		t.Fatal("wrong Montgomery inverse")
+	}
+}
+
+func TestArithmetic(t *testing.T) {
+	edge := []*big.Int{
+		big.NewInt(0),
+		big.NewInt(1),
+		new(big.Int).Sub(modulus, big.NewInt(1)),
+		new(big.Int).Rsh(modulus, 1),
+	}
+
+	values := edge
+	for i := 0; i < 50; i++ {
+		values = append(values, randomBig(t))
+	}
+
+	check := func(op string, e *Element, expected *big.Int) {
+		t.Helper()
+		expected.Mod(expected, modulus)
+		if actual := e.BigInt(new(big.Int)); actual.Cmp(expected) != 0 {
+			t.Fatalf("%s: expected %s, got %s", op, expected, actual)
+		}
+	}
+
+	for _, a := range values {
+		for _, b := range values[:10] {
+			var x, y, z Element
+			x.SetBigInt(a)
+			y.SetBigInt(b)
+
+			check("set", &x, new(big.Int).Set(a))
+			check("add", z.Add(&x, &y), new(big.Int).Add(a, b))
+			check("sub", z.Sub(&x, &y), new(big.Int).Sub(a, b))
+			check("mul", z.Mul(&x, &y), new(big.Int).Mul(a, b))
+			check("square", z.Square(&x), new(big.Int).Mul(a, a))
+			check("neg", z.Neg(&x), new(big.Int).Neg(a))
+			check("double", z.Double(&x), new(big.Int).Lsh(a, 1))
+
+			if x.Equal(&y) != boolToInt(a.Cmp(b) == 0) {
+				t.Fatal("equal")
+			}
+			check("select", z.Select(1, &x, &y), new(big.Int).Set(a))
+			check("select", z.Select(0, &x, &y), new(big.Int).Set(b))
+		}
+
+		var x, z Element
+		x.SetBigInt(a)
+		if a.Sign() == 0 {
+			check("inverse", z.Inverse(&x), big.NewInt(0))
+			if x.IsZero() != 1 {
+				t.Fatal("zero")
+			}
+		} else {
+			check("inverse", z.Inverse(&x), new(big.Int).ModInverse(a, modulus))
+			if x.IsZero() != 0 {
+				t.Fatal("nonzero")
+			}
+		}
+	}
+
+	// Aliasing of inputs and outputs
+	a, b := randomBig(t), randomBig(t)
+	var x, y Element
+	x.SetBigInt(a)
+	y.SetBigInt(b)
+	check("aliased mul", x.Mul(&x, &y), new(big.Int).Mul(a, b))
+	check("aliased sub", y.Sub(&x, &y), new(big.Int).Sub(new(big.Int).Mul(a, b), b))
+
+	// Inputs exceeding the modulus are reduced
+	check("reduce", x.SetBigInt(new(big.Int).Lsh(a, 300)), new(big.Int).Lsh(a, 300))
+	check("reduce", x.SetBigInt(new(big.Int).Neg(a)), new(big.Int).Neg(a))
+	check("uint64", x.SetUint64(1<<63+5), new(big.Int).SetUint64(1<<63+5))
+}
+
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+func BenchmarkMul(b *testing.B) {
+	var x, y Element
+	x.SetUint64(123456789)
+	y.SetBigInt(new(big.Int).Sub(modulus, big.NewInt(42)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		x.Mul(&x, &y)
+	}
+}
@@ -376,7 +376,8 @@ func (i *Int) BigEndian(min, max int) []byte {
 		panic("Int not representable in max bytes")
 	}
 	buf := make([]byte, pad)
-	copy(buf[ofs:], i.V.Bytes())
+	b := i.V.Bytes() // may be shorter than act
+	copy(buf[ofs+act-len(b):], b)
 	return buf
 }
 
@@ -98,3 +98,76 @@ func TestIntClone(t *testing.T) {
 		t.Error("Should not be equal")
 	}
 }
+
+// Fuzz the decoding of integers modulo the order of the underlying field of BabyJubJub,
+// which are received as untrusted on-chain input.
+
+var fuzzModulus, _ = new(big.Int).SetString("21888242871839275222246405745257275088548364400416034343698204186575808495617", 10)
+
+func fuzzByteOrder(littleEndian bool) ByteOrder {
+	if littleEndian {
+		return LittleEndian
+	}
+	return BigEndian
+}
+
+func fuzzSeedInts(f *testing.F) {
+	for _, b := range [][]byte{nil, {0}, {1}, fuzzModulus.Bytes(), new(big.Int).Sub(fuzzModulus, big.NewInt(1)).Bytes(), bytes.Repeat([]byte{0xff}, 32), bytes.Repeat([]byte{0xff}, 33)} {
+		f.Add(b, false)
+		f.Add(b, true)
+	}
+}
+
+func FuzzIntSetBytes(f *testing.F) {
+	fuzzSeedInts(f)
+	f.Fuzz(func(t *testing.T, b []byte, littleEndian bool) {
+		i := NewInt64(0, fuzzModulus)
+		i.BO = fuzzByteOrder(littleEndian)
+		i.SetBytes(b)
+
+		be := b
+		if littleEndian {
+			be = reverse(nil, b)
+		}
+		v := new(big.Int).SetBytes(be)
+		require.Zero(t, v.Mod(v, fuzzModulus).Cmp(&i.V))
+	})
+}
+
+func FuzzIntUnmarshalBinary(f *testing.F) {
+	fuzzSeedInts(f)
+	f.Fuzz(func(t *testing.T, b []byte, littleEndian bool) {
+		i := NewInt64(0, fuzzModulus)
+		i.BO = fuzzByteOrder(littleEndian)
+		if err := i.UnmarshalBinary(b); err != nil {
+			return
+		}
+		require.Equal(t, i.MarshalSize(), len(b))
+		require.Equal(t, -1, i.V.Cmp(fuzzModulus))
+
+		e, err := i.MarshalBinary()
+		require.NoError(t, err)
+		require.Equal(t, b, e)
+	})
+}
+
+func FuzzIntEndian(f *testing.F) {
+	fuzzSeedInts(f)
+	f.Fuzz(func(t *testing.T, b []byte, _ bool) {
+		i := NewInt64(0, fuzzModulus)
+		i.SetBytes(b)
+		l := i.MarshalSize()
+
+		be := i.BigEndian(0, 0)
+		require.Len(t, be, l)
+		require.True(t, i.Equal(NewIntBytes(be, fuzzModulus, BigEndian)))
+		require.Equal(t, be, i.BigEndian(l, l))
+		require.Equal(t, append(make([]byte, 8), be...), i.BigEndian(l+8, 0))
+
+		le := i.LittleEndian(l, l)
+		require.Len(t, le, l)
+		require.True(t, i.Equal(NewIntBytes(le, fuzzModulus, LittleEndian)))
+		require.Equal(t, reverse(nil, be), le)
+		require.True(t, i.Equal(NewIntBytes(i.LittleEndian(0, 0), fuzzModulus, LittleEndian)))
+	})
+}
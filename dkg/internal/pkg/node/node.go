@@ -0,0 +1,46 @@
+// Package node runs a node of the distributed key generation, shared by zkdkg run and cmd/full_node.
+package node
+
+import (
+	"client/pkg/dkg"
+	"flag"
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// Run defines the flags of a node on fs, parses them from args and takes part in the distributed key generation.
+func Run(fs *flag.FlagSet, args []string) error {
+	configFile := fs.String("c", "./configs/config.json", "filename of the config file")
+	idPipe := fs.String("id-pipe", "", "filename of the named pipe used for writing the docker IDs of the zokrates containers")
+	disputeValid := fs.Bool("dispute-valid", false, "whether the node should dispute the commitment of the first participant")
+	broadcastOnly := fs.Bool("broadcast-only", false, "only generate and broadcast shares and commitments, then exit, after withdrawing the stake once the protocol ends if staked")
+	resultFile := fs.String("result", "", "filename of the JSON result written when the run ends")
+	shareFile := fs.String("share", "", "filename of the distributed key share written after a successful run")
+	skipArtifactChecks := fs.Bool("skip-artifact-checks", false, "run with artifacts that have no manifest, without checking their integrity and verifier keys")
+	fs.Parse(args)
+
+	config, err := dkg.LoadConfig(*configFile)
+	if err != nil {
+		return err
+	}
+	config.SkipArtifactChecks = config.SkipArtifactChecks || *skipArtifactChecks
+
+	gen, err := dkg.NewDistributedKeyGenerator(config, *idPipe, *disputeValid, *broadcastOnly)
+	if err != nil {
+		return fmt.Errorf("initializing DKG protocol: %w", err)
+	}
+
+	pub, err := gen.Generate()
+	if writeErr := gen.WriteOutputs(pub, err, *resultFile, *shareFile); writeErr != nil {
+		log.Errorf("Writing outputs: %v", writeErr)
+	}
+	if err != nil {
+		return fmt.Errorf("executing DKG protocol: %w", err)
+	}
+
+	if !*broadcastOnly {
+		log.Infof("Public Key: %+v", pub)
+	}
+	return nil
+}
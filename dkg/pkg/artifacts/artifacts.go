@@ -0,0 +1,263 @@
+// Package artifacts manages the compiled circuits and keys the prover needs.
+//
+// The artifacts of all circuits for one number of participants live in a Dir,
+// laid out as written by scripts/build.sh:
+//
+//	<dir>/manifest.json
+//	<dir>/<circuit>/{out,abi.json,proving.key,verification.key}
+//
+// The manifest records the SHA-256 checksum of every file, tying each proving key
+// to the verification key embedded in the verifier contract of its circuit.
+package artifacts
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// ManifestFile is the name of the manifest within a Dir.
+const ManifestFile = "manifest.json"
+
+// Files are the artifacts of a circuit that are recorded in the manifest.
+var Files = []string{"out", "abi.json", "proving.key", "verification.key"}
+
+// Circuits maps the circuits of the protocol to the names of their verifier contracts.
+var Circuits = map[string]string{
+	"poly_eval": "ShareVerifier",
+	"key_deriv": "KeyVerifier",
+}
+
+// ErrNoManifest is returned when a Dir has no manifest, e.g. because it was built before manifests were recorded.
+var ErrNoManifest = errors.New("no manifest")
+
+// Manifest describes the artifacts of all circuits for one number of participants.
+type Manifest struct {
+	Participants int                 `json:"participants"`
+	Circuits     map[string]*Circuit `json:"circuits"`
+}
+
+// Circuit describes the artifacts of a single circuit.
+type Circuit struct {
+	Verifier string            `json:"verifier"` // Name of the verifier contract embedding the verification key
+	Files    map[string]string `json:"files"`    // Hex-encoded SHA-256 checksums by file name
+}
+
+func (m *Manifest) circuitNames() []string {
+	names := make([]string, 0, len(m.Circuits))
+	for name := range m.Circuits {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (m *Manifest) validate() error {
+	if m.Participants <= 0 {
+		return fmt.Errorf("invalid number of participants %d", m.Participants)
+	}
+	for name := range Circuits {
+		c, ok := m.Circuits[name]
+		if !ok {
+			return fmt.Errorf("circuit %s missing", name)
+		}
+		for _, file := range Files {
+			if _, ok := c.Files[file]; !ok {
+				return fmt.Errorf("circuit %s: file %s missing", name, file)
+			}
+		}
+	}
+	for name, c := range m.Circuits {
+		for file := range c.Files {
+			if err := checkName(file); err != nil {
+				return fmt.Errorf("circuit %s: %w", name, err)
+			}
+		}
+		if err := checkName(name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkName rejects names that could escape a Dir when taken from an untrusted manifest.
+func checkName(name string) error {
+	if name == "" || name == "." || name == ".." || strings.ContainsAny(name, `/\`) {
+		return fmt.Errorf("invalid name %q", name)
+	}
+	return nil
+}
+
+// Dir holds the artifacts of all circuits for one number of participants.
+type Dir string
+
+// Store holds the artifacts for several numbers of participants below a root directory,
+// laid out as <root>/<participants>/zk like the build directory of the repository.
+type Store string
+
+// Dir returns the directory of the artifacts for the given number of participants.
+func (s Store) Dir(participants int) Dir {
+	return Dir(filepath.Join(string(s), fmt.Sprint(participants), "zk"))
+}
+
+func (d Dir) path(elem ...string) string {
+	return filepath.Join(append([]string{string(d)}, elem...)...)
+}
+
+// ReadManifest reads the manifest of the directory without verifying the artifacts.
+func (d Dir) ReadManifest() (*Manifest, error) {
+	b, err := os.ReadFile(d.path(ManifestFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrNoManifest
+	} else if err != nil {
+		return nil, fmt.Errorf("read manifest: %w", err)
+	}
+	return decodeManifest(b)
+}
+
+func decodeManifest(b []byte) (*Manifest, error) {
+	var m Manifest
+	if err := json.Unmarshal(b, &m); err != nil {
+		return nil, fmt.Errorf("decode manifest: %w", err)
+	}
+	if err := m.validate(); err != nil {
+		return nil, fmt.Errorf("invalid manifest: %w", err)
+	}
+	return &m, nil
+}
+
+// Record computes the checksums of the artifacts in the directory and writes its manifest.
+func (d Dir) Record(participants int) (*Manifest, error) {
+	m := &Manifest{
+		Participants: participants,
+		Circuits:     make(map[string]*Circuit),
+	}
+	for name, verifier := range Circuits {
+		c := &Circuit{
+			Verifier: verifier,
+			Files:    make(map[string]string),
+		}
+		for _, file := range Files {
+			sum, err := checksumFile(d.path(name, file))
+			if err != nil {
+				return nil, fmt.Errorf("circuit %s: %w", name, err)
+			}
+			c.Files[file] = sum
+		}
+		m.Circuits[name] = c
+	}
+
+	b, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("encode manifest: %w", err)
+	}
+	if err := os.WriteFile(d.path(ManifestFile), append(b, '\n'), 0644); err != nil {
+		return nil, fmt.Errorf("write manifest: %w", err)
+	}
+	return m, nil
+}
+
+// Verify checks the artifacts in the directory against the checksums of its manifest.
+func (d Dir) Verify() (*Manifest, error) {
+	m, err := d.ReadManifest()
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range m.circuitNames() {
+		for file, want := range m.Circuits[name].Files {
+			sum, err := checksumFile(d.path(name, file))
+			if err != nil {
+				return nil, fmt.Errorf("circuit %s: %w", name, err)
+			}
+			if !strings.EqualFold(sum, want) {
+				return nil, fmt.Errorf("circuit %s: checksum mismatch of %s: have %s, want %s", name, file, sum, want)
+			}
+		}
+	}
+	return m, nil
+}
+
+// CheckVerifier checks that the verification key of the circuit is the one embedded in the given runtime bytecode
+// of its verifier contract, so that the proving key produces proofs the verifier accepts.
+// The verifier exported by zokrates holds every coordinate of the key as a constant.
+func (d Dir) CheckVerifier(circuit string, code []byte) error {
+	if len(code) == 0 {
+		return fmt.Errorf("%s: no verifier contract deployed", circuit)
+	}
+
+	b, err := os.ReadFile(d.path(circuit, "verification.key"))
+	if err != nil {
+		return fmt.Errorf("%s: read verification key: %w", circuit, err)
+	}
+	constants, err := verificationKeyConstants(b)
+	if err != nil {
+		return fmt.Errorf("%s: %w", circuit, err)
+	}
+	for _, c := range constants {
+		if !bytes.Contains(code, c.Bytes()) {
+			return fmt.Errorf("%s: verification key doesn't match the verifier contract", circuit)
+		}
+	}
+	return nil
+}
+
+// verificationKeyConstants returns all curve point coordinates of a verification key written by zokrates setup.
+func verificationKeyConstants(b []byte) ([]*big.Int, error) {
+	var vk map[string]interface{}
+	if err := json.Unmarshal(b, &vk); err != nil {
+		return nil, fmt.Errorf("decode verification key: %w", err)
+	}
+
+	var constants []*big.Int
+	var collect func(v interface{}) error
+	collect = func(v interface{}) error {
+		switch v := v.(type) {
+		case []interface{}:
+			for _, e := range v {
+				if err := collect(e); err != nil {
+					return err
+				}
+			}
+		case string:
+			c, ok := new(big.Int).SetString(strings.TrimPrefix(v, "0x"), 16)
+			if !ok {
+				return fmt.Errorf("invalid coordinate %q", v)
+			}
+			constants = append(constants, c)
+		}
+		return nil
+	}
+	for _, key := range []string{"alpha", "beta", "gamma", "delta", "gamma_abc"} {
+		v, ok := vk[key]
+		if !ok {
+			return nil, fmt.Errorf("verification key lacks %s", key)
+		}
+		if err := collect(v); err != nil {
+			return nil, fmt.Errorf("%s: %w", key, err)
+		}
+	}
+	return constants, nil
+}
+
+func checksumFile(name string) (string, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", fmt.Errorf("read %s: %w", name, err)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
@@ -0,0 +1,161 @@
+package artifacts
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const verificationKey = `{
+  "scheme": "g16",
+  "curve": "bn128",
+  "alpha": ["0x01a1", "0x01a2"],
+  "beta": [["0x01b1", "0x01b2"], ["0x01b3", "0x01b4"]],
+  "gamma": [["0x01c1", "0x01c2"], ["0x01c3", "0x01c4"]],
+  "delta": [["0x01d1", "0x01d2"], ["0x01d3", "0x01d4"]],
+  "gamma_abc": [["0x01e1", "0x01e2"], ["0x01e3", "0x01e4"]]
+}`
+
+// writeArtifacts fills the directory with placeholder artifacts as produced by scripts/build.sh.
+func writeArtifacts(t *testing.T, d Dir) {
+	for name := range Circuits {
+		require.NoError(t, os.MkdirAll(d.path(name), 0755))
+		for _, file := range Files {
+			content := name + "/" + file
+			if file == "verification.key" {
+				content = verificationKey
+			}
+			require.NoError(t, os.WriteFile(d.path(name, file), []byte(content), 0644))
+		}
+	}
+}
+
+// verifierCode returns bytecode embedding the constants of verificationKey.
+func verifierCode(t *testing.T) []byte {
+	constants, err := verificationKeyConstants([]byte(verificationKey))
+	require.NoError(t, err)
+	require.Len(t, constants, 18)
+
+	code := []byte{0x60, 0x80}
+	for _, c := range constants {
+		code = append(code, 0x7f)
+		code = append(code, c.FillBytes(make([]byte, 32))...)
+	}
+	return code
+}
+
+func TestRecordVerify(t *testing.T) {
+	d := Store(t.TempDir()).Dir(4)
+	writeArtifacts(t, d)
+
+	_, err := d.Verify()
+	require.True(t, errors.Is(err, ErrNoManifest))
+
+	recorded, err := d.Record(4)
+	require.NoError(t, err)
+
+	m, err := d.Verify()
+	require.NoError(t, err)
+	require.Equal(t, recorded, m)
+	require.Equal(t, 4, m.Participants)
+	require.Equal(t, "ShareVerifier", m.Circuits["poly_eval"].Verifier)
+
+	require.NoError(t, os.WriteFile(d.path("key_deriv", "proving.key"), []byte("stale"), 0644))
+	_, err = d.Verify()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "checksum mismatch of proving.key")
+
+	require.NoError(t, os.Remove(d.path("key_deriv", "proving.key")))
+	_, err = d.Verify()
+	require.Error(t, err)
+}
+
+func TestFetch(t *testing.T) {
+	src := Dir(t.TempDir())
+	writeArtifacts(t, src)
+	_, err := src.Record(4)
+	require.NoError(t, err)
+
+	server := httptest.NewServer(http.FileServer(http.Dir(string(src))))
+	defer server.Close()
+
+	for _, s := range []Source{NewSource(server.URL), NewSource(string(src))} {
+		d := Store(t.TempDir()).Dir(4)
+
+		_, err := d.Fetch(context.Background(), s, 5)
+		require.Error(t, err)
+		_, err = os.Stat(string(d))
+		require.True(t, errors.Is(err, os.ErrNotExist))
+
+		fetched, err := d.Fetch(context.Background(), s, 4)
+		require.NoError(t, err)
+
+		m, err := d.Verify()
+		require.NoError(t, err)
+		require.Equal(t, fetched, m)
+
+		// Fetching again only replaces changed files
+		require.NoError(t, os.WriteFile(d.path("poly_eval", "out"), []byte("corrupted"), 0644))
+		_, err = d.Fetch(context.Background(), s, 4)
+		require.NoError(t, err)
+		_, err = d.Verify()
+		require.NoError(t, err)
+	}
+}
+
+func TestFetchCorrupted(t *testing.T) {
+	src := Dir(t.TempDir())
+	writeArtifacts(t, src)
+	_, err := src.Record(4)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(src.path("poly_eval", "proving.key"), []byte("stale"), 0644))
+
+	server := httptest.NewServer(http.FileServer(http.Dir(string(src))))
+	defer server.Close()
+
+	d := Dir(t.TempDir())
+	_, err = d.Fetch(context.Background(), NewSource(server.URL), 4)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "checksum mismatch")
+
+	_, err = d.Verify()
+	require.True(t, errors.Is(err, ErrNoManifest))
+	_, err = os.Stat(d.path("poly_eval", "proving.key"))
+	require.True(t, errors.Is(err, os.ErrNotExist))
+}
+
+func TestDecodeManifest(t *testing.T) {
+	// Names taken from a fetched manifest must not escape the directory
+	_, err := decodeManifest([]byte(`{"participants": 4, "circuits": {"poly_eval": {"files": {"../../escape": ""}}}}`))
+	require.Error(t, err)
+
+	_, err = decodeManifest([]byte(`{"participants": 4, "circuits": {}}`))
+	require.Error(t, err)
+}
+
+func TestCheckVerifier(t *testing.T) {
+	d := Dir(t.TempDir())
+	writeArtifacts(t, d)
+
+	code := verifierCode(t)
+	require.NoError(t, d.CheckVerifier("poly_eval", code))
+
+	// A verifier exported from a different verification key
+	stale := append([]byte{}, code...)
+	copy(stale[len(stale)-32:], new(big.Int).SetInt64(0x0abc).FillBytes(make([]byte, 32)))
+	require.Error(t, d.CheckVerifier("poly_eval", stale))
+
+	require.Error(t, d.CheckVerifier("poly_eval", nil))
+	require.Error(t, d.CheckVerifier("unknown", code))
+}
+
+func TestStore(t *testing.T) {
+	require.Equal(t, Dir(filepath.Join("build", "8", "zk")), Store("build").Dir(8))
+}
@@ -0,0 +1,153 @@
+package artifacts
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// maxManifestSize bounds the size of a fetched manifest.
+const maxManifestSize = 1 << 20
+
+// Source provides the artifacts of a Dir for one number of participants,
+// with names relative to the directory such as "manifest.json" or "poly_eval/proving.key".
+type Source interface {
+	Open(ctx context.Context, name string) (io.ReadCloser, error)
+}
+
+// NewSource returns an HTTPSource for http and https URLs and a DirSource for everything else.
+func NewSource(location string) Source {
+	if u, err := url.Parse(location); err == nil && (u.Scheme == "http" || u.Scheme == "https") {
+		return &HTTPSource{BaseURL: location}
+	}
+	return DirSource(location)
+}
+
+// DirSource reads artifacts from a local directory.
+type DirSource string
+
+func (s DirSource) Open(_ context.Context, name string) (io.ReadCloser, error) {
+	return os.Open(filepath.Join(string(s), filepath.FromSlash(name)))
+}
+
+// HTTPSource downloads artifacts from a mirror serving the layout of a Dir below BaseURL.
+type HTTPSource struct {
+	BaseURL string
+	Client  *http.Client // http.DefaultClient if nil
+}
+
+func (s *HTTPSource) Open(ctx context.Context, name string) (io.ReadCloser, error) {
+	u, err := url.Parse(s.BaseURL)
+	if err != nil {
+		return nil, fmt.Errorf("parse base url: %w", err)
+	}
+	u.Path = path.Join(u.Path, name)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("new request: %w", err)
+	}
+
+	client := s.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("get %s: %w", u, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("get %s: %s", u, resp.Status)
+	}
+	return resp.Body, nil
+}
+
+// Fetch downloads the artifacts for the given number of participants from the source into the directory.
+// Every file is checked against the checksum in the fetched manifest before it replaces an existing one,
+// and the manifest is written last, so an interrupted fetch never leaves a directory that passes Verify.
+func (d Dir) Fetch(ctx context.Context, src Source, participants int) (*Manifest, error) {
+	r, err := src.Open(ctx, ManifestFile)
+	if err != nil {
+		return nil, fmt.Errorf("open manifest: %w", err)
+	}
+	b, err := io.ReadAll(io.LimitReader(r, maxManifestSize))
+	r.Close()
+	if err != nil {
+		return nil, fmt.Errorf("read manifest: %w", err)
+	}
+
+	m, err := decodeManifest(b)
+	if err != nil {
+		return nil, err
+	}
+	if m.Participants != participants {
+		return nil, fmt.Errorf("source provides artifacts for %d participants, not %d", m.Participants, participants)
+	}
+
+	// Remove a stale manifest first, the files it describes are about to change
+	if err := os.Remove(d.path(ManifestFile)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("remove manifest: %w", err)
+	}
+
+	for _, name := range m.circuitNames() {
+		if err := os.MkdirAll(d.path(name), 0755); err != nil {
+			return nil, fmt.Errorf("create directory: %w", err)
+		}
+		for file, sum := range m.Circuits[name].Files {
+			if err := d.fetchFile(ctx, src, name+"/"+file, sum); err != nil {
+				return nil, fmt.Errorf("circuit %s: %w", name, err)
+			}
+		}
+	}
+
+	if err := os.WriteFile(d.path(ManifestFile), b, 0644); err != nil {
+		return nil, fmt.Errorf("write manifest: %w", err)
+	}
+	return m, nil
+}
+
+func (d Dir) fetchFile(ctx context.Context, src Source, name, sum string) error {
+	dst := d.path(filepath.FromSlash(name))
+	if have, err := checksumFile(dst); err == nil && strings.EqualFold(have, sum) {
+		return nil
+	}
+
+	r, err := src.Open(ctx, name)
+	if err != nil {
+		return fmt.Errorf("open %s: %w", name, err)
+	}
+	defer r.Close()
+
+	tmp, err := os.CreateTemp(filepath.Dir(dst), "."+filepath.Base(dst)+".*")
+	if err != nil {
+		return fmt.Errorf("create temporary file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	h := sha256.New()
+	_, err = io.Copy(io.MultiWriter(tmp, h), r)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("download %s: %w", name, err)
+	}
+
+	if have := hex.EncodeToString(h.Sum(nil)); !strings.EqualFold(have, sum) {
+		return fmt.Errorf("checksum mismatch of %s: have %s, want %s", name, have, sum)
+	}
+	if err := os.Chmod(tmp.Name(), 0644); err != nil {
+		return fmt.Errorf("chmod %s: %w", name, err)
+	}
+	return os.Rename(tmp.Name(), dst)
+}
@@ -0,0 +1,61 @@
+package dkg
+
+import (
+	"client/pkg/artifacts"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	log "github.com/sirupsen/logrus"
+)
+
+// prepareArtifacts makes sure the artifacts in dir are intact and belong to the verifier contracts,
+// since a stale proving key silently produces proofs the verifiers reject.
+// Missing or corrupted artifacts are fetched from the mirror if one is configured.
+// Artifacts without a manifest are only accepted with skipChecks.
+func prepareArtifacts(ctx context.Context, client bind.ContractCaller, contract *ZKDKGContract, params *Params, dir artifacts.Dir, mirror string, skipChecks bool) error {
+	m, err := dir.Verify()
+	if err != nil && mirror != "" {
+		log.Infof("Fetching artifacts from %s: %v", mirror, err)
+		m, err = dir.Fetch(ctx, artifacts.NewSource(mirror), int(params.NoParticipants))
+		if err != nil {
+			return fmt.Errorf("fetch: %w", err)
+		}
+	}
+	if errors.Is(err, artifacts.ErrNoManifest) && skipChecks {
+		log.Warnf("No artifact manifest in %s, skipping integrity checks", dir)
+		return nil
+	} else if errors.Is(err, artifacts.ErrNoManifest) {
+		return fmt.Errorf("verify: %w in %s, record one with zkdkg artifacts record or set SkipArtifactChecks", err, dir)
+	} else if err != nil {
+		return fmt.Errorf("verify: %w", err)
+	}
+
+	if m.Participants != int(params.NoParticipants) {
+		return fmt.Errorf("artifacts are built for %d participants, but the contract has %d", m.Participants, params.NoParticipants)
+	}
+
+	opts := &bind.CallOpts{Context: ctx}
+	verifiers := map[ProofType]func(*bind.CallOpts) (common.Address, error){
+		EvalPolyProof: contract.ShareVerifier,
+		KeyDerivProof: contract.KeyVerifier,
+	}
+	for proofType, verifier := range verifiers {
+		address, err := verifier(opts)
+		if err != nil {
+			return fmt.Errorf("%s verifier address: %w", proofType, err)
+		}
+		code, err := client.CodeAt(ctx, address, nil)
+		if err != nil {
+			return fmt.Errorf("%s verifier code: %w", proofType, err)
+		}
+		if err := dir.CheckVerifier(string(proofType), code); err != nil {
+			return err
+		}
+	}
+
+	log.Infof("Artifacts in %s are intact and match the verifier contracts", dir)
+	return nil
+}
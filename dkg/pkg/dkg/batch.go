@@ -0,0 +1,125 @@
+package dkg
+
+import (
+	"client/internal/pkg/group/curve25519"
+	"sort"
+
+	"go.dedis.ch/kyber/v3"
+	"go.dedis.ch/kyber/v3/share"
+	"go.dedis.ch/kyber/v3/suites"
+	"go.dedis.ch/kyber/v3/util/random"
+)
+
+// Bit length of the random weights used for combining the share checks.
+// A batch containing an invalid share passes with a probability of at most 2^-weightBits.
+const weightBits = 128
+
+type batchEntry struct {
+	dealer  uint16
+	share   kyber.Scalar
+	commits []kyber.Point
+}
+
+// BatchVerifier checks the decrypted shares of several dealers for a single participant at once.
+//
+// Instead of checking share_j * G == sum_k index^k * C_jk for each dealer j on its own,
+// the checks are combined with random weights r_j into the single equation
+//
+//	(sum_j r_j * share_j) * G == sum_j r_j * (sum_k index^k * C_jk)
+//
+// which is evaluated through one multi-scalar multiplication.
+// If the combined check fails, each share is checked individually to find the invalid ones.
+type BatchVerifier struct {
+	suite   suites.Suite
+	index   uint16
+	entries []batchEntry
+}
+
+// NewBatchVerifier creates a verifier for shares of the participant with the given one-based index.
+func NewBatchVerifier(suite suites.Suite, index uint16) *BatchVerifier {
+	return &BatchVerifier{
+		suite: suite,
+		index: index,
+	}
+}
+
+// Add queues the share received from the given dealer together with the dealer's commitments.
+func (b *BatchVerifier) Add(dealer uint16, s kyber.Scalar, commits []kyber.Point) {
+	b.entries = append(b.entries, batchEntry{
+		dealer:  dealer,
+		share:   s,
+		commits: append([]kyber.Point(nil), commits...),
+	})
+}
+
+// Len returns the number of queued shares.
+func (b *BatchVerifier) Len() int {
+	return len(b.entries)
+}
+
+// Verify checks all queued shares and returns the indices of the dealers whose shares are invalid, in ascending order.
+// The queue is emptied afterwards.
+func (b *BatchVerifier) Verify() []uint16 {
+	defer func() { b.entries = nil }()
+
+	if len(b.entries) == 0 || b.verifyBatch() {
+		return nil
+	}
+
+	invalid := make([]uint16, 0)
+	for _, e := range b.entries {
+		if !b.verifySingle(e) {
+			invalid = append(invalid, e.dealer)
+		}
+	}
+
+	sort.Slice(invalid, func(i, j int) bool { return invalid[i] < invalid[j] })
+	return invalid
+}
+
+func (b *BatchVerifier) verifyBatch() bool {
+	x := b.suite.Scalar().SetInt64(int64(b.index))
+	buf := make([]byte, weightBits/8)
+
+	sum := b.suite.Scalar().Zero()
+	scalars := make([]kyber.Scalar, 0, len(b.entries)+1)
+	points := make([]kyber.Point, 0, len(b.entries)+1)
+
+	for _, e := range b.entries {
+		random.Bytes(buf, b.suite.RandomStream())
+		r := b.suite.Scalar().SetBytes(buf)
+
+		// Shares are decrypted in the base field of the curve, bring them into the scalar field
+		sb, err := e.share.MarshalBinary()
+		if err != nil {
+			return false
+		}
+		sum.Add(sum, b.suite.Scalar().Mul(r, b.suite.Scalar().SetBytes(sb)))
+
+		// Evaluating the commitment polynomial is cheap because the index is small
+		eval := b.suite.Point().Null()
+		for k := len(e.commits) - 1; k >= 0; k-- {
+			curve25519.MulPublic(eval, x, eval)
+			eval.Add(eval, e.commits[k])
+		}
+
+		scalars = append(scalars, r)
+		points = append(points, eval)
+	}
+
+	scalars = append(scalars, sum.Neg(sum))
+	points = append(points, b.suite.Point().Base())
+
+	res, err := curve25519.MultiScalarMul(scalars, points)
+	if err != nil {
+		return false
+	}
+	return res.Equal(b.suite.Point().Null())
+}
+
+func (b *BatchVerifier) verifySingle(e batchEntry) bool {
+	return share.NewPubPoly(b.suite, nil, e.commits).Check(&share.PriShare{
+		I: int(b.index) - 1,
+		V: e.share,
+	})
+}
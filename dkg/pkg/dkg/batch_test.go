@@ -0,0 +1,52 @@
+package dkg
+
+import (
+	"client/internal/pkg/group/curve25519"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.dedis.ch/kyber/v3/group/mod"
+	"go.dedis.ch/kyber/v3/share"
+)
+
+func TestBatchVerifier(t *testing.T) {
+	param := curve25519.ParamBabyJubJub()
+	suite := curve25519.NewBlakeSHA256BabyJubJub(false)
+
+	const dealers, threshold, index = 8, 5, 3
+
+	for _, corrupted := range [][]uint16{nil, {2}, {1, 5, 8}} {
+		verifier := NewBatchVerifier(suite, index)
+		bad := make(map[uint16]bool)
+		for _, dealer := range corrupted {
+			bad[dealer] = true
+		}
+
+		for dealer := uint16(1); dealer <= dealers; dealer++ {
+			priPoly := share.NewPriPoly(suite, threshold, nil, suite.RandomStream())
+			_, commits := priPoly.Commit(nil).Info()
+
+			s, err := priPoly.Eval(index - 1).V.MarshalBinary()
+			require.NoError(t, err)
+
+			// Decrypted shares live in the base field of the curve
+			v := new(big.Int).SetBytes(s)
+			if bad[dealer] {
+				v.Add(v, big.NewInt(1))
+			}
+			fi := mod.NewInt(v, &param.P)
+
+			require.Equal(t, !bad[dealer], share.NewPubPoly(suite, nil, commits).Check(&share.PriShare{I: index - 1, V: fi}))
+			verifier.Add(dealer, fi, commits)
+		}
+
+		invalid := verifier.Verify()
+		if corrupted == nil {
+			require.Empty(t, invalid)
+		} else {
+			require.Equal(t, corrupted, invalid)
+		}
+		require.Zero(t, verifier.Len())
+	}
+}
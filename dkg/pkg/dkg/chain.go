@@ -0,0 +1,427 @@
+package dkg
+
+import (
+	"client/pkg/zk"
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// chain is the view of the contract a DistKeyGenerator acts on, as seen by a single participant.
+// Transactions block until they are mined and fail if they revert.
+type chain interface {
+	// Subscribe delivers the events of the contract, e.g. *ZKDKGContractBroadcastSharesLog,
+	// and the *types.Header of new blocks to the channel.
+	// It returns once the subscription is established, so no event emitted afterwards is missed.
+	Subscribe(ctx context.Context, events chan<- interface{}) (event.Subscription, error)
+	LatestHeader(ctx context.Context) (*types.Header, error)
+
+	Register(ctx context.Context, pub, possessionProof [2]*big.Int, compressed bool, stake *big.Int) (uint16, error)
+	PublicKeys(ctx context.Context) ([][2]*big.Int, error)
+	// Registrations returns the Registration events of all participants so far, which carry their proofs of possession.
+	Registrations(ctx context.Context) ([]*ZKDKGContractRegistration, error)
+	BroadcastShares(ctx context.Context, commitments, shares []*big.Int) error
+	DisputeShare(ctx context.Context, disputeeIndex uint16, shares []*big.Int) error
+	// SimulateDisputeShare executes disputeShare on the latest state without sending a transaction, failing like it would.
+	SimulateDisputeShare(ctx context.Context, disputeeIndex uint16, shares []*big.Int) error
+	DefendShare(ctx context.Context, proof ShareVerifierProof) error
+	SubmitPublicKey(ctx context.Context, pub [2]*big.Int, proof KeyVerifierProof) error // ErrAborted if the submission aborted the protocol
+	// Settled reports whether the contract paid out the stakes, which it does once the protocol completed or aborted.
+	Settled(ctx context.Context) (bool, error)
+	// Withdraw withdraws the stake and reward credited to the participant, returning zero without a transaction if there are none.
+	Withdraw(ctx context.Context) (*big.Int, error)
+
+	// BroadcastInputs returns the arguments of the broadcastShares transaction that emitted a BroadcastSharesLog.
+	BroadcastInputs(ctx context.Context, txHash common.Hash) (commitments, shares []*big.Int, err error)
+	// SubmittedPublicKey returns the public key of the submitPublicKey transaction that emitted a PublicKeySubmission.
+	SubmittedPublicKey(ctx context.Context, txHash common.Hash) ([2]*big.Int, error)
+
+	CommitmentHash(ctx context.Context, index uint16) ([32]byte, error)
+	// ShareHash returns the hash of the shares the participant broadcast, see sharesHash.
+	ShareHash(ctx context.Context, index uint16) ([32]byte, error)
+	// Transactions returns the transactions sent so far.
+	Transactions() []SentTransaction
+	PhaseEnd(ctx context.Context) (uint64, error)
+	ExpiredDisputes(ctx context.Context) ([]uint16, error)
+}
+
+// prover generates the proofs of the programs, see Prover.
+type prover interface {
+	ComputeWitness(ctx context.Context, proofType ProofType, witness zk.Witness) error
+	GenerateProof(ctx context.Context, proofType ProofType) (*Proof, error)
+	Close()
+}
+
+var abortionTopic = crypto.Keccak256Hash([]byte("Abortion()"))
+
+// sharesHash hashes the encrypted shares of a broadcast like the contract, i.e. keccak256(abi.encodePacked(shares)).
+func sharesHash(shares []*big.Int) [32]byte {
+	packed := make([]byte, 0, 32*len(shares))
+	for _, share := range shares {
+		packed = append(packed, common.LeftPadBytes(share.Bytes(), 32)...)
+	}
+	return crypto.Keccak256Hash(packed)
+}
+
+// contractChain implements chain with a deployed contract.
+type contractChain struct {
+	client      *Endpoints
+	chainID     *big.Int
+	contract    *ZKDKGContract
+	bound       *bind.BoundContract
+	contractAbi abi.ABI
+	address     common.Address
+	fromBlock   uint64 // Block the contract was deployed in, see Config.DeploymentBlock
+	key         *ecdsa.PrivateKey
+	from        common.Address
+	policy      RetryPolicy // Retries the calls and sends of transact that fail with a TransientError
+	sent        []SentTransaction
+}
+
+func (c *contractChain) Subscribe(ctx context.Context, events chan<- interface{}) (event.Subscription, error) {
+	query := ethereum.FilterQuery{Addresses: []common.Address{c.address}}
+	return subscribeLogs(ctx, c.client, query, c.parseLog, events)
+}
+
+// parseLog turns a log of the contract into its event, or nil for events the generator doesn't handle.
+func (c *contractChain) parseLog(l types.Log) (interface{}, error) {
+	if len(l.Topics) == 0 {
+		return nil, nil
+	}
+	e, err := c.contractAbi.EventByID(l.Topics[0])
+	if err != nil {
+		return nil, err
+	}
+
+	switch e.Name {
+	case "RegistrationEndLog":
+		return c.contract.ParseRegistrationEndLog(l)
+	case "BroadcastSharesLog":
+		return c.contract.ParseBroadcastSharesLog(l)
+	case "DistributionEndLog":
+		return c.contract.ParseDistributionEndLog(l)
+	case "DisputeShare":
+		return c.contract.ParseDisputeShare(l)
+	case "Exclusion":
+		return c.contract.ParseExclusion(l)
+	case "Slashing":
+		return c.contract.ParseSlashing(l)
+	case "Abortion":
+		return c.contract.ParseAbortion(l)
+	case "PublicKeySubmission":
+		return c.contract.ParsePublicKeySubmission(l)
+	}
+	return nil, nil
+}
+
+func (c *contractChain) LatestHeader(ctx context.Context) (*types.Header, error) {
+	return c.client.HeaderByNumber(ctx, nil)
+}
+
+func (c *contractChain) transactOpts(ctx context.Context) (*bind.TransactOpts, error) {
+	opts, err := bind.NewKeyedTransactorWithChainID(c.key, c.chainID)
+	if err != nil {
+		return nil, fmt.Errorf("keyed transactor with chainID: %w", err)
+	}
+	opts.Context = ctx
+	opts.GasPrice = big.NewInt(1000000000)
+	return opts, nil
+}
+
+// transact sends a transaction calling the method of the contract and waits until it is mined.
+// Reverts, whether during the gas estimation or once mined, are returned as RevertError.
+// The transaction is signed once with a fixed nonce, so that retrying its send can't create a duplicate.
+func (c *contractChain) transact(ctx context.Context, value *big.Int, method string, args ...interface{}) (*types.Receipt, error) {
+	opts, err := c.transactOpts(ctx)
+	if err != nil {
+		return nil, err
+	}
+	opts.Value = value
+	opts.NoSend = true
+
+	data, err := c.contractAbi.Pack(method, args...)
+	if err != nil {
+		return nil, fmt.Errorf("pack args: %w", err)
+	}
+	estimate, err := retryView(ctx, c, method, func() (uint64, error) {
+		return c.client.EstimateGas(ctx, ethereum.CallMsg{
+			From:  c.from,
+			To:    &c.address,
+			Value: value,
+			Data:  data,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	opts.GasLimit = estimate + 30000
+
+	nonce, err := retryView(ctx, c, "pending nonce", func() (uint64, error) { return c.client.PendingNonceAt(ctx, c.from) })
+	if err != nil {
+		return nil, err
+	}
+	opts.Nonce = new(big.Int).SetUint64(nonce)
+
+	tx, err := c.bound.RawTransact(opts, data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: sign: %w", method, err)
+	}
+	if err := sendSigned(ctx, c.policy, c.client, method, tx); err != nil {
+		return nil, err
+	}
+
+	// WaitMined polls the receipt of the hash, riding out endpoints failing in the meantime
+	receipt, err := bind.WaitMined(ctx, c.client, tx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: wait mined: %w", method, err)
+	}
+	c.sent = append(c.sent, SentTransaction{
+		Method:   method,
+		Hash:     tx.Hash(),
+		GasUsed:  receipt.GasUsed,
+		Reverted: receipt.Status == types.ReceiptStatusFailed,
+	})
+	if receipt.Status == types.ReceiptStatusFailed {
+		return nil, c.replayRevert(ctx, method, tx, receipt)
+	}
+	return receipt, nil
+}
+
+// txSender is the part of Endpoints sendSigned needs.
+type txSender interface {
+	SendTransaction(ctx context.Context, tx *types.Transaction) error
+	TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error)
+}
+
+// sendSigned sends a signed transaction, repeating the very same transaction as long as the endpoints can't be reached.
+// Errors the endpoints answer with, e.g. insufficient funds, prove the transaction wasn't accepted and are final.
+// A repeated send that is refused because the transaction is known or its nonce is used succeeded if the receipt
+// shows that the earlier send got through.
+func sendSigned(ctx context.Context, p RetryPolicy, client txSender, method string, tx *types.Transaction) error {
+	resent := false
+	_, err := retry(ctx, p, IsTransient, func() (struct{}, error) {
+		err := client.SendTransaction(ctx, tx)
+		if err != nil && resent {
+			msg := err.Error()
+			if strings.Contains(msg, "already known") {
+				return struct{}{}, nil
+			}
+			if strings.Contains(msg, "nonce too low") {
+				if _, receiptErr := client.TransactionReceipt(ctx, tx.Hash()); receiptErr == nil {
+					return struct{}{}, nil
+				}
+			}
+		}
+		resent = true
+		return struct{}{}, classifyError(ctx, method, err)
+	})
+	return err
+}
+
+// replayRevert recovers the reason of a mined transaction that reverted by repeating it as a call on the state before its block.
+func (c *contractChain) replayRevert(ctx context.Context, method string, tx *types.Transaction, receipt *types.Receipt) error {
+	msg := ethereum.CallMsg{
+		From:     c.from,
+		To:       tx.To(),
+		Gas:      tx.Gas(),
+		GasPrice: tx.GasPrice(),
+		Value:    tx.Value(),
+		Data:     tx.Data(),
+	}
+	_, err := c.client.CallContract(ctx, msg, new(big.Int).Sub(receipt.BlockNumber, big.NewInt(1)))
+	if reason, ok := revertReason(err); ok {
+		return &RevertError{Method: method, Reason: reason}
+	}
+	return &RevertError{Method: method}
+}
+
+func (c *contractChain) Register(ctx context.Context, pub, possessionProof [2]*big.Int, compressed bool, stake *big.Int) (uint16, error) {
+	// The compressed key is decompressed by the contract, saving calldata at the cost of computation
+	method, arg := "register", interface{}(pub)
+	if compressed {
+		method, arg = "registerCompressed", zk.Compress(pub[0], pub[1])
+	}
+
+	if _, err := c.transact(ctx, stake, method, arg, possessionProof); err != nil {
+		return 0, err
+	}
+
+	return retryView(ctx, c, "participants", func() (uint16, error) {
+		return c.contract.Participants(&bind.CallOpts{Context: ctx}, c.from)
+	})
+}
+
+func (c *contractChain) PublicKeys(ctx context.Context) ([][2]*big.Int, error) {
+	keys, err := c.contract.PublicKeys(&bind.CallOpts{Context: ctx})
+	return keys, classifyError(ctx, "publicKeys", err)
+}
+
+func (c *contractChain) Registrations(ctx context.Context) ([]*ZKDKGContractRegistration, error) {
+	it, err := c.contract.FilterRegistration(&bind.FilterOpts{Start: c.fromBlock, Context: ctx})
+	if err != nil {
+		return nil, classifyError(ctx, "filter registrations", err)
+	}
+	defer it.Close()
+
+	var registrations []*ZKDKGContractRegistration
+	for it.Next() {
+		registrations = append(registrations, it.Event)
+	}
+	return registrations, classifyError(ctx, "filter registrations", it.Error())
+}
+
+func (c *contractChain) BroadcastShares(ctx context.Context, commitments, shares []*big.Int) error {
+	_, err := c.transact(ctx, nil, "broadcastShares", commitments, shares)
+	return err
+}
+
+func (c *contractChain) DisputeShare(ctx context.Context, disputeeIndex uint16, shares []*big.Int) error {
+	_, err := c.transact(ctx, nil, "disputeShare", disputeeIndex, shares)
+	return err
+}
+
+func (c *contractChain) SimulateDisputeShare(ctx context.Context, disputeeIndex uint16, shares []*big.Int) error {
+	return c.simulate(ctx, "disputeShare", disputeeIndex, shares)
+}
+
+// simulate executes a call of the method on the latest state without sending a transaction.
+// Reverts are returned as RevertError.
+func (c *contractChain) simulate(ctx context.Context, method string, args ...interface{}) error {
+	data, err := c.contractAbi.Pack(method, args...)
+	if err != nil {
+		return fmt.Errorf("pack args: %w", err)
+	}
+	_, err = c.client.CallContract(ctx, ethereum.CallMsg{From: c.from, To: &c.address, Data: data}, nil)
+	return classifyError(ctx, method, err)
+}
+
+func (c *contractChain) DefendShare(ctx context.Context, proof ShareVerifierProof) error {
+	_, err := c.transact(ctx, nil, "defendShare", proof)
+	return err
+}
+
+func (c *contractChain) SubmitPublicKey(ctx context.Context, pub [2]*big.Int, proof KeyVerifierProof) error {
+	receipt, err := c.transact(ctx, nil, "submitPublicKey", pub, proof)
+	if err != nil {
+		return err
+	}
+
+	for _, eventLog := range receipt.Logs {
+		if eventLog.Topics[0] == abortionTopic {
+			return ErrAborted
+		}
+	}
+	return nil
+}
+
+func (c *contractChain) Settled(ctx context.Context) (bool, error) {
+	settled, err := c.contract.IsSettled(&bind.CallOpts{Context: ctx})
+	return settled, classifyError(ctx, "isSettled", err)
+}
+
+func (c *contractChain) Withdraw(ctx context.Context) (*big.Int, error) {
+	balance, err := retryView(ctx, c, "balances", func() (*big.Int, error) {
+		return c.contract.Balances(&bind.CallOpts{Context: ctx}, c.from)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if balance.Sign() == 0 {
+		return balance, nil
+	}
+
+	if _, err := c.transact(ctx, nil, "withdraw"); err != nil {
+		return nil, err
+	}
+	return balance, nil
+}
+
+func (c *contractChain) txInputs(ctx context.Context, txHash common.Hash) ([]interface{}, error) {
+	tx, err := c.client.TransactionByHash(ctx, txHash)
+	if err != nil {
+		return nil, classifyError(ctx, "transaction by hash", err)
+	}
+
+	_, inputs, err := DecodeCall(c.contractAbi, tx.Data())
+	if err != nil {
+		return nil, fmt.Errorf("decode call: %w", err)
+	}
+
+	return inputs, nil
+}
+
+func (c *contractChain) BroadcastInputs(ctx context.Context, txHash common.Hash) ([]*big.Int, []*big.Int, error) {
+	inputs, err := c.txInputs(ctx, txHash)
+	if err != nil {
+		return nil, nil, err
+	}
+	return inputs[0].([]*big.Int), inputs[1].([]*big.Int), nil
+}
+
+func (c *contractChain) SubmittedPublicKey(ctx context.Context, txHash common.Hash) ([2]*big.Int, error) {
+	inputs, err := c.txInputs(ctx, txHash)
+	if err != nil {
+		return [2]*big.Int{}, err
+	}
+	return inputs[0].([2]*big.Int), nil
+}
+
+// participantAddress returns the address of the participant with the index.
+func (c *contractChain) participantAddress(opts *bind.CallOpts, index uint16) (common.Address, error) {
+	address, err := c.contract.Addresses(opts, big.NewInt(int64(index)-1))
+	return address, classifyError(opts.Context, "addresses", err)
+}
+
+func (c *contractChain) CommitmentHash(ctx context.Context, index uint16) ([32]byte, error) {
+	opts := &bind.CallOpts{Context: ctx}
+
+	address, err := c.participantAddress(opts, index)
+	if err != nil {
+		return [32]byte{}, err
+	}
+
+	hash, err := c.contract.CommitmentHashes(opts, address)
+	if err != nil {
+		return [32]byte{}, classifyError(ctx, "commitmentHashes", err)
+	}
+	return hash, nil
+}
+
+func (c *contractChain) ShareHash(ctx context.Context, index uint16) ([32]byte, error) {
+	opts := &bind.CallOpts{Context: ctx}
+
+	address, err := c.participantAddress(opts, index)
+	if err != nil {
+		return [32]byte{}, err
+	}
+
+	hash, err := c.contract.ShareHashes(opts, address)
+	if err != nil {
+		return [32]byte{}, classifyError(ctx, "shareHashes", err)
+	}
+	return hash, nil
+}
+
+func (c *contractChain) Transactions() []SentTransaction {
+	return c.sent
+}
+
+func (c *contractChain) PhaseEnd(ctx context.Context) (uint64, error) {
+	phaseEnd, err := c.contract.PhaseEnd(&bind.CallOpts{Context: ctx})
+	return phaseEnd, classifyError(ctx, "phaseEnd", err)
+}
+
+func (c *contractChain) ExpiredDisputes(ctx context.Context) ([]uint16, error) {
+	indices, err := c.contract.ExpiredDisputes(&bind.CallOpts{Context: ctx})
+	return indices, classifyError(ctx, "expiredDisputes", err)
+}
@@ -0,0 +1,63 @@
+package dkg
+
+import (
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// idleGrace is how long to wait for a block after the extrapolated chain time passed a deadline.
+const idleGrace = 2 * time.Second
+
+// ChainClock tells when a deadline of the contract has passed by following the timestamps of new blocks.
+//
+// The contract compares its deadlines against block.timestamp, which drifts from the local clock
+// and advances irregularly, so a deadline has only passed for certain once a mined block is later than it.
+// Development chains only mine blocks on demand though, so while no block arrives the chain time
+// is extrapolated from the latest block with the elapsed local time, which is unaffected by clock offsets.
+//
+// A ChainClock isn't safe for concurrent use, it is owned by the event loop of a DistKeyGenerator.
+type ChainClock struct {
+	head     uint64    // Timestamp of the latest block
+	received time.Time // Local time the latest block was observed
+	now      func() time.Time
+}
+
+func NewChainClock() *ChainClock {
+	return &ChainClock{now: time.Now}
+}
+
+// Observe advances the clock to a new block.
+func (c *ChainClock) Observe(header *types.Header) {
+	if header.Time < c.head {
+		// Reorganizations may deliver earlier blocks, the contract has seen the later ones already
+		return
+	}
+	c.head = header.Time
+	c.received = c.now()
+}
+
+// Head returns the timestamp of the latest block.
+func (c *ChainClock) Head() uint64 {
+	return c.head
+}
+
+// Passed reports whether a block later than the deadline was mined,
+// so a transaction requiring block.timestamp > deadline will succeed.
+func (c *ChainClock) Passed(deadline uint64) bool {
+	return c.head > deadline
+}
+
+// UntilIdle returns how long to wait for a block passing the deadline before assuming an idle chain passed it.
+// It returns false for deadlines that are never reached, like the phase ends of the evaluation mode.
+func (c *ChainClock) UntilIdle(deadline uint64) (time.Duration, bool) {
+	if deadline >= pointInFuture {
+		return 0, false
+	}
+	if c.Passed(deadline) {
+		return 0, true
+	}
+	// The first second later than the deadline
+	remaining := time.Duration(deadline-c.head+1) * time.Second
+	return c.received.Add(remaining + idleGrace).Sub(c.now()), true
+}
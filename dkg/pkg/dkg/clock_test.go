@@ -0,0 +1,49 @@
+package dkg
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChainClock(t *testing.T) {
+	now := time.Unix(5000, 0)
+	clock := &ChainClock{now: func() time.Time { return now }}
+
+	// The chain runs an hour behind the local clock
+	clock.Observe(&types.Header{Time: 5000 - 3600})
+	require.False(t, clock.Passed(5000-3600))
+
+	wait, ok := clock.UntilIdle(5000 - 3600 + 10)
+	require.True(t, ok)
+	require.Equal(t, 11*time.Second+idleGrace, wait)
+
+	// Blocks arrive irregularly, only their timestamps count
+	now = now.Add(time.Minute)
+	clock.Observe(&types.Header{Time: 5000 - 3600 + 5})
+	require.False(t, clock.Passed(5000-3600+10))
+	wait, _ = clock.UntilIdle(5000 - 3600 + 10)
+	require.Equal(t, 6*time.Second+idleGrace, wait)
+
+	clock.Observe(&types.Header{Time: 5000 - 3600 + 11})
+	require.True(t, clock.Passed(5000-3600+10))
+	wait, ok = clock.UntilIdle(5000 - 3600 + 10)
+	require.True(t, ok)
+	require.Zero(t, wait)
+
+	// Earlier blocks of a reorganization don't turn the clock back
+	clock.Observe(&types.Header{Time: 5000 - 3600})
+	require.Equal(t, uint64(5000-3600+11), clock.Head())
+}
+
+func TestChainClockNeverReached(t *testing.T) {
+	clock := NewChainClock()
+	clock.Observe(&types.Header{Time: uint64(time.Now().Unix())})
+
+	for _, deadline := range []uint64{pointInFuture, ^uint64(0)} {
+		_, ok := clock.UntilIdle(deadline)
+		require.False(t, ok, "deadline %d", deadline)
+	}
+}
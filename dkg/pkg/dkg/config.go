@@ -1,9 +1,85 @@
 package dkg
 
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
 type Config struct {
-	EthereumNode       string
+	EthereumNode string
+	// Further RPC endpoints of the same chain to fail over to, see Endpoints
+	EthereumNodes      []string
 	EthereumPrivateKey string
 	DkgPrivateKey      string
 	ContractAddress    string
 	MountSource        string
+
+	// Deployment manifest written by zkdkg deploy, takes precedence over ContractAddress and DeploymentBlock
+	Deployment string
+
+	// Block the contract was deployed in, from which to filter its events
+	DeploymentBlock uint64
+
+	// Directory or http(s) URL to fetch the artifacts from if MountSource lacks them, see the artifacts package
+	ArtifactMirror string
+
+	// Run with artifacts that have no manifest, i.e. without their integrity and verifier key checks
+	SkipArtifactChecks bool
+
+	// Register the public key in compressed form, see registerCompressed of the contract
+	CompressedRegistration bool
+
+	// Retry policies for RPC calls and transactions that fail to reach the endpoints, and for the prover
+	Retry       RetryPolicy
+	ProverRetry RetryPolicy
+
+	// Delay between the turns of the participants to submit the public key, see DefaultSubmissionBackoff
+	SubmissionBackoff time.Duration
+}
+
+// Endpoints returns the RPC endpoints in the order to try them, EthereumNode first.
+func (c *Config) Endpoints() []string {
+	var urls []string
+	for _, url := range append([]string{c.EthereumNode}, c.EthereumNodes...) {
+		if url != "" {
+			urls = append(urls, url)
+		}
+	}
+	return urls
+}
+
+// LoadConfig reads the JSON config file with the given name and the deployment manifest it refers to.
+func LoadConfig(name string) (*Config, error) {
+	config, err := ReadConfig(name)
+	if err != nil {
+		return nil, err
+	}
+
+	if config.Deployment != "" {
+		deployment, err := ReadDeployment(config.Deployment)
+		if err != nil {
+			return nil, err
+		}
+		config.ContractAddress = deployment.ZKDKG.Hex()
+		config.DeploymentBlock = deployment.Block
+	}
+	return config, nil
+}
+
+// ReadConfig reads the JSON config file with the given name without resolving its deployment manifest.
+func ReadConfig(name string) (*Config, error) {
+	v := viper.New()
+	v.SetConfigFile(name)
+	v.SetConfigType("json")
+	if err := v.ReadInConfig(); err != nil {
+		return nil, fmt.Errorf("read config: %w", err)
+	}
+
+	var config Config
+	if err := v.Unmarshal(&config); err != nil {
+		return nil, fmt.Errorf("unmarshal config into struct: %w", err)
+	}
+	return &config, nil
 }
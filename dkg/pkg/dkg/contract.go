@@ -0,0 +1,2365 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package dkg
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = errors.New
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+)
+
+// KeyVerifierProof is an auto generated low-level Go binding around an user-defined struct.
+type KeyVerifierProof struct {
+	A PairingG1Point
+	B PairingG2Point
+	C PairingG1Point
+}
+
+// PairingG1Point is an auto generated low-level Go binding around an user-defined struct.
+type PairingG1Point struct {
+	X *big.Int
+	Y *big.Int
+}
+
+// PairingG2Point is an auto generated low-level Go binding around an user-defined struct.
+type PairingG2Point struct {
+	X [2]*big.Int
+	Y [2]*big.Int
+}
+
+// ShareVerifierProof is an auto generated low-level Go binding around an user-defined struct.
+type ShareVerifierProof struct {
+	A PairingG1Point
+	B PairingG2Point
+	C PairingG1Point
+}
+
+// ZKDKGContractMetaData contains all meta data concerning the ZKDKGContract contract.
+var ZKDKGContractMetaData = &bind.MetaData{
+	ABI: "[{\"type\":\"constructor\",\"stateMutability\":\"nonpayable\",\"inputs\":[{\"name\":\"_shareVerifier\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"_keyVerifier\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"_noParticipants\",\"type\":\"uint16\",\"internalType\":\"uint16\"},{\"name\":\"_userThreshold\",\"type\":\"uint16\",\"internalType\":\"uint16\"},{\"name\":\"_periodLength\",\"type\":\"uint16\",\"internalType\":\"uint16\"},{\"name\":\"_stake\",\"type\":\"uint256\",\"internalType\":\"uint256\"}]},{\"type\":\"event\",\"name\":\"Abortion\",\"anonymous\":false,\"inputs\":[]},{\"type\":\"event\",\"name\":\"BroadcastSharesLog\",\"anonymous\":false,\"inputs\":[{\"name\":\"sender\",\"type\":\"address\",\"internalType\":\"address\",\"indexed\":false},{\"name\":\"broadcasterIndex\",\"type\":\"uint16\",\"internalType\":\"uint16\",\"indexed\":false}]},{\"type\":\"event\",\"name\":\"DisputeShare\",\"anonymous\":false,\"inputs\":[{\"name\":\"disputerIndex\",\"type\":\"uint16\",\"internalType\":\"uint16\",\"indexed\":false},{\"name\":\"disputeeIndex\",\"type\":\"uint16\",\"internalType\":\"uint16\",\"indexed\":false}]},{\"type\":\"event\",\"name\":\"DistributionEndLog\",\"anonymous\":false,\"inputs\":[]},{\"type\":\"event\",\"name\":\"Exclusion\",\"anonymous\":false,\"inputs\":[{\"name\":\"index\",\"type\":\"uint16\",\"internalType\":\"uint16\",\"indexed\":false}]},{\"type\":\"event\",\"name\":\"PublicKeySubmission\",\"anonymous\":false,\"inputs\":[]},{\"type\":\"event\",\"name\":\"RegistrationEndLog\",\"anonymous\":false,\"inputs\":[]},{\"type\":\"event\",\"name\":\"Reset\",\"anonymous\":false,\"inputs\":[]},{\"type\":\"function\",\"name\":\"STAKE\",\"inputs\":[],\"outputs\":[{\"name\":\"\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"addresses\",\"inputs\":[{\"name\":\"\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"outputs\":[{\"name\":\"\",\"type\":\"address\",\"internalType\":\"address\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"broadcastShares\",\"inputs\":[{\"name\":\"commitments\",\"type\":\"uint256[]\",\"internalType\":\"uint256[]\"},{\"name\":\"shares\",\"type\":\"uint256[]\",\"internalType\":\"uint256[]\"}],\"outputs\":[],\"stateMutability\":\"nonpayable\"},{\"type\":\"function\",\"name\":\"commitmentHashes\",\"inputs\":[{\"name\":\"\",\"type\":\"address\",\"internalType\":\"address\"}],\"outputs\":[{\"name\":\"\",\"type\":\"bytes32\",\"internalType\":\"bytes32\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"defendShare\",\"inputs\":[{\"name\":\"proof\",\"type\":\"tuple\",\"internalType\":\"structShareVerifier.Proof\",\"components\":[{\"name\":\"a\",\"type\":\"tuple\",\"internalType\":\"structPairing.G1Point\",\"components\":[{\"name\":\"X\",\"type\":\"uint256\",\"internalType\":\"uint256\"},{\"name\":\"Y\",\"type\":\"uint256\",\"internalType\":\"uint256\"}]},{\"name\":\"b\",\"type\":\"tuple\",\"internalType\":\"structPairing.G2Point\",\"components\":[{\"name\":\"X\",\"type\":\"uint256[2]\",\"internalType\":\"uint256[2]\"},{\"name\":\"Y\",\"type\":\"uint256[2]\",\"internalType\":\"uint256[2]\"}]},{\"name\":\"c\",\"type\":\"tuple\",\"internalType\":\"structPairing.G1Point\",\"components\":[{\"name\":\"X\",\"type\":\"uint256\",\"internalType\":\"uint256\"},{\"name\":\"Y\",\"type\":\"uint256\",\"internalType\":\"uint256\"}]}]}],\"outputs\":[],\"stateMutability\":\"nonpayable\"},{\"type\":\"function\",\"name\":\"disputeShare\",\"inputs\":[{\"name\":\"disputeeIndex\",\"type\":\"uint16\",\"internalType\":\"uint16\"},{\"name\":\"shares\",\"type\":\"uint256[]\",\"internalType\":\"uint256[]\"}],\"outputs\":[],\"stateMutability\":\"nonpayable\"},{\"type\":\"function\",\"name\":\"expiredDisputes\",\"inputs\":[],\"outputs\":[{\"name\":\"\",\"type\":\"uint16[]\",\"internalType\":\"uint16[]\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"firstCoefficients\",\"inputs\":[{\"name\":\"\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"outputs\":[{\"name\":\"\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"isRegistered\",\"inputs\":[{\"name\":\"_addr\",\"type\":\"address\",\"internalType\":\"address\"}],\"outputs\":[{\"name\":\"\",\"type\":\"bool\",\"internalType\":\"bool\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"minimumThreshold\",\"inputs\":[],\"outputs\":[{\"name\":\"\",\"type\":\"uint16\",\"internalType\":\"uint16\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"noParticipants\",\"inputs\":[],\"outputs\":[{\"name\":\"\",\"type\":\"uint16\",\"internalType\":\"uint16\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"participants\",\"inputs\":[{\"name\":\"\",\"type\":\"address\",\"internalType\":\"address\"}],\"outputs\":[{\"name\":\"index\",\"type\":\"uint16\",\"internalType\":\"uint16\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"periodLength\",\"inputs\":[],\"outputs\":[{\"name\":\"\",\"type\":\"uint16\",\"internalType\":\"uint16\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"phase\",\"inputs\":[],\"outputs\":[{\"name\":\"\",\"type\":\"uint8\",\"internalType\":\"enumZKDKG.Phase\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"phaseEnd\",\"inputs\":[],\"outputs\":[{\"name\":\"\",\"type\":\"uint64\",\"internalType\":\"uint64\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"publicKeys\",\"inputs\":[],\"outputs\":[{\"name\":\"\",\"type\":\"uint256[2][]\",\"internalType\":\"uint256[2][]\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"register\",\"inputs\":[{\"name\":\"publicKey\",\"type\":\"uint256[2]\",\"internalType\":\"uint256[2]\"},{\"name\":\"possessionProof\",\"type\":\"uint256[2]\",\"internalType\":\"uint256[2]\"}],\"outputs\":[],\"stateMutability\":\"payable\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"compressedKey\",\"type\":\"uint256\"},{\"name\":\"possessionProof\",\"type\":\"uint256[2]\",\"internalType\":\"uint256[2]\"}],\"name\":\"registerCompressed\",\"outputs\":[],\"stateMutability\":\"payable\",\"type\":\"function\"},{\"type\":\"function\",\"name\":\"shareHashes\",\"inputs\":[{\"name\":\"\",\"type\":\"address\",\"internalType\":\"address\"}],\"outputs\":[{\"name\":\"\",\"type\":\"bytes32\",\"internalType\":\"bytes32\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"submitPublicKey\",\"inputs\":[{\"name\":\"_publicKey\",\"type\":\"uint256[2]\",\"internalType\":\"uint256[2]\"},{\"name\":\"proof\",\"type\":\"tuple\",\"internalType\":\"structKeyVerifier.Proof\",\"components\":[{\"name\":\"a\",\"type\":\"tuple\",\"internalType\":\"structPairing.G1Point\",\"components\":[{\"name\":\"X\",\"type\":\"uint256\",\"internalType\":\"uint256\"},{\"name\":\"Y\",\"type\":\"uint256\",\"internalType\":\"uint256\"}]},{\"name\":\"b\",\"type\":\"tuple\",\"internalType\":\"structPairing.G2Point\",\"components\":[{\"name\":\"X\",\"type\":\"uint256[2]\",\"internalType\":\"uint256[2]\"},{\"name\":\"Y\",\"type\":\"uint256[2]\",\"internalType\":\"uint256[2]\"}]},{\"name\":\"c\",\"type\":\"tuple\",\"internalType\":\"structPairing.G1Point\",\"components\":[{\"name\":\"X\",\"type\":\"uint256\",\"internalType\":\"uint256\"},{\"name\":\"Y\",\"type\":\"uint256\",\"internalType\":\"uint256\"}]}]}],\"outputs\":[],\"stateMutability\":\"nonpayable\"},{\"type\":\"function\",\"name\":\"userThreshold\",\"inputs\":[],\"outputs\":[{\"name\":\"\",\"type\":\"uint16\",\"internalType\":\"uint16\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"shareVerifier\",\"inputs\":[],\"outputs\":[{\"name\":\"\",\"type\":\"address\",\"internalType\":\"contractShareVerifier\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"keyVerifier\",\"inputs\":[],\"outputs\":[{\"name\":\"\",\"type\":\"address\",\"internalType\":\"contractKeyVerifier\"}],\"stateMutability\":\"view\"},{\"type\":\"event\",\"name\":\"Registration\",\"anonymous\":false,\"inputs\":[{\"name\":\"sender\",\"type\":\"address\",\"internalType\":\"address\",\"indexed\":false},{\"name\":\"index\",\"type\":\"uint16\",\"internalType\":\"uint16\",\"indexed\":false},{\"name\":\"possessionProof\",\"type\":\"uint256[2]\",\"internalType\":\"uint256[2]\",\"indexed\":false}]},{\"type\":\"function\",\"name\":\"stakes\",\"inputs\":[{\"name\":\"\",\"type\":\"address\",\"internalType\":\"address\"}],\"outputs\":[{\"name\":\"\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"balances\",\"inputs\":[{\"name\":\"\",\"type\":\"address\",\"internalType\":\"address\"}],\"outputs\":[{\"name\":\"\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"slashed\",\"inputs\":[],\"outputs\":[{\"name\":\"\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"withdraw\",\"inputs\":[],\"outputs\":[],\"stateMutability\":\"nonpayable\"},{\"type\":\"event\",\"name\":\"Slashing\",\"anonymous\":false,\"inputs\":[{\"name\":\"index\",\"type\":\"uint16\",\"internalType\":\"uint16\",\"indexed\":false},{\"name\":\"amount\",\"type\":\"uint256\",\"internalType\":\"uint256\",\"indexed\":false}]},{\"type\":\"function\",\"name\":\"isSettled\",\"inputs\":[],\"outputs\":[{\"name\":\"\",\"type\":\"bool\",\"internalType\":\"bool\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"settle\",\"inputs\":[],\"outputs\":[],\"stateMutability\":\"nonpayable\"}]",
+}
+
+// ZKDKGContractABI is the input ABI used to generate the binding from.
+// Deprecated: Use ZKDKGContractMetaData.ABI instead.
+var ZKDKGContractABI = ZKDKGContractMetaData.ABI
+
+// ZKDKGContract is an auto generated Go binding around an Ethereum contract.
+type ZKDKGContract struct {
+	ZKDKGContractCaller     // Read-only binding to the contract
+	ZKDKGContractTransactor // Write-only binding to the contract
+	ZKDKGContractFilterer   // Log filterer for contract events
+}
+
+// ZKDKGContractCaller is an auto generated read-only Go binding around an Ethereum contract.
+type ZKDKGContractCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// ZKDKGContractTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type ZKDKGContractTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// ZKDKGContractFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type ZKDKGContractFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// ZKDKGContractSession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type ZKDKGContractSession struct {
+	Contract     *ZKDKGContract    // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts     // Call options to use throughout this session
+	TransactOpts bind.TransactOpts // Transaction auth options to use throughout this session
+}
+
+// ZKDKGContractCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type ZKDKGContractCallerSession struct {
+	Contract *ZKDKGContractCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts        // Call options to use throughout this session
+}
+
+// ZKDKGContractTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type ZKDKGContractTransactorSession struct {
+	Contract     *ZKDKGContractTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts        // Transaction auth options to use throughout this session
+}
+
+// ZKDKGContractRaw is an auto generated low-level Go binding around an Ethereum contract.
+type ZKDKGContractRaw struct {
+	Contract *ZKDKGContract // Generic contract binding to access the raw methods on
+}
+
+// ZKDKGContractCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type ZKDKGContractCallerRaw struct {
+	Contract *ZKDKGContractCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// ZKDKGContractTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type ZKDKGContractTransactorRaw struct {
+	Contract *ZKDKGContractTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewZKDKGContract creates a new instance of ZKDKGContract, bound to a specific deployed contract.
+func NewZKDKGContract(address common.Address, backend bind.ContractBackend) (*ZKDKGContract, error) {
+	contract, err := bindZKDKGContract(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &ZKDKGContract{ZKDKGContractCaller: ZKDKGContractCaller{contract: contract}, ZKDKGContractTransactor: ZKDKGContractTransactor{contract: contract}, ZKDKGContractFilterer: ZKDKGContractFilterer{contract: contract}}, nil
+}
+
+// NewZKDKGContractCaller creates a new read-only instance of ZKDKGContract, bound to a specific deployed contract.
+func NewZKDKGContractCaller(address common.Address, caller bind.ContractCaller) (*ZKDKGContractCaller, error) {
+	contract, err := bindZKDKGContract(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &ZKDKGContractCaller{contract: contract}, nil
+}
+
+// NewZKDKGContractTransactor creates a new write-only instance of ZKDKGContract, bound to a specific deployed contract.
+func NewZKDKGContractTransactor(address common.Address, transactor bind.ContractTransactor) (*ZKDKGContractTransactor, error) {
+	contract, err := bindZKDKGContract(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &ZKDKGContractTransactor{contract: contract}, nil
+}
+
+// NewZKDKGContractFilterer creates a new log filterer instance of ZKDKGContract, bound to a specific deployed contract.
+func NewZKDKGContractFilterer(address common.Address, filterer bind.ContractFilterer) (*ZKDKGContractFilterer, error) {
+	contract, err := bindZKDKGContract(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &ZKDKGContractFilterer{contract: contract}, nil
+}
+
+// bindZKDKGContract binds a generic wrapper to an already deployed contract.
+func bindZKDKGContract(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := abi.JSON(strings.NewReader(ZKDKGContractABI))
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_ZKDKGContract *ZKDKGContractRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _ZKDKGContract.Contract.ZKDKGContractCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_ZKDKGContract *ZKDKGContractRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _ZKDKGContract.Contract.ZKDKGContractTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_ZKDKGContract *ZKDKGContractRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _ZKDKGContract.Contract.ZKDKGContractTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_ZKDKGContract *ZKDKGContractCallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _ZKDKGContract.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_ZKDKGContract *ZKDKGContractTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _ZKDKGContract.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_ZKDKGContract *ZKDKGContractTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _ZKDKGContract.Contract.contract.Transact(opts, method, params...)
+}
+
+// STAKE is a free data retrieval call binding the contract method 0x125fdbbc.
+//
+// Solidity: function STAKE() view returns(uint256)
+func (_ZKDKGContract *ZKDKGContractCaller) STAKE(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _ZKDKGContract.contract.Call(opts, &out, "STAKE")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// STAKE is a free data retrieval call binding the contract method 0x125fdbbc.
+//
+// Solidity: function STAKE() view returns(uint256)
+func (_ZKDKGContract *ZKDKGContractSession) STAKE() (*big.Int, error) {
+	return _ZKDKGContract.Contract.STAKE(&_ZKDKGContract.CallOpts)
+}
+
+// STAKE is a free data retrieval call binding the contract method 0x125fdbbc.
+//
+// Solidity: function STAKE() view returns(uint256)
+func (_ZKDKGContract *ZKDKGContractCallerSession) STAKE() (*big.Int, error) {
+	return _ZKDKGContract.Contract.STAKE(&_ZKDKGContract.CallOpts)
+}
+
+// Addresses is a free data retrieval call binding the contract method 0xedf26d9b.
+//
+// Solidity: function addresses(uint256 ) view returns(address)
+func (_ZKDKGContract *ZKDKGContractCaller) Addresses(opts *bind.CallOpts, arg0 *big.Int) (common.Address, error) {
+	var out []interface{}
+	err := _ZKDKGContract.contract.Call(opts, &out, "addresses", arg0)
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// Addresses is a free data retrieval call binding the contract method 0xedf26d9b.
+//
+// Solidity: function addresses(uint256 ) view returns(address)
+func (_ZKDKGContract *ZKDKGContractSession) Addresses(arg0 *big.Int) (common.Address, error) {
+	return _ZKDKGContract.Contract.Addresses(&_ZKDKGContract.CallOpts, arg0)
+}
+
+// Addresses is a free data retrieval call binding the contract method 0xedf26d9b.
+//
+// Solidity: function addresses(uint256 ) view returns(address)
+func (_ZKDKGContract *ZKDKGContractCallerSession) Addresses(arg0 *big.Int) (common.Address, error) {
+	return _ZKDKGContract.Contract.Addresses(&_ZKDKGContract.CallOpts, arg0)
+}
+
+// Balances is a free data retrieval call binding the contract method 0x27e235e3.
+//
+// Solidity: function balances(address ) view returns(uint256)
+func (_ZKDKGContract *ZKDKGContractCaller) Balances(opts *bind.CallOpts, arg0 common.Address) (*big.Int, error) {
+	var out []interface{}
+	err := _ZKDKGContract.contract.Call(opts, &out, "balances", arg0)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// Balances is a free data retrieval call binding the contract method 0x27e235e3.
+//
+// Solidity: function balances(address ) view returns(uint256)
+func (_ZKDKGContract *ZKDKGContractSession) Balances(arg0 common.Address) (*big.Int, error) {
+	return _ZKDKGContract.Contract.Balances(&_ZKDKGContract.CallOpts, arg0)
+}
+
+// Balances is a free data retrieval call binding the contract method 0x27e235e3.
+//
+// Solidity: function balances(address ) view returns(uint256)
+func (_ZKDKGContract *ZKDKGContractCallerSession) Balances(arg0 common.Address) (*big.Int, error) {
+	return _ZKDKGContract.Contract.Balances(&_ZKDKGContract.CallOpts, arg0)
+}
+
+// CommitmentHashes is a free data retrieval call binding the contract method 0x8a48b163.
+//
+// Solidity: function commitmentHashes(address ) view returns(bytes32)
+func (_ZKDKGContract *ZKDKGContractCaller) CommitmentHashes(opts *bind.CallOpts, arg0 common.Address) ([32]byte, error) {
+	var out []interface{}
+	err := _ZKDKGContract.contract.Call(opts, &out, "commitmentHashes", arg0)
+
+	if err != nil {
+		return *new([32]byte), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([32]byte)).(*[32]byte)
+
+	return out0, err
+
+}
+
+// CommitmentHashes is a free data retrieval call binding the contract method 0x8a48b163.
+//
+// Solidity: function commitmentHashes(address ) view returns(bytes32)
+func (_ZKDKGContract *ZKDKGContractSession) CommitmentHashes(arg0 common.Address) ([32]byte, error) {
+	return _ZKDKGContract.Contract.CommitmentHashes(&_ZKDKGContract.CallOpts, arg0)
+}
+
+// CommitmentHashes is a free data retrieval call binding the contract method 0x8a48b163.
+//
+// Solidity: function commitmentHashes(address ) view returns(bytes32)
+func (_ZKDKGContract *ZKDKGContractCallerSession) CommitmentHashes(arg0 common.Address) ([32]byte, error) {
+	return _ZKDKGContract.Contract.CommitmentHashes(&_ZKDKGContract.CallOpts, arg0)
+}
+
+// ExpiredDisputes is a free data retrieval call binding the contract method 0xa55b36a8.
+//
+// Solidity: function expiredDisputes() view returns(uint16[])
+func (_ZKDKGContract *ZKDKGContractCaller) ExpiredDisputes(opts *bind.CallOpts) ([]uint16, error) {
+	var out []interface{}
+	err := _ZKDKGContract.contract.Call(opts, &out, "expiredDisputes")
+
+	if err != nil {
+		return *new([]uint16), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([]uint16)).(*[]uint16)
+
+	return out0, err
+
+}
+
+// ExpiredDisputes is a free data retrieval call binding the contract method 0xa55b36a8.
+//
+// Solidity: function expiredDisputes() view returns(uint16[])
+func (_ZKDKGContract *ZKDKGContractSession) ExpiredDisputes() ([]uint16, error) {
+	return _ZKDKGContract.Contract.ExpiredDisputes(&_ZKDKGContract.CallOpts)
+}
+
+// ExpiredDisputes is a free data retrieval call binding the contract method 0xa55b36a8.
+//
+// Solidity: function expiredDisputes() view returns(uint16[])
+func (_ZKDKGContract *ZKDKGContractCallerSession) ExpiredDisputes() ([]uint16, error) {
+	return _ZKDKGContract.Contract.ExpiredDisputes(&_ZKDKGContract.CallOpts)
+}
+
+// FirstCoefficients is a free data retrieval call binding the contract method 0xad458c75.
+//
+// Solidity: function firstCoefficients(uint256 ) view returns(uint256)
+func (_ZKDKGContract *ZKDKGContractCaller) FirstCoefficients(opts *bind.CallOpts, arg0 *big.Int) (*big.Int, error) {
+	var out []interface{}
+	err := _ZKDKGContract.contract.Call(opts, &out, "firstCoefficients", arg0)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// FirstCoefficients is a free data retrieval call binding the contract method 0xad458c75.
+//
+// Solidity: function firstCoefficients(uint256 ) view returns(uint256)
+func (_ZKDKGContract *ZKDKGContractSession) FirstCoefficients(arg0 *big.Int) (*big.Int, error) {
+	return _ZKDKGContract.Contract.FirstCoefficients(&_ZKDKGContract.CallOpts, arg0)
+}
+
+// FirstCoefficients is a free data retrieval call binding the contract method 0xad458c75.
+//
+// Solidity: function firstCoefficients(uint256 ) view returns(uint256)
+func (_ZKDKGContract *ZKDKGContractCallerSession) FirstCoefficients(arg0 *big.Int) (*big.Int, error) {
+	return _ZKDKGContract.Contract.FirstCoefficients(&_ZKDKGContract.CallOpts, arg0)
+}
+
+// IsRegistered is a free data retrieval call binding the contract method 0xc3c5a547.
+//
+// Solidity: function isRegistered(address _addr) view returns(bool)
+func (_ZKDKGContract *ZKDKGContractCaller) IsRegistered(opts *bind.CallOpts, _addr common.Address) (bool, error) {
+	var out []interface{}
+	err := _ZKDKGContract.contract.Call(opts, &out, "isRegistered", _addr)
+
+	if err != nil {
+		return *new(bool), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(bool)).(*bool)
+
+	return out0, err
+
+}
+
+// IsRegistered is a free data retrieval call binding the contract method 0xc3c5a547.
+//
+// Solidity: function isRegistered(address _addr) view returns(bool)
+func (_ZKDKGContract *ZKDKGContractSession) IsRegistered(_addr common.Address) (bool, error) {
+	return _ZKDKGContract.Contract.IsRegistered(&_ZKDKGContract.CallOpts, _addr)
+}
+
+// IsRegistered is a free data retrieval call binding the contract method 0xc3c5a547.
+//
+// Solidity: function isRegistered(address _addr) view returns(bool)
+func (_ZKDKGContract *ZKDKGContractCallerSession) IsRegistered(_addr common.Address) (bool, error) {
+	return _ZKDKGContract.Contract.IsRegistered(&_ZKDKGContract.CallOpts, _addr)
+}
+
+// IsSettled is a free data retrieval call binding the contract method 0x3270bb5b.
+//
+// Solidity: function isSettled() view returns(bool)
+func (_ZKDKGContract *ZKDKGContractCaller) IsSettled(opts *bind.CallOpts) (bool, error) {
+	var out []interface{}
+	err := _ZKDKGContract.contract.Call(opts, &out, "isSettled")
+
+	if err != nil {
+		return *new(bool), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(bool)).(*bool)
+
+	return out0, err
+
+}
+
+// IsSettled is a free data retrieval call binding the contract method 0x3270bb5b.
+//
+// Solidity: function isSettled() view returns(bool)
+func (_ZKDKGContract *ZKDKGContractSession) IsSettled() (bool, error) {
+	return _ZKDKGContract.Contract.IsSettled(&_ZKDKGContract.CallOpts)
+}
+
+// IsSettled is a free data retrieval call binding the contract method 0x3270bb5b.
+//
+// Solidity: function isSettled() view returns(bool)
+func (_ZKDKGContract *ZKDKGContractCallerSession) IsSettled() (bool, error) {
+	return _ZKDKGContract.Contract.IsSettled(&_ZKDKGContract.CallOpts)
+}
+
+// KeyVerifier is a free data retrieval call binding the contract method 0xf1c545bd.
+//
+// Solidity: function keyVerifier() view returns(address)
+func (_ZKDKGContract *ZKDKGContractCaller) KeyVerifier(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _ZKDKGContract.contract.Call(opts, &out, "keyVerifier")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// KeyVerifier is a free data retrieval call binding the contract method 0xf1c545bd.
+//
+// Solidity: function keyVerifier() view returns(address)
+func (_ZKDKGContract *ZKDKGContractSession) KeyVerifier() (common.Address, error) {
+	return _ZKDKGContract.Contract.KeyVerifier(&_ZKDKGContract.CallOpts)
+}
+
+// KeyVerifier is a free data retrieval call binding the contract method 0xf1c545bd.
+//
+// Solidity: function keyVerifier() view returns(address)
+func (_ZKDKGContract *ZKDKGContractCallerSession) KeyVerifier() (common.Address, error) {
+	return _ZKDKGContract.Contract.KeyVerifier(&_ZKDKGContract.CallOpts)
+}
+
+// MinimumThreshold is a free data retrieval call binding the contract method 0x75da30d0.
+//
+// Solidity: function minimumThreshold() view returns(uint16)
+func (_ZKDKGContract *ZKDKGContractCaller) MinimumThreshold(opts *bind.CallOpts) (uint16, error) {
+	var out []interface{}
+	err := _ZKDKGContract.contract.Call(opts, &out, "minimumThreshold")
+
+	if err != nil {
+		return *new(uint16), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(uint16)).(*uint16)
+
+	return out0, err
+
+}
+
+// MinimumThreshold is a free data retrieval call binding the contract method 0x75da30d0.
+//
+// Solidity: function minimumThreshold() view returns(uint16)
+func (_ZKDKGContract *ZKDKGContractSession) MinimumThreshold() (uint16, error) {
+	return _ZKDKGContract.Contract.MinimumThreshold(&_ZKDKGContract.CallOpts)
+}
+
+// MinimumThreshold is a free data retrieval call binding the contract method 0x75da30d0.
+//
+// Solidity: function minimumThreshold() view returns(uint16)
+func (_ZKDKGContract *ZKDKGContractCallerSession) MinimumThreshold() (uint16, error) {
+	return _ZKDKGContract.Contract.MinimumThreshold(&_ZKDKGContract.CallOpts)
+}
+
+// NoParticipants is a free data retrieval call binding the contract method 0x3a3b4f62.
+//
+// Solidity: function noParticipants() view returns(uint16)
+func (_ZKDKGContract *ZKDKGContractCaller) NoParticipants(opts *bind.CallOpts) (uint16, error) {
+	var out []interface{}
+	err := _ZKDKGContract.contract.Call(opts, &out, "noParticipants")
+
+	if err != nil {
+		return *new(uint16), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(uint16)).(*uint16)
+
+	return out0, err
+
+}
+
+// NoParticipants is a free data retrieval call binding the contract method 0x3a3b4f62.
+//
+// Solidity: function noParticipants() view returns(uint16)
+func (_ZKDKGContract *ZKDKGContractSession) NoParticipants() (uint16, error) {
+	return _ZKDKGContract.Contract.NoParticipants(&_ZKDKGContract.CallOpts)
+}
+
+// NoParticipants is a free data retrieval call binding the contract method 0x3a3b4f62.
+//
+// Solidity: function noParticipants() view returns(uint16)
+func (_ZKDKGContract *ZKDKGContractCallerSession) NoParticipants() (uint16, error) {
+	return _ZKDKGContract.Contract.NoParticipants(&_ZKDKGContract.CallOpts)
+}
+
+// Participants is a free data retrieval call binding the contract method 0x09e69ede.
+//
+// Solidity: function participants(address ) view returns(uint16 index)
+func (_ZKDKGContract *ZKDKGContractCaller) Participants(opts *bind.CallOpts, arg0 common.Address) (uint16, error) {
+	var out []interface{}
+	err := _ZKDKGContract.contract.Call(opts, &out, "participants", arg0)
+
+	if err != nil {
+		return *new(uint16), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(uint16)).(*uint16)
+
+	return out0, err
+
+}
+
+// Participants is a free data retrieval call binding the contract method 0x09e69ede.
+//
+// Solidity: function participants(address ) view returns(uint16 index)
+func (_ZKDKGContract *ZKDKGContractSession) Participants(arg0 common.Address) (uint16, error) {
+	return _ZKDKGContract.Contract.Participants(&_ZKDKGContract.CallOpts, arg0)
+}
+
+// Participants is a free data retrieval call binding the contract method 0x09e69ede.
+//
+// Solidity: function participants(address ) view returns(uint16 index)
+func (_ZKDKGContract *ZKDKGContractCallerSession) Participants(arg0 common.Address) (uint16, error) {
+	return _ZKDKGContract.Contract.Participants(&_ZKDKGContract.CallOpts, arg0)
+}
+
+// PeriodLength is a free data retrieval call binding the contract method 0xd2ca2115.
+//
+// Solidity: function periodLength() view returns(uint16)
+func (_ZKDKGContract *ZKDKGContractCaller) PeriodLength(opts *bind.CallOpts) (uint16, error) {
+	var out []interface{}
+	err := _ZKDKGContract.contract.Call(opts, &out, "periodLength")
+
+	if err != nil {
+		return *new(uint16), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(uint16)).(*uint16)
+
+	return out0, err
+
+}
+
+// PeriodLength is a free data retrieval call binding the contract method 0xd2ca2115.
+//
+// Solidity: function periodLength() view returns(uint16)
+func (_ZKDKGContract *ZKDKGContractSession) PeriodLength() (uint16, error) {
+	return _ZKDKGContract.Contract.PeriodLength(&_ZKDKGContract.CallOpts)
+}
+
+// PeriodLength is a free data retrieval call binding the contract method 0xd2ca2115.
+//
+// Solidity: function periodLength() view returns(uint16)
+func (_ZKDKGContract *ZKDKGContractCallerSession) PeriodLength() (uint16, error) {
+	return _ZKDKGContract.Contract.PeriodLength(&_ZKDKGContract.CallOpts)
+}
+
+// Phase is a free data retrieval call binding the contract method 0xb1c9fe6e.
+//
+// Solidity: function phase() view returns(uint8)
+func (_ZKDKGContract *ZKDKGContractCaller) Phase(opts *bind.CallOpts) (uint8, error) {
+	var out []interface{}
+	err := _ZKDKGContract.contract.Call(opts, &out, "phase")
+
+	if err != nil {
+		return *new(uint8), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(uint8)).(*uint8)
+
+	return out0, err
+
+}
+
+// Phase is a free data retrieval call binding the contract method 0xb1c9fe6e.
+//
+// Solidity: function phase() view returns(uint8)
+func (_ZKDKGContract *ZKDKGContractSession) Phase() (uint8, error) {
+	return _ZKDKGContract.Contract.Phase(&_ZKDKGContract.CallOpts)
+}
+
+// Phase is a free data retrieval call binding the contract method 0xb1c9fe6e.
+//
+// Solidity: function phase() view returns(uint8)
+func (_ZKDKGContract *ZKDKGContractCallerSession) Phase() (uint8, error) {
+	return _ZKDKGContract.Contract.Phase(&_ZKDKGContract.CallOpts)
+}
+
+// PhaseEnd is a free data retrieval call binding the contract method 0xccf2a742.
+//
+// Solidity: function phaseEnd() view returns(uint64)
+func (_ZKDKGContract *ZKDKGContractCaller) PhaseEnd(opts *bind.CallOpts) (uint64, error) {
+	var out []interface{}
+	err := _ZKDKGContract.contract.Call(opts, &out, "phaseEnd")
+
+	if err != nil {
+		return *new(uint64), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(uint64)).(*uint64)
+
+	return out0, err
+
+}
+
+// PhaseEnd is a free data retrieval call binding the contract method 0xccf2a742.
+//
+// Solidity: function phaseEnd() view returns(uint64)
+func (_ZKDKGContract *ZKDKGContractSession) PhaseEnd() (uint64, error) {
+	return _ZKDKGContract.Contract.PhaseEnd(&_ZKDKGContract.CallOpts)
+}
+
+// PhaseEnd is a free data retrieval call binding the contract method 0xccf2a742.
+//
+// Solidity: function phaseEnd() view returns(uint64)
+func (_ZKDKGContract *ZKDKGContractCallerSession) PhaseEnd() (uint64, error) {
+	return _ZKDKGContract.Contract.PhaseEnd(&_ZKDKGContract.CallOpts)
+}
+
+// PublicKeys is a free data retrieval call binding the contract method 0xe96d6e4f.
+//
+// Solidity: function publicKeys() view returns(uint256[2][])
+func (_ZKDKGContract *ZKDKGContractCaller) PublicKeys(opts *bind.CallOpts) ([][2]*big.Int, error) {
+	var out []interface{}
+	err := _ZKDKGContract.contract.Call(opts, &out, "publicKeys")
+
+	if err != nil {
+		return *new([][2]*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([][2]*big.Int)).(*[][2]*big.Int)
+
+	return out0, err
+
+}
+
+// PublicKeys is a free data retrieval call binding the contract method 0xe96d6e4f.
+//
+// Solidity: function publicKeys() view returns(uint256[2][])
+func (_ZKDKGContract *ZKDKGContractSession) PublicKeys() ([][2]*big.Int, error) {
+	return _ZKDKGContract.Contract.PublicKeys(&_ZKDKGContract.CallOpts)
+}
+
+// PublicKeys is a free data retrieval call binding the contract method 0xe96d6e4f.
+//
+// Solidity: function publicKeys() view returns(uint256[2][])
+func (_ZKDKGContract *ZKDKGContractCallerSession) PublicKeys() ([][2]*big.Int, error) {
+	return _ZKDKGContract.Contract.PublicKeys(&_ZKDKGContract.CallOpts)
+}
+
+// ShareHashes is a free data retrieval call binding the contract method 0xfec140fc.
+//
+// Solidity: function shareHashes(address ) view returns(bytes32)
+func (_ZKDKGContract *ZKDKGContractCaller) ShareHashes(opts *bind.CallOpts, arg0 common.Address) ([32]byte, error) {
+	var out []interface{}
+	err := _ZKDKGContract.contract.Call(opts, &out, "shareHashes", arg0)
+
+	if err != nil {
+		return *new([32]byte), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([32]byte)).(*[32]byte)
+
+	return out0, err
+
+}
+
+// ShareHashes is a free data retrieval call binding the contract method 0xfec140fc.
+//
+// Solidity: function shareHashes(address ) view returns(bytes32)
+func (_ZKDKGContract *ZKDKGContractSession) ShareHashes(arg0 common.Address) ([32]byte, error) {
+	return _ZKDKGContract.Contract.ShareHashes(&_ZKDKGContract.CallOpts, arg0)
+}
+
+// ShareHashes is a free data retrieval call binding the contract method 0xfec140fc.
+//
+// Solidity: function shareHashes(address ) view returns(bytes32)
+func (_ZKDKGContract *ZKDKGContractCallerSession) ShareHashes(arg0 common.Address) ([32]byte, error) {
+	return _ZKDKGContract.Contract.ShareHashes(&_ZKDKGContract.CallOpts, arg0)
+}
+
+// ShareVerifier is a free data retrieval call binding the contract method 0x236a74b1.
+//
+// Solidity: function shareVerifier() view returns(address)
+func (_ZKDKGContract *ZKDKGContractCaller) ShareVerifier(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _ZKDKGContract.contract.Call(opts, &out, "shareVerifier")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// ShareVerifier is a free data retrieval call binding the contract method 0x236a74b1.
+//
+// Solidity: function shareVerifier() view returns(address)
+func (_ZKDKGContract *ZKDKGContractSession) ShareVerifier() (common.Address, error) {
+	return _ZKDKGContract.Contract.ShareVerifier(&_ZKDKGContract.CallOpts)
+}
+
+// ShareVerifier is a free data retrieval call binding the contract method 0x236a74b1.
+//
+// Solidity: function shareVerifier() view returns(address)
+func (_ZKDKGContract *ZKDKGContractCallerSession) ShareVerifier() (common.Address, error) {
+	return _ZKDKGContract.Contract.ShareVerifier(&_ZKDKGContract.CallOpts)
+}
+
+// Slashed is a free data retrieval call binding the contract method 0x0a1ca632.
+//
+// Solidity: function slashed() view returns(uint256)
+func (_ZKDKGContract *ZKDKGContractCaller) Slashed(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _ZKDKGContract.contract.Call(opts, &out, "slashed")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// Slashed is a free data retrieval call binding the contract method 0x0a1ca632.
+//
+// Solidity: function slashed() view returns(uint256)
+func (_ZKDKGContract *ZKDKGContractSession) Slashed() (*big.Int, error) {
+	return _ZKDKGContract.Contract.Slashed(&_ZKDKGContract.CallOpts)
+}
+
+// Slashed is a free data retrieval call binding the contract method 0x0a1ca632.
+//
+// Solidity: function slashed() view returns(uint256)
+func (_ZKDKGContract *ZKDKGContractCallerSession) Slashed() (*big.Int, error) {
+	return _ZKDKGContract.Contract.Slashed(&_ZKDKGContract.CallOpts)
+}
+
+// Stakes is a free data retrieval call binding the contract method 0x16934fc4.
+//
+// Solidity: function stakes(address ) view returns(uint256)
+func (_ZKDKGContract *ZKDKGContractCaller) Stakes(opts *bind.CallOpts, arg0 common.Address) (*big.Int, error) {
+	var out []interface{}
+	err := _ZKDKGContract.contract.Call(opts, &out, "stakes", arg0)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// Stakes is a free data retrieval call binding the contract method 0x16934fc4.
+//
+// Solidity: function stakes(address ) view returns(uint256)
+func (_ZKDKGContract *ZKDKGContractSession) Stakes(arg0 common.Address) (*big.Int, error) {
+	return _ZKDKGContract.Contract.Stakes(&_ZKDKGContract.CallOpts, arg0)
+}
+
+// Stakes is a free data retrieval call binding the contract method 0x16934fc4.
+//
+// Solidity: function stakes(address ) view returns(uint256)
+func (_ZKDKGContract *ZKDKGContractCallerSession) Stakes(arg0 common.Address) (*big.Int, error) {
+	return _ZKDKGContract.Contract.Stakes(&_ZKDKGContract.CallOpts, arg0)
+}
+
+// UserThreshold is a free data retrieval call binding the contract method 0xdb5e75a0.
+//
+// Solidity: function userThreshold() view returns(uint16)
+func (_ZKDKGContract *ZKDKGContractCaller) UserThreshold(opts *bind.CallOpts) (uint16, error) {
+	var out []interface{}
+	err := _ZKDKGContract.contract.Call(opts, &out, "userThreshold")
+
+	if err != nil {
+		return *new(uint16), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(uint16)).(*uint16)
+
+	return out0, err
+
+}
+
+// UserThreshold is a free data retrieval call binding the contract method 0xdb5e75a0.
+//
+// Solidity: function userThreshold() view returns(uint16)
+func (_ZKDKGContract *ZKDKGContractSession) UserThreshold() (uint16, error) {
+	return _ZKDKGContract.Contract.UserThreshold(&_ZKDKGContract.CallOpts)
+}
+
+// UserThreshold is a free data retrieval call binding the contract method 0xdb5e75a0.
+//
+// Solidity: function userThreshold() view returns(uint16)
+func (_ZKDKGContract *ZKDKGContractCallerSession) UserThreshold() (uint16, error) {
+	return _ZKDKGContract.Contract.UserThreshold(&_ZKDKGContract.CallOpts)
+}
+
+// BroadcastShares is a paid mutator transaction binding the contract method 0x318f2d57.
+//
+// Solidity: function broadcastShares(uint256[] commitments, uint256[] shares) returns()
+func (_ZKDKGContract *ZKDKGContractTransactor) BroadcastShares(opts *bind.TransactOpts, commitments []*big.Int, shares []*big.Int) (*types.Transaction, error) {
+	return _ZKDKGContract.contract.Transact(opts, "broadcastShares", commitments, shares)
+}
+
+// BroadcastShares is a paid mutator transaction binding the contract method 0x318f2d57.
+//
+// Solidity: function broadcastShares(uint256[] commitments, uint256[] shares) returns()
+func (_ZKDKGContract *ZKDKGContractSession) BroadcastShares(commitments []*big.Int, shares []*big.Int) (*types.Transaction, error) {
+	return _ZKDKGContract.Contract.BroadcastShares(&_ZKDKGContract.TransactOpts, commitments, shares)
+}
+
+// BroadcastShares is a paid mutator transaction binding the contract method 0x318f2d57.
+//
+// Solidity: function broadcastShares(uint256[] commitments, uint256[] shares) returns()
+func (_ZKDKGContract *ZKDKGContractTransactorSession) BroadcastShares(commitments []*big.Int, shares []*big.Int) (*types.Transaction, error) {
+	return _ZKDKGContract.Contract.BroadcastShares(&_ZKDKGContract.TransactOpts, commitments, shares)
+}
+
+// DefendShare is a paid mutator transaction binding the contract method 0x66320250.
+//
+// Solidity: function defendShare(((uint256,uint256),(uint256[2],uint256[2]),(uint256,uint256)) proof) returns()
+func (_ZKDKGContract *ZKDKGContractTransactor) DefendShare(opts *bind.TransactOpts, proof ShareVerifierProof) (*types.Transaction, error) {
+	return _ZKDKGContract.contract.Transact(opts, "defendShare", proof)
+}
+
+// DefendShare is a paid mutator transaction binding the contract method 0x66320250.
+//
+// Solidity: function defendShare(((uint256,uint256),(uint256[2],uint256[2]),(uint256,uint256)) proof) returns()
+func (_ZKDKGContract *ZKDKGContractSession) DefendShare(proof ShareVerifierProof) (*types.Transaction, error) {
+	return _ZKDKGContract.Contract.DefendShare(&_ZKDKGContract.TransactOpts, proof)
+}
+
+// DefendShare is a paid mutator transaction binding the contract method 0x66320250.
+//
+// Solidity: function defendShare(((uint256,uint256),(uint256[2],uint256[2]),(uint256,uint256)) proof) returns()
+func (_ZKDKGContract *ZKDKGContractTransactorSession) DefendShare(proof ShareVerifierProof) (*types.Transaction, error) {
+	return _ZKDKGContract.Contract.DefendShare(&_ZKDKGContract.TransactOpts, proof)
+}
+
+// DisputeShare is a paid mutator transaction binding the contract method 0x2a6e222c.
+//
+// Solidity: function disputeShare(uint16 disputeeIndex, uint256[] shares) returns()
+func (_ZKDKGContract *ZKDKGContractTransactor) DisputeShare(opts *bind.TransactOpts, disputeeIndex uint16, shares []*big.Int) (*types.Transaction, error) {
+	return _ZKDKGContract.contract.Transact(opts, "disputeShare", disputeeIndex, shares)
+}
+
+// DisputeShare is a paid mutator transaction binding the contract method 0x2a6e222c.
+//
+// Solidity: function disputeShare(uint16 disputeeIndex, uint256[] shares) returns()
+func (_ZKDKGContract *ZKDKGContractSession) DisputeShare(disputeeIndex uint16, shares []*big.Int) (*types.Transaction, error) {
+	return _ZKDKGContract.Contract.DisputeShare(&_ZKDKGContract.TransactOpts, disputeeIndex, shares)
+}
+
+// DisputeShare is a paid mutator transaction binding the contract method 0x2a6e222c.
+//
+// Solidity: function disputeShare(uint16 disputeeIndex, uint256[] shares) returns()
+func (_ZKDKGContract *ZKDKGContractTransactorSession) DisputeShare(disputeeIndex uint16, shares []*big.Int) (*types.Transaction, error) {
+	return _ZKDKGContract.Contract.DisputeShare(&_ZKDKGContract.TransactOpts, disputeeIndex, shares)
+}
+
+// Register is a paid mutator transaction binding the contract method 0x4fdbefc9.
+//
+// Solidity: function register(uint256[2] publicKey, uint256[2] possessionProof) payable returns()
+func (_ZKDKGContract *ZKDKGContractTransactor) Register(opts *bind.TransactOpts, publicKey [2]*big.Int, possessionProof [2]*big.Int) (*types.Transaction, error) {
+	return _ZKDKGContract.contract.Transact(opts, "register", publicKey, possessionProof)
+}
+
+// Register is a paid mutator transaction binding the contract method 0x4fdbefc9.
+//
+// Solidity: function register(uint256[2] publicKey, uint256[2] possessionProof) payable returns()
+func (_ZKDKGContract *ZKDKGContractSession) Register(publicKey [2]*big.Int, possessionProof [2]*big.Int) (*types.Transaction, error) {
+	return _ZKDKGContract.Contract.Register(&_ZKDKGContract.TransactOpts, publicKey, possessionProof)
+}
+
+// Register is a paid mutator transaction binding the contract method 0x4fdbefc9.
+//
+// Solidity: function register(uint256[2] publicKey, uint256[2] possessionProof) payable returns()
+func (_ZKDKGContract *ZKDKGContractTransactorSession) Register(publicKey [2]*big.Int, possessionProof [2]*big.Int) (*types.Transaction, error) {
+	return _ZKDKGContract.Contract.Register(&_ZKDKGContract.TransactOpts, publicKey, possessionProof)
+}
+
+// RegisterCompressed is a paid mutator transaction binding the contract method 0x89bb986a.
+//
+// Solidity: function registerCompressed(uint256 compressedKey, uint256[2] possessionProof) payable returns()
+func (_ZKDKGContract *ZKDKGContractTransactor) RegisterCompressed(opts *bind.TransactOpts, compressedKey *big.Int, possessionProof [2]*big.Int) (*types.Transaction, error) {
+	return _ZKDKGContract.contract.Transact(opts, "registerCompressed", compressedKey, possessionProof)
+}
+
+// RegisterCompressed is a paid mutator transaction binding the contract method 0x89bb986a.
+//
+// Solidity: function registerCompressed(uint256 compressedKey, uint256[2] possessionProof) payable returns()
+func (_ZKDKGContract *ZKDKGContractSession) RegisterCompressed(compressedKey *big.Int, possessionProof [2]*big.Int) (*types.Transaction, error) {
+	return _ZKDKGContract.Contract.RegisterCompressed(&_ZKDKGContract.TransactOpts, compressedKey, possessionProof)
+}
+
+// RegisterCompressed is a paid mutator transaction binding the contract method 0x89bb986a.
+//
+// Solidity: function registerCompressed(uint256 compressedKey, uint256[2] possessionProof) payable returns()
+func (_ZKDKGContract *ZKDKGContractTransactorSession) RegisterCompressed(compressedKey *big.Int, possessionProof [2]*big.Int) (*types.Transaction, error) {
+	return _ZKDKGContract.Contract.RegisterCompressed(&_ZKDKGContract.TransactOpts, compressedKey, possessionProof)
+}
+
+// Settle is a paid mutator transaction binding the contract method 0x11da60b4.
+//
+// Solidity: function settle() returns()
+func (_ZKDKGContract *ZKDKGContractTransactor) Settle(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _ZKDKGContract.contract.Transact(opts, "settle")
+}
+
+// Settle is a paid mutator transaction binding the contract method 0x11da60b4.
+//
+// Solidity: function settle() returns()
+func (_ZKDKGContract *ZKDKGContractSession) Settle() (*types.Transaction, error) {
+	return _ZKDKGContract.Contract.Settle(&_ZKDKGContract.TransactOpts)
+}
+
+// Settle is a paid mutator transaction binding the contract method 0x11da60b4.
+//
+// Solidity: function settle() returns()
+func (_ZKDKGContract *ZKDKGContractTransactorSession) Settle() (*types.Transaction, error) {
+	return _ZKDKGContract.Contract.Settle(&_ZKDKGContract.TransactOpts)
+}
+
+// SubmitPublicKey is a paid mutator transaction binding the contract method 0x7632dae1.
+//
+// Solidity: function submitPublicKey(uint256[2] _publicKey, ((uint256,uint256),(uint256[2],uint256[2]),(uint256,uint256)) proof) returns()
+func (_ZKDKGContract *ZKDKGContractTransactor) SubmitPublicKey(opts *bind.TransactOpts, _publicKey [2]*big.Int, proof KeyVerifierProof) (*types.Transaction, error) {
+	return _ZKDKGContract.contract.Transact(opts, "submitPublicKey", _publicKey, proof)
+}
+
+// SubmitPublicKey is a paid mutator transaction binding the contract method 0x7632dae1.
+//
+// Solidity: function submitPublicKey(uint256[2] _publicKey, ((uint256,uint256),(uint256[2],uint256[2]),(uint256,uint256)) proof) returns()
+func (_ZKDKGContract *ZKDKGContractSession) SubmitPublicKey(_publicKey [2]*big.Int, proof KeyVerifierProof) (*types.Transaction, error) {
+	return _ZKDKGContract.Contract.SubmitPublicKey(&_ZKDKGContract.TransactOpts, _publicKey, proof)
+}
+
+// SubmitPublicKey is a paid mutator transaction binding the contract method 0x7632dae1.
+//
+// Solidity: function submitPublicKey(uint256[2] _publicKey, ((uint256,uint256),(uint256[2],uint256[2]),(uint256,uint256)) proof) returns()
+func (_ZKDKGContract *ZKDKGContractTransactorSession) SubmitPublicKey(_publicKey [2]*big.Int, proof KeyVerifierProof) (*types.Transaction, error) {
+	return _ZKDKGContract.Contract.SubmitPublicKey(&_ZKDKGContract.TransactOpts, _publicKey, proof)
+}
+
+// Withdraw is a paid mutator transaction binding the contract method 0x3ccfd60b.
+//
+// Solidity: function withdraw() returns()
+func (_ZKDKGContract *ZKDKGContractTransactor) Withdraw(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _ZKDKGContract.contract.Transact(opts, "withdraw")
+}
+
+// Withdraw is a paid mutator transaction binding the contract method 0x3ccfd60b.
+//
+// Solidity: function withdraw() returns()
+func (_ZKDKGContract *ZKDKGContractSession) Withdraw() (*types.Transaction, error) {
+	return _ZKDKGContract.Contract.Withdraw(&_ZKDKGContract.TransactOpts)
+}
+
+// Withdraw is a paid mutator transaction binding the contract method 0x3ccfd60b.
+//
+// Solidity: function withdraw() returns()
+func (_ZKDKGContract *ZKDKGContractTransactorSession) Withdraw() (*types.Transaction, error) {
+	return _ZKDKGContract.Contract.Withdraw(&_ZKDKGContract.TransactOpts)
+}
+
+// ZKDKGContractAbortionIterator is returned from FilterAbortion and is used to iterate over the raw logs and unpacked data for Abortion events raised by the ZKDKGContract contract.
+type ZKDKGContractAbortionIterator struct {
+	Event *ZKDKGContractAbortion // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *ZKDKGContractAbortionIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(ZKDKGContractAbortion)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(ZKDKGContractAbortion)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *ZKDKGContractAbortionIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *ZKDKGContractAbortionIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// ZKDKGContractAbortion represents a Abortion event raised by the ZKDKGContract contract.
+type ZKDKGContractAbortion struct {
+	Raw types.Log // Blockchain specific contextual infos
+}
+
+// FilterAbortion is a free log retrieval operation binding the contract event 0xe32f118ee0f8694bcae304f750e5a480bc5f1ca7a5b24c60f47fb29c0a9efe70.
+//
+// Solidity: event Abortion()
+func (_ZKDKGContract *ZKDKGContractFilterer) FilterAbortion(opts *bind.FilterOpts) (*ZKDKGContractAbortionIterator, error) {
+
+	logs, sub, err := _ZKDKGContract.contract.FilterLogs(opts, "Abortion")
+	if err != nil {
+		return nil, err
+	}
+	return &ZKDKGContractAbortionIterator{contract: _ZKDKGContract.contract, event: "Abortion", logs: logs, sub: sub}, nil
+}
+
+// WatchAbortion is a free log subscription operation binding the contract event 0xe32f118ee0f8694bcae304f750e5a480bc5f1ca7a5b24c60f47fb29c0a9efe70.
+//
+// Solidity: event Abortion()
+func (_ZKDKGContract *ZKDKGContractFilterer) WatchAbortion(opts *bind.WatchOpts, sink chan<- *ZKDKGContractAbortion) (event.Subscription, error) {
+
+	logs, sub, err := _ZKDKGContract.contract.WatchLogs(opts, "Abortion")
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(ZKDKGContractAbortion)
+				if err := _ZKDKGContract.contract.UnpackLog(event, "Abortion", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseAbortion is a log parse operation binding the contract event 0xe32f118ee0f8694bcae304f750e5a480bc5f1ca7a5b24c60f47fb29c0a9efe70.
+//
+// Solidity: event Abortion()
+func (_ZKDKGContract *ZKDKGContractFilterer) ParseAbortion(log types.Log) (*ZKDKGContractAbortion, error) {
+	event := new(ZKDKGContractAbortion)
+	if err := _ZKDKGContract.contract.UnpackLog(event, "Abortion", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// ZKDKGContractBroadcastSharesLogIterator is returned from FilterBroadcastSharesLog and is used to iterate over the raw logs and unpacked data for BroadcastSharesLog events raised by the ZKDKGContract contract.
+type ZKDKGContractBroadcastSharesLogIterator struct {
+	Event *ZKDKGContractBroadcastSharesLog // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *ZKDKGContractBroadcastSharesLogIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(ZKDKGContractBroadcastSharesLog)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(ZKDKGContractBroadcastSharesLog)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *ZKDKGContractBroadcastSharesLogIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *ZKDKGContractBroadcastSharesLogIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// ZKDKGContractBroadcastSharesLog represents a BroadcastSharesLog event raised by the ZKDKGContract contract.
+type ZKDKGContractBroadcastSharesLog struct {
+	Sender           common.Address
+	BroadcasterIndex uint16
+	Raw              types.Log // Blockchain specific contextual infos
+}
+
+// FilterBroadcastSharesLog is a free log retrieval operation binding the contract event 0x6cfce17021d02cafcfcd949e9bd0120d6941dec93a228d6cd4564f16c58781bc.
+//
+// Solidity: event BroadcastSharesLog(address sender, uint16 broadcasterIndex)
+func (_ZKDKGContract *ZKDKGContractFilterer) FilterBroadcastSharesLog(opts *bind.FilterOpts) (*ZKDKGContractBroadcastSharesLogIterator, error) {
+
+	logs, sub, err := _ZKDKGContract.contract.FilterLogs(opts, "BroadcastSharesLog")
+	if err != nil {
+		return nil, err
+	}
+	return &ZKDKGContractBroadcastSharesLogIterator{contract: _ZKDKGContract.contract, event: "BroadcastSharesLog", logs: logs, sub: sub}, nil
+}
+
+// WatchBroadcastSharesLog is a free log subscription operation binding the contract event 0x6cfce17021d02cafcfcd949e9bd0120d6941dec93a228d6cd4564f16c58781bc.
+//
+// Solidity: event BroadcastSharesLog(address sender, uint16 broadcasterIndex)
+func (_ZKDKGContract *ZKDKGContractFilterer) WatchBroadcastSharesLog(opts *bind.WatchOpts, sink chan<- *ZKDKGContractBroadcastSharesLog) (event.Subscription, error) {
+
+	logs, sub, err := _ZKDKGContract.contract.WatchLogs(opts, "BroadcastSharesLog")
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(ZKDKGContractBroadcastSharesLog)
+				if err := _ZKDKGContract.contract.UnpackLog(event, "BroadcastSharesLog", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseBroadcastSharesLog is a log parse operation binding the contract event 0x6cfce17021d02cafcfcd949e9bd0120d6941dec93a228d6cd4564f16c58781bc.
+//
+// Solidity: event BroadcastSharesLog(address sender, uint16 broadcasterIndex)
+func (_ZKDKGContract *ZKDKGContractFilterer) ParseBroadcastSharesLog(log types.Log) (*ZKDKGContractBroadcastSharesLog, error) {
+	event := new(ZKDKGContractBroadcastSharesLog)
+	if err := _ZKDKGContract.contract.UnpackLog(event, "BroadcastSharesLog", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// ZKDKGContractDisputeShareIterator is returned from FilterDisputeShare and is used to iterate over the raw logs and unpacked data for DisputeShare events raised by the ZKDKGContract contract.
+type ZKDKGContractDisputeShareIterator struct {
+	Event *ZKDKGContractDisputeShare // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *ZKDKGContractDisputeShareIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(ZKDKGContractDisputeShare)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(ZKDKGContractDisputeShare)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *ZKDKGContractDisputeShareIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *ZKDKGContractDisputeShareIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// ZKDKGContractDisputeShare represents a DisputeShare event raised by the ZKDKGContract contract.
+type ZKDKGContractDisputeShare struct {
+	DisputerIndex uint16
+	DisputeeIndex uint16
+	Raw           types.Log // Blockchain specific contextual infos
+}
+
+// FilterDisputeShare is a free log retrieval operation binding the contract event 0x17e1f96ba5a1e9a871cfa1490f48f17dd524a7231ba340612926dd38abf1260d.
+//
+// Solidity: event DisputeShare(uint16 disputerIndex, uint16 disputeeIndex)
+func (_ZKDKGContract *ZKDKGContractFilterer) FilterDisputeShare(opts *bind.FilterOpts) (*ZKDKGContractDisputeShareIterator, error) {
+
+	logs, sub, err := _ZKDKGContract.contract.FilterLogs(opts, "DisputeShare")
+	if err != nil {
+		return nil, err
+	}
+	return &ZKDKGContractDisputeShareIterator{contract: _ZKDKGContract.contract, event: "DisputeShare", logs: logs, sub: sub}, nil
+}
+
+// WatchDisputeShare is a free log subscription operation binding the contract event 0x17e1f96ba5a1e9a871cfa1490f48f17dd524a7231ba340612926dd38abf1260d.
+//
+// Solidity: event DisputeShare(uint16 disputerIndex, uint16 disputeeIndex)
+func (_ZKDKGContract *ZKDKGContractFilterer) WatchDisputeShare(opts *bind.WatchOpts, sink chan<- *ZKDKGContractDisputeShare) (event.Subscription, error) {
+
+	logs, sub, err := _ZKDKGContract.contract.WatchLogs(opts, "DisputeShare")
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(ZKDKGContractDisputeShare)
+				if err := _ZKDKGContract.contract.UnpackLog(event, "DisputeShare", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseDisputeShare is a log parse operation binding the contract event 0x17e1f96ba5a1e9a871cfa1490f48f17dd524a7231ba340612926dd38abf1260d.
+//
+// Solidity: event DisputeShare(uint16 disputerIndex, uint16 disputeeIndex)
+func (_ZKDKGContract *ZKDKGContractFilterer) ParseDisputeShare(log types.Log) (*ZKDKGContractDisputeShare, error) {
+	event := new(ZKDKGContractDisputeShare)
+	if err := _ZKDKGContract.contract.UnpackLog(event, "DisputeShare", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// ZKDKGContractDistributionEndLogIterator is returned from FilterDistributionEndLog and is used to iterate over the raw logs and unpacked data for DistributionEndLog events raised by the ZKDKGContract contract.
+type ZKDKGContractDistributionEndLogIterator struct {
+	Event *ZKDKGContractDistributionEndLog // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *ZKDKGContractDistributionEndLogIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(ZKDKGContractDistributionEndLog)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(ZKDKGContractDistributionEndLog)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *ZKDKGContractDistributionEndLogIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *ZKDKGContractDistributionEndLogIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// ZKDKGContractDistributionEndLog represents a DistributionEndLog event raised by the ZKDKGContract contract.
+type ZKDKGContractDistributionEndLog struct {
+	Raw types.Log // Blockchain specific contextual infos
+}
+
+// FilterDistributionEndLog is a free log retrieval operation binding the contract event 0x6ef19bb8899f00ceaba46657440ee982630ff9ce4d5265ec3398dffcd097630d.
+//
+// Solidity: event DistributionEndLog()
+func (_ZKDKGContract *ZKDKGContractFilterer) FilterDistributionEndLog(opts *bind.FilterOpts) (*ZKDKGContractDistributionEndLogIterator, error) {
+
+	logs, sub, err := _ZKDKGContract.contract.FilterLogs(opts, "DistributionEndLog")
+	if err != nil {
+		return nil, err
+	}
+	return &ZKDKGContractDistributionEndLogIterator{contract: _ZKDKGContract.contract, event: "DistributionEndLog", logs: logs, sub: sub}, nil
+}
+
+// WatchDistributionEndLog is a free log subscription operation binding the contract event 0x6ef19bb8899f00ceaba46657440ee982630ff9ce4d5265ec3398dffcd097630d.
+//
+// Solidity: event DistributionEndLog()
+func (_ZKDKGContract *ZKDKGContractFilterer) WatchDistributionEndLog(opts *bind.WatchOpts, sink chan<- *ZKDKGContractDistributionEndLog) (event.Subscription, error) {
+
+	logs, sub, err := _ZKDKGContract.contract.WatchLogs(opts, "DistributionEndLog")
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(ZKDKGContractDistributionEndLog)
+				if err := _ZKDKGContract.contract.UnpackLog(event, "DistributionEndLog", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseDistributionEndLog is a log parse operation binding the contract event 0x6ef19bb8899f00ceaba46657440ee982630ff9ce4d5265ec3398dffcd097630d.
+//
+// Solidity: event DistributionEndLog()
+func (_ZKDKGContract *ZKDKGContractFilterer) ParseDistributionEndLog(log types.Log) (*ZKDKGContractDistributionEndLog, error) {
+	event := new(ZKDKGContractDistributionEndLog)
+	if err := _ZKDKGContract.contract.UnpackLog(event, "DistributionEndLog", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// ZKDKGContractExclusionIterator is returned from FilterExclusion and is used to iterate over the raw logs and unpacked data for Exclusion events raised by the ZKDKGContract contract.
+type ZKDKGContractExclusionIterator struct {
+	Event *ZKDKGContractExclusion // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *ZKDKGContractExclusionIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(ZKDKGContractExclusion)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(ZKDKGContractExclusion)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *ZKDKGContractExclusionIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *ZKDKGContractExclusionIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// ZKDKGContractExclusion represents a Exclusion event raised by the ZKDKGContract contract.
+type ZKDKGContractExclusion struct {
+	Index uint16
+	Raw   types.Log // Blockchain specific contextual infos
+}
+
+// FilterExclusion is a free log retrieval operation binding the contract event 0xfb5a16b920658c8bf2bc36419e44de81b5cada454690d77997e2201afd28511d.
+//
+// Solidity: event Exclusion(uint16 index)
+func (_ZKDKGContract *ZKDKGContractFilterer) FilterExclusion(opts *bind.FilterOpts) (*ZKDKGContractExclusionIterator, error) {
+
+	logs, sub, err := _ZKDKGContract.contract.FilterLogs(opts, "Exclusion")
+	if err != nil {
+		return nil, err
+	}
+	return &ZKDKGContractExclusionIterator{contract: _ZKDKGContract.contract, event: "Exclusion", logs: logs, sub: sub}, nil
+}
+
+// WatchExclusion is a free log subscription operation binding the contract event 0xfb5a16b920658c8bf2bc36419e44de81b5cada454690d77997e2201afd28511d.
+//
+// Solidity: event Exclusion(uint16 index)
+func (_ZKDKGContract *ZKDKGContractFilterer) WatchExclusion(opts *bind.WatchOpts, sink chan<- *ZKDKGContractExclusion) (event.Subscription, error) {
+
+	logs, sub, err := _ZKDKGContract.contract.WatchLogs(opts, "Exclusion")
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(ZKDKGContractExclusion)
+				if err := _ZKDKGContract.contract.UnpackLog(event, "Exclusion", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseExclusion is a log parse operation binding the contract event 0xfb5a16b920658c8bf2bc36419e44de81b5cada454690d77997e2201afd28511d.
+//
+// Solidity: event Exclusion(uint16 index)
+func (_ZKDKGContract *ZKDKGContractFilterer) ParseExclusion(log types.Log) (*ZKDKGContractExclusion, error) {
+	event := new(ZKDKGContractExclusion)
+	if err := _ZKDKGContract.contract.UnpackLog(event, "Exclusion", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// ZKDKGContractPublicKeySubmissionIterator is returned from FilterPublicKeySubmission and is used to iterate over the raw logs and unpacked data for PublicKeySubmission events raised by the ZKDKGContract contract.
+type ZKDKGContractPublicKeySubmissionIterator struct {
+	Event *ZKDKGContractPublicKeySubmission // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *ZKDKGContractPublicKeySubmissionIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(ZKDKGContractPublicKeySubmission)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(ZKDKGContractPublicKeySubmission)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *ZKDKGContractPublicKeySubmissionIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *ZKDKGContractPublicKeySubmissionIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// ZKDKGContractPublicKeySubmission represents a PublicKeySubmission event raised by the ZKDKGContract contract.
+type ZKDKGContractPublicKeySubmission struct {
+	Raw types.Log // Blockchain specific contextual infos
+}
+
+// FilterPublicKeySubmission is a free log retrieval operation binding the contract event 0x826bbd625910e29607d941b29f4e89a8a0e8100a4fdb04b92335b732e919b24d.
+//
+// Solidity: event PublicKeySubmission()
+func (_ZKDKGContract *ZKDKGContractFilterer) FilterPublicKeySubmission(opts *bind.FilterOpts) (*ZKDKGContractPublicKeySubmissionIterator, error) {
+
+	logs, sub, err := _ZKDKGContract.contract.FilterLogs(opts, "PublicKeySubmission")
+	if err != nil {
+		return nil, err
+	}
+	return &ZKDKGContractPublicKeySubmissionIterator{contract: _ZKDKGContract.contract, event: "PublicKeySubmission", logs: logs, sub: sub}, nil
+}
+
+// WatchPublicKeySubmission is a free log subscription operation binding the contract event 0x826bbd625910e29607d941b29f4e89a8a0e8100a4fdb04b92335b732e919b24d.
+//
+// Solidity: event PublicKeySubmission()
+func (_ZKDKGContract *ZKDKGContractFilterer) WatchPublicKeySubmission(opts *bind.WatchOpts, sink chan<- *ZKDKGContractPublicKeySubmission) (event.Subscription, error) {
+
+	logs, sub, err := _ZKDKGContract.contract.WatchLogs(opts, "PublicKeySubmission")
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(ZKDKGContractPublicKeySubmission)
+				if err := _ZKDKGContract.contract.UnpackLog(event, "PublicKeySubmission", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParsePublicKeySubmission is a log parse operation binding the contract event 0x826bbd625910e29607d941b29f4e89a8a0e8100a4fdb04b92335b732e919b24d.
+//
+// Solidity: event PublicKeySubmission()
+func (_ZKDKGContract *ZKDKGContractFilterer) ParsePublicKeySubmission(log types.Log) (*ZKDKGContractPublicKeySubmission, error) {
+	event := new(ZKDKGContractPublicKeySubmission)
+	if err := _ZKDKGContract.contract.UnpackLog(event, "PublicKeySubmission", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// ZKDKGContractRegistrationIterator is returned from FilterRegistration and is used to iterate over the raw logs and unpacked data for Registration events raised by the ZKDKGContract contract.
+type ZKDKGContractRegistrationIterator struct {
+	Event *ZKDKGContractRegistration // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *ZKDKGContractRegistrationIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(ZKDKGContractRegistration)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(ZKDKGContractRegistration)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *ZKDKGContractRegistrationIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *ZKDKGContractRegistrationIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// ZKDKGContractRegistration represents a Registration event raised by the ZKDKGContract contract.
+type ZKDKGContractRegistration struct {
+	Sender          common.Address
+	Index           uint16
+	PossessionProof [2]*big.Int
+	Raw             types.Log // Blockchain specific contextual infos
+}
+
+// FilterRegistration is a free log retrieval operation binding the contract event 0xbb03d518556185c650abc5217698a926280e1aa57bde256a45a4d53cdaae0b36.
+//
+// Solidity: event Registration(address sender, uint16 index, uint256[2] possessionProof)
+func (_ZKDKGContract *ZKDKGContractFilterer) FilterRegistration(opts *bind.FilterOpts) (*ZKDKGContractRegistrationIterator, error) {
+
+	logs, sub, err := _ZKDKGContract.contract.FilterLogs(opts, "Registration")
+	if err != nil {
+		return nil, err
+	}
+	return &ZKDKGContractRegistrationIterator{contract: _ZKDKGContract.contract, event: "Registration", logs: logs, sub: sub}, nil
+}
+
+// WatchRegistration is a free log subscription operation binding the contract event 0xbb03d518556185c650abc5217698a926280e1aa57bde256a45a4d53cdaae0b36.
+//
+// Solidity: event Registration(address sender, uint16 index, uint256[2] possessionProof)
+func (_ZKDKGContract *ZKDKGContractFilterer) WatchRegistration(opts *bind.WatchOpts, sink chan<- *ZKDKGContractRegistration) (event.Subscription, error) {
+
+	logs, sub, err := _ZKDKGContract.contract.WatchLogs(opts, "Registration")
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(ZKDKGContractRegistration)
+				if err := _ZKDKGContract.contract.UnpackLog(event, "Registration", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseRegistration is a log parse operation binding the contract event 0xbb03d518556185c650abc5217698a926280e1aa57bde256a45a4d53cdaae0b36.
+//
+// Solidity: event Registration(address sender, uint16 index, uint256[2] possessionProof)
+func (_ZKDKGContract *ZKDKGContractFilterer) ParseRegistration(log types.Log) (*ZKDKGContractRegistration, error) {
+	event := new(ZKDKGContractRegistration)
+	if err := _ZKDKGContract.contract.UnpackLog(event, "Registration", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// ZKDKGContractRegistrationEndLogIterator is returned from FilterRegistrationEndLog and is used to iterate over the raw logs and unpacked data for RegistrationEndLog events raised by the ZKDKGContract contract.
+type ZKDKGContractRegistrationEndLogIterator struct {
+	Event *ZKDKGContractRegistrationEndLog // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *ZKDKGContractRegistrationEndLogIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(ZKDKGContractRegistrationEndLog)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(ZKDKGContractRegistrationEndLog)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *ZKDKGContractRegistrationEndLogIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *ZKDKGContractRegistrationEndLogIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// ZKDKGContractRegistrationEndLog represents a RegistrationEndLog event raised by the ZKDKGContract contract.
+type ZKDKGContractRegistrationEndLog struct {
+	Raw types.Log // Blockchain specific contextual infos
+}
+
+// FilterRegistrationEndLog is a free log retrieval operation binding the contract event 0x4bdb43f822bd6cc36c8e0ae7be9183af9b7abc30c6d42bb71e156fe987e2b858.
+//
+// Solidity: event RegistrationEndLog()
+func (_ZKDKGContract *ZKDKGContractFilterer) FilterRegistrationEndLog(opts *bind.FilterOpts) (*ZKDKGContractRegistrationEndLogIterator, error) {
+
+	logs, sub, err := _ZKDKGContract.contract.FilterLogs(opts, "RegistrationEndLog")
+	if err != nil {
+		return nil, err
+	}
+	return &ZKDKGContractRegistrationEndLogIterator{contract: _ZKDKGContract.contract, event: "RegistrationEndLog", logs: logs, sub: sub}, nil
+}
+
+// WatchRegistrationEndLog is a free log subscription operation binding the contract event 0x4bdb43f822bd6cc36c8e0ae7be9183af9b7abc30c6d42bb71e156fe987e2b858.
+//
+// Solidity: event RegistrationEndLog()
+func (_ZKDKGContract *ZKDKGContractFilterer) WatchRegistrationEndLog(opts *bind.WatchOpts, sink chan<- *ZKDKGContractRegistrationEndLog) (event.Subscription, error) {
+
+	logs, sub, err := _ZKDKGContract.contract.WatchLogs(opts, "RegistrationEndLog")
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(ZKDKGContractRegistrationEndLog)
+				if err := _ZKDKGContract.contract.UnpackLog(event, "RegistrationEndLog", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseRegistrationEndLog is a log parse operation binding the contract event 0x4bdb43f822bd6cc36c8e0ae7be9183af9b7abc30c6d42bb71e156fe987e2b858.
+//
+// Solidity: event RegistrationEndLog()
+func (_ZKDKGContract *ZKDKGContractFilterer) ParseRegistrationEndLog(log types.Log) (*ZKDKGContractRegistrationEndLog, error) {
+	event := new(ZKDKGContractRegistrationEndLog)
+	if err := _ZKDKGContract.contract.UnpackLog(event, "RegistrationEndLog", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// ZKDKGContractResetIterator is returned from FilterReset and is used to iterate over the raw logs and unpacked data for Reset events raised by the ZKDKGContract contract.
+type ZKDKGContractResetIterator struct {
+	Event *ZKDKGContractReset // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *ZKDKGContractResetIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(ZKDKGContractReset)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(ZKDKGContractReset)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *ZKDKGContractResetIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *ZKDKGContractResetIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// ZKDKGContractReset represents a Reset event raised by the ZKDKGContract contract.
+type ZKDKGContractReset struct {
+	Raw types.Log // Blockchain specific contextual infos
+}
+
+// FilterReset is a free log retrieval operation binding the contract event 0x6423db340205c829eeb91151b1c5d1dc6d7a2b8708b1621494e89ba90c87081e.
+//
+// Solidity: event Reset()
+func (_ZKDKGContract *ZKDKGContractFilterer) FilterReset(opts *bind.FilterOpts) (*ZKDKGContractResetIterator, error) {
+
+	logs, sub, err := _ZKDKGContract.contract.FilterLogs(opts, "Reset")
+	if err != nil {
+		return nil, err
+	}
+	return &ZKDKGContractResetIterator{contract: _ZKDKGContract.contract, event: "Reset", logs: logs, sub: sub}, nil
+}
+
+// WatchReset is a free log subscription operation binding the contract event 0x6423db340205c829eeb91151b1c5d1dc6d7a2b8708b1621494e89ba90c87081e.
+//
+// Solidity: event Reset()
+func (_ZKDKGContract *ZKDKGContractFilterer) WatchReset(opts *bind.WatchOpts, sink chan<- *ZKDKGContractReset) (event.Subscription, error) {
+
+	logs, sub, err := _ZKDKGContract.contract.WatchLogs(opts, "Reset")
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(ZKDKGContractReset)
+				if err := _ZKDKGContract.contract.UnpackLog(event, "Reset", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseReset is a log parse operation binding the contract event 0x6423db340205c829eeb91151b1c5d1dc6d7a2b8708b1621494e89ba90c87081e.
+//
+// Solidity: event Reset()
+func (_ZKDKGContract *ZKDKGContractFilterer) ParseReset(log types.Log) (*ZKDKGContractReset, error) {
+	event := new(ZKDKGContractReset)
+	if err := _ZKDKGContract.contract.UnpackLog(event, "Reset", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// ZKDKGContractSlashingIterator is returned from FilterSlashing and is used to iterate over the raw logs and unpacked data for Slashing events raised by the ZKDKGContract contract.
+type ZKDKGContractSlashingIterator struct {
+	Event *ZKDKGContractSlashing // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *ZKDKGContractSlashingIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(ZKDKGContractSlashing)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(ZKDKGContractSlashing)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *ZKDKGContractSlashingIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *ZKDKGContractSlashingIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// ZKDKGContractSlashing represents a Slashing event raised by the ZKDKGContract contract.
+type ZKDKGContractSlashing struct {
+	Index  uint16
+	Amount *big.Int
+	Raw    types.Log // Blockchain specific contextual infos
+}
+
+// FilterSlashing is a free log retrieval operation binding the contract event 0xaecf8029465bb75f7d7bacba2a68134ab1e28ac7afb23d738481e33bef108ee6.
+//
+// Solidity: event Slashing(uint16 index, uint256 amount)
+func (_ZKDKGContract *ZKDKGContractFilterer) FilterSlashing(opts *bind.FilterOpts) (*ZKDKGContractSlashingIterator, error) {
+
+	logs, sub, err := _ZKDKGContract.contract.FilterLogs(opts, "Slashing")
+	if err != nil {
+		return nil, err
+	}
+	return &ZKDKGContractSlashingIterator{contract: _ZKDKGContract.contract, event: "Slashing", logs: logs, sub: sub}, nil
+}
+
+// WatchSlashing is a free log subscription operation binding the contract event 0xaecf8029465bb75f7d7bacba2a68134ab1e28ac7afb23d738481e33bef108ee6.
+//
+// Solidity: event Slashing(uint16 index, uint256 amount)
+func (_ZKDKGContract *ZKDKGContractFilterer) WatchSlashing(opts *bind.WatchOpts, sink chan<- *ZKDKGContractSlashing) (event.Subscription, error) {
+
+	logs, sub, err := _ZKDKGContract.contract.WatchLogs(opts, "Slashing")
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(ZKDKGContractSlashing)
+				if err := _ZKDKGContract.contract.UnpackLog(event, "Slashing", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseSlashing is a log parse operation binding the contract event 0xaecf8029465bb75f7d7bacba2a68134ab1e28ac7afb23d738481e33bef108ee6.
+//
+// Solidity: event Slashing(uint16 index, uint256 amount)
+func (_ZKDKGContract *ZKDKGContractFilterer) ParseSlashing(log types.Log) (*ZKDKGContractSlashing, error) {
+	event := new(ZKDKGContractSlashing)
+	if err := _ZKDKGContract.contract.UnpackLog(event, "Slashing", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
@@ -0,0 +1,166 @@
+package dkg
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// Deployment describes the contracts of one protocol run, as written by zkdkg deploy.
+// A config refers to it with its Deployment field instead of a ContractAddress.
+type Deployment struct {
+	ChainID       *big.Int         `json:"chainId,omitempty"`
+	Block         uint64           `json:"block"` // Block the DKG contract was deployed in, from which to filter its events
+	ZKDKG         common.Address   `json:"zkdkg"`
+	ShareVerifier common.Address   `json:"shareVerifier"`
+	KeyVerifier   common.Address   `json:"keyVerifier"`
+	Params        DeploymentParams `json:"params"`
+}
+
+// DeploymentParams are the constructor arguments of the DKG contract.
+type DeploymentParams struct {
+	NoParticipants uint16   `json:"noParticipants"`
+	UserThreshold  uint16   `json:"userThreshold"`
+	PeriodLength   uint16   `json:"periodLength"`    // 0 for evaluation mode
+	Stake          *big.Int `json:"stake,omitempty"` // Wei deposited by each participant at registration, none if nil
+}
+
+// DefaultUserThreshold returns the user threshold the Hardhat deploy task used, two thirds of the participants.
+func DefaultUserThreshold(noParticipants uint16) uint16 {
+	return uint16(2 * (uint32(noParticipants) + 1) / 3)
+}
+
+// Validate applies the checks of the constructor of the contract.
+func (p DeploymentParams) Validate() error {
+	if p.NoParticipants == 0 {
+		return errors.New("no participants")
+	}
+	if min := MinimumThreshold(p.NoParticipants); p.UserThreshold < min || p.UserThreshold > p.NoParticipants {
+		return fmt.Errorf("user threshold %d has to be between the minimum threshold %d and the number of participants %d", p.UserThreshold, min, p.NoParticipants)
+	}
+	return nil
+}
+
+// DeployBackend is the part of a client required to deploy contracts and wait for their receipts.
+type DeployBackend interface {
+	bind.ContractBackend
+	bind.DeployBackend
+}
+
+// ReadBytecode reads the creation bytecode of a contract compiled by solc --bin into dir, e.g. build/<participants>/contracts.
+func ReadBytecode(dir, contract string) ([]byte, error) {
+	b, err := os.ReadFile(filepath.Join(dir, contract+".bin"))
+	if err != nil {
+		return nil, fmt.Errorf("read bytecode of %s: %w", contract, err)
+	}
+	code, err := hexutil.Decode("0x" + strings.TrimPrefix(strings.TrimSpace(string(b)), "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("decode bytecode of %s: %w", contract, err)
+	}
+	if len(code) == 0 {
+		return nil, fmt.Errorf("bytecode of %s is empty", contract)
+	}
+	return code, nil
+}
+
+// Deploy deploys the verifier contracts and then the DKG contract with the compiled bytecode in dir.
+// It waits for each contract to be mined, so the returned deployment can be used right away.
+// The chain ID of the deployment is left to the caller, who signs for a chain with opts.
+func Deploy(ctx context.Context, backend DeployBackend, opts *bind.TransactOpts, dir string, params DeploymentParams) (*Deployment, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	contractAbi, err := abi.JSON(strings.NewReader(ZKDKGContractMetaData.ABI))
+	if err != nil {
+		return nil, fmt.Errorf("read abi: %w", err)
+	}
+
+	stake := params.Stake
+	if stake == nil {
+		stake = new(big.Int)
+	}
+
+	d := &Deployment{Params: params}
+	if d.KeyVerifier, _, err = deployContract(ctx, backend, opts, dir, "KeyVerifier", abi.ABI{}); err != nil {
+		return nil, err
+	}
+	if d.ShareVerifier, _, err = deployContract(ctx, backend, opts, dir, "ShareVerifier", abi.ABI{}); err != nil {
+		return nil, err
+	}
+
+	var receipt *types.Receipt
+	d.ZKDKG, receipt, err = deployContract(
+		ctx, backend, opts, dir, "ZKDKG", contractAbi,
+		d.ShareVerifier, d.KeyVerifier, params.NoParticipants, params.UserThreshold, params.PeriodLength, stake,
+	)
+	if err != nil {
+		return nil, err
+	}
+	d.Block = receipt.BlockNumber.Uint64()
+
+	return d, nil
+}
+
+func deployContract(ctx context.Context, backend DeployBackend, opts *bind.TransactOpts, dir, name string, contractAbi abi.ABI, args ...interface{}) (common.Address, *types.Receipt, error) {
+	code, err := ReadBytecode(dir, name)
+	if err != nil {
+		return common.Address{}, nil, err
+	}
+
+	o := *opts
+	o.Context = ctx
+	_, tx, _, err := bind.DeployContract(&o, contractAbi, code, backend, args...)
+	if err != nil {
+		return common.Address{}, nil, fmt.Errorf("deploy %s: %w", name, err)
+	}
+
+	receipt, err := bind.WaitMined(ctx, backend, tx)
+	if err != nil {
+		return common.Address{}, nil, fmt.Errorf("wait for deployment of %s: %w", name, err)
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return common.Address{}, nil, fmt.Errorf("deployment of %s reverted in transaction %s", name, tx.Hash())
+	}
+	return receipt.ContractAddress, receipt, nil
+}
+
+// ReadDeployment reads a deployment manifest.
+func ReadDeployment(name string) (*Deployment, error) {
+	b, err := os.ReadFile(name)
+	if err != nil {
+		return nil, fmt.Errorf("read deployment: %w", err)
+	}
+
+	var d Deployment
+	if err := json.Unmarshal(b, &d); err != nil {
+		return nil, fmt.Errorf("decode deployment: %w", err)
+	}
+	if d.ZKDKG == (common.Address{}) {
+		return nil, fmt.Errorf("deployment %s lacks the zkDKG contract address", name)
+	}
+	return &d, nil
+}
+
+// Write writes the deployment manifest to the file with the given name.
+func (d *Deployment) Write(name string) error {
+	b, err := json.MarshalIndent(d, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode deployment: %w", err)
+	}
+	if err := os.WriteFile(name, append(b, '\n'), 0644); err != nil {
+		return fmt.Errorf("write deployment: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+package dkg
+
+import (
+	"context"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind/backends"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+// emptyContract is the creation bytecode of a contract without code, it ignores any constructor arguments.
+const emptyContract = "600080f3"
+
+func writeBytecode(t *testing.T, dir string, contracts map[string]string) {
+	for name, code := range contracts {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name+".bin"), []byte(code+"\n"), 0644))
+	}
+}
+
+// newSimulatedBackend returns a simulated chain that mines blocks in the background and a funded transactor.
+func newSimulatedBackend(t *testing.T) (*backends.SimulatedBackend, *bind.TransactOpts) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	opts, err := bind.NewKeyedTransactorWithChainID(key, big.NewInt(1337))
+	require.NoError(t, err)
+
+	sim := backends.NewSimulatedBackend(core.GenesisAlloc{
+		opts.From: {Balance: new(big.Int).Lsh(big.NewInt(1), 100)},
+	}, 30_000_000)
+
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(10 * time.Millisecond)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				sim.Commit()
+			}
+		}
+	}()
+	t.Cleanup(func() {
+		close(done)
+		sim.Close()
+	})
+
+	return sim, opts
+}
+
+func TestDeploy(t *testing.T) {
+	dir := t.TempDir()
+	sim, opts := newSimulatedBackend(t)
+	params := DeploymentParams{NoParticipants: 5, UserThreshold: DefaultUserThreshold(5), Stake: big.NewInt(1e18)}
+
+	_, err := Deploy(context.Background(), sim, opts, dir, params)
+	require.Error(t, err, "missing bytecode")
+
+	writeBytecode(t, dir, map[string]string{"KeyVerifier": emptyContract, "ShareVerifier": emptyContract, "ZKDKG": emptyContract})
+
+	_, err = Deploy(context.Background(), sim, opts, dir, DeploymentParams{NoParticipants: 5, UserThreshold: 2})
+	require.Error(t, err, "user threshold below the minimum threshold")
+
+	d, err := Deploy(context.Background(), sim, opts, dir, params)
+	require.NoError(t, err)
+	require.Equal(t, params, d.Params)
+	require.NotZero(t, d.Block)
+	require.NotEqual(t, d.KeyVerifier, d.ShareVerifier)
+	require.NotEqual(t, d.ShareVerifier, d.ZKDKG)
+
+	name := filepath.Join(dir, "deployment.json")
+	require.NoError(t, d.Write(name))
+	read, err := ReadDeployment(name)
+	require.NoError(t, err)
+	require.Equal(t, d, read)
+
+	config := filepath.Join(dir, "config.json")
+	require.NoError(t, os.WriteFile(config, []byte(`{"EthereumNode": "ws://127.0.0.1:8545", "Deployment": "`+name+`"}`), 0644))
+	c, err := LoadConfig(config)
+	require.NoError(t, err)
+	require.Equal(t, d.ZKDKG.Hex(), c.ContractAddress)
+	require.Equal(t, d.Block, c.DeploymentBlock)
+
+	// A constructor that reverts
+	writeBytecode(t, dir, map[string]string{"ZKDKG": "60006000fd"})
+	_, err = Deploy(context.Background(), sim, opts, dir, params)
+	require.Error(t, err)
+}
+
+func TestDefaultUserThreshold(t *testing.T) {
+	for n := uint16(1); n < 300; n++ {
+		require.NoError(t, DeploymentParams{NoParticipants: n, UserThreshold: DefaultUserThreshold(n)}.Validate(), "%d participants", n)
+	}
+	require.Equal(t, uint16(4), DefaultUserThreshold(5))
+}
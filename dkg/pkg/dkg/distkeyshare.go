@@ -1,6 +1,11 @@
 package dkg
 
 import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+
 	"go.dedis.ch/kyber/v3"
 	"go.dedis.ch/kyber/v3/share"
 )
@@ -22,3 +27,74 @@ func (d *DistKeyShare) PriShare() *share.PriShare {
 func (d *DistKeyShare) Commitments() []kyber.Point {
 	return d.Commits
 }
+
+// distKeyShareFile is the hex encoded share file, which leaves out the private polynomial of the node as a dealer.
+type distKeyShareFile struct {
+	Index       uint16   `json:"index"`
+	Share       string   `json:"share"`
+	Commitments []string `json:"commitments"`
+	PublicKey   string   `json:"publicKey"`
+}
+
+// Write writes the share to a file only the owner can read.
+func (d *DistKeyShare) Write(name string) error {
+	f := distKeyShareFile{Index: uint16(d.Share.I + 1)}
+
+	b, err := d.Share.V.MarshalBinary()
+	if err != nil {
+		return fmt.Errorf("marshal share: %w", err)
+	}
+	f.Share = hex.EncodeToString(b)
+
+	for _, commit := range d.Commits {
+		b, err := commit.MarshalBinary()
+		if err != nil {
+			return fmt.Errorf("marshal commitment: %w", err)
+		}
+		f.Commitments = append(f.Commitments, hex.EncodeToString(b))
+	}
+	f.PublicKey = f.Commitments[0]
+
+	b, err = json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode share: %w", err)
+	}
+	if err := os.WriteFile(name, append(b, '\n'), 0600); err != nil {
+		return fmt.Errorf("write share: %w", err)
+	}
+	return nil
+}
+
+// ReadDistKeyShare reads a share written by Write.
+func ReadDistKeyShare(suite kyber.Group, name string) (*DistKeyShare, error) {
+	b, err := os.ReadFile(name)
+	if err != nil {
+		return nil, fmt.Errorf("read share: %w", err)
+	}
+	var f distKeyShareFile
+	if err := json.Unmarshal(b, &f); err != nil {
+		return nil, fmt.Errorf("decode share: %w", err)
+	}
+
+	v := suite.Scalar()
+	if err := unmarshalHex(v, f.Share); err != nil {
+		return nil, fmt.Errorf("share: %w", err)
+	}
+	d := &DistKeyShare{Share: &share.PriShare{I: int(f.Index) - 1, V: v}}
+	for i, c := range f.Commitments {
+		commit := suite.Point()
+		if err := unmarshalHex(commit, c); err != nil {
+			return nil, fmt.Errorf("commitment %d: %w", i, err)
+		}
+		d.Commits = append(d.Commits, commit)
+	}
+	return d, nil
+}
+
+func unmarshalHex(v interface{ UnmarshalBinary([]byte) error }, s string) error {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return err
+	}
+	return v.UnmarshalBinary(b)
+}
@@ -1,9 +1,11 @@
 package dkg
 
 import (
+	"bytes"
 	"client/internal/pkg/group/curve25519"
+	"client/pkg/artifacts"
+	"client/pkg/zk"
 	"context"
-	"crypto/ecdsa"
 	"errors"
 	"fmt"
 	"math/big"
@@ -11,21 +13,15 @@ import (
 	"strings"
 	"time"
 
-	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
-	"github.com/ethereum/go-ethereum/ethclient"
-	"github.com/ethereum/go-ethereum/event"
-	"github.com/iden3/go-iden3-crypto/poseidon"
 	log "github.com/sirupsen/logrus"
 	"go.dedis.ch/kyber/v3"
 	"go.dedis.ch/kyber/v3/group/mod"
 	"go.dedis.ch/kyber/v3/share"
 	"go.dedis.ch/kyber/v3/suites"
-	"golang.org/x/sync/errgroup"
 )
 
 type Participant struct {
@@ -33,46 +29,53 @@ type Participant struct {
 	pub   kyber.Point
 }
 
+// DistKeyGenerator takes part in the protocol on behalf of a single participant.
+// All of its state is owned by the event loop of Generate, see loop.go.
 type DistKeyGenerator struct {
-	ctx					context.Context
-	suite               suites.Suite
-	polyProver          *Prover
-	curveParams         *curve25519.Param
-	client              *ethclient.Client
-	chainID             *big.Int
-	contract            *ZKDKGContract
-	contractAbi			abi.ABI
-	contractAddress		common.Address
-	ethereumAddress  	common.Address
-	ethereumPrivateKey	*ecdsa.PrivateKey
-	long                kyber.Scalar
-	periodChange		chan struct{}
-	pub                 kyber.Point
-	participants        map[uint16]*Participant
-	index               uint16
-	priPoly             *share.PriPoly
-	shares              map[uint16]kyber.Scalar
-	commitments         map[uint16][]kyber.Point
-	disputeValid		bool
-	broadcastOnly		bool
+	ctx             context.Context
+	suite           suites.Suite
+	polyProver      prover
+	curveParams     *curve25519.Param
+	params          *Params
+	chain           chain
+	ethereumAddress common.Address
+	contractAddress common.Address // Address of the contract, to which the proofs of possession are bound
+	long            kyber.Scalar
+	pub             kyber.Point
+	participants    map[uint16]*Participant
+	index           uint16
+	priPoly         *share.PriPoly
+	shares          map[uint16]kyber.Scalar
+	commitments     map[uint16][]kyber.Point
+	encryptedShares map[uint16][]*big.Int
+	batch           *BatchVerifier
+	disputeValid    bool
+	broadcastOnly   bool
+	compressedKey   bool
+	proverRetry     RetryPolicy
+	// Delay between the turns of the participants to submit the public key
+	submissionBackoff time.Duration
+
+	// Outcome of the run, see Result
+	distKeyShare *DistKeyShare
+	disputes     []*DisputeResult
+	excluded     map[uint16]string
+	slashed      map[uint16]*big.Int
+	released     bool     // The contract settled the protocol, releasing the stakes
+	settled      bool     // The node withdrew its payout once released
+	payout       *big.Int // Withdrawn by the node once settled
+
+	loop loopState
 }
 
-var errAbortion error = errors.New("protocol aborted due to insufficient remaining participants")
-const bufferTimeInSecs uint64 = 2
-
 func NewDistributedKeyGenerator(config *Config, idPipe string, disputeValid, broadcastOnly bool) (*DistKeyGenerator, error) {
+	suite := curve25519.NewBlakeSHA256BabyJubJub(false)
 
-	param := ParamBabyJubJub()
-	curve := &curve25519.ProjectiveCurve{}
-	curve.Init(param, false)
-	suite := &curve25519.SuiteCurve25519{ProjectiveCurve: *curve}
-
-	client, err := ethclient.Dial(config.EthereumNode)
+	ctx := context.Background()
+	client, err := DialEndpoints(ctx, config.Endpoints())
 	if err != nil {
 		return nil, fmt.Errorf("dial eth client: %w", err)
 	}
-
-	ctx := context.Background()
 	chainID, err := client.ChainID(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("chainID: %w", err)
@@ -95,7 +98,7 @@ func NewDistributedKeyGenerator(config *Config, idPipe string, disputeValid, bro
 		return nil, fmt.Errorf("hex to ecdsa: %w", err)
 	}
 
-	ethereumPublicKey := crypto.PubkeyToAddress(ethereumPrivateKey.PublicKey)
+	ethereumAddress := crypto.PubkeyToAddress(ethereumPrivateKey.PublicKey)
 
 	long, err := HexToScalar(suite, config.DkgPrivateKey)
 	if err != nil {
@@ -109,197 +112,90 @@ func NewDistributedKeyGenerator(config *Config, idPipe string, disputeValid, bro
 		}
 	}
 
-	polyProver, err := NewProver(config.MountSource, pipe)
+	params, err := ReadParams(&bind.CallOpts{Context: ctx}, &contract.ZKDKGContractCaller)
 	if err != nil {
-		return nil, fmt.Errorf("prover: %w", err)
-	}
-
-	return &DistKeyGenerator{
-		ctx: 				 ctx,
-		suite:               suite,
-		polyProver:          polyProver,
-		curveParams:         param,
-		client:              client,
-		chainID:             chainID,
-		contract:            contract,
-		contractAbi: 		 contractAbi,
-		contractAddress: 	 contractAddress,
-		ethereumAddress:     ethereumPublicKey,
-		ethereumPrivateKey:  ethereumPrivateKey,
-		long:                long,
-		periodChange: 		 make(chan struct{}),
-		pub:                 suite.Point().Mul(long, nil),
-		participants:        make(map[uint16]*Participant),
-		shares:              make(map[uint16]kyber.Scalar),
-		commitments:         make(map[uint16][]kyber.Point),
-		disputeValid:  		 disputeValid,
-		broadcastOnly:		 broadcastOnly,
-	}, nil
-
-}
-
-func (d *DistKeyGenerator) Generate() (kyber.Point, error) {
-	log.Info("Generating distributed private key...")
-
-	distributionEnd := make(chan struct{})
-	broadcastsCollected := make(chan struct{})
-	g, ctx := errgroup.WithContext(d.ctx)
-	d.ctx = ctx
-
-	if !d.broadcastOnly {
-		g.Go(func() error {
-			if err := d.WatchBroadcastSharesLog(ctx, distributionEnd, broadcastsCollected); err != nil {
-				return fmt.Errorf("watching broadcast shares log failed: %w", err)
-			}
-			return nil
-		})
-
-		g.Go(func() error {
-			if err := d.WatchDistributionEndLog(ctx); err != nil {
-				return fmt.Errorf("watching distribution end log failed: %w", err)
-			}
-			close(distributionEnd)
-			return nil
-		})
-
-		g.Go(func() error {
-			if err := d.WatchDisputeShareLog(ctx); err != nil {
-				return fmt.Errorf("watching dispute share log failed: %w", err)
-			}
-			return nil
-		})
-
-		g.Go(func() error {
-			if err := d.WatchExclusion(ctx); err != nil {
-				return fmt.Errorf("watching exclusion failed: %w", err)
-			}
-			return nil
-		})
-
-		g.Go(func() error {
-			if err := d.WatchAbortion(ctx); err != nil {
-				if errors.Is(err, errAbortion) {
-					return errAbortion
-				}
-				return fmt.Errorf("watching abortion failed: %w", err)
-			}
-			return nil
-		})
+		return nil, fmt.Errorf("read params: %w", err)
 	}
+	log.Infof("Protocol parameters: %s", params)
 
-	if err := d.RegisterAndWait(ctx); err != nil {
-		return nil, fmt.Errorf("register and wait: %w", err)
+	if err := prepareArtifacts(ctx, client, contract, params, artifacts.Dir(config.MountSource), config.ArtifactMirror, config.SkipArtifactChecks); err != nil {
+		return nil, fmt.Errorf("artifacts: %w", err)
 	}
 
-	if err := d.CollectParticipants(); err != nil {
-		return nil, fmt.Errorf("collect participants: %w", err)
-	}
-
-	if err := d.DistributeShares(); err != nil {
-		return nil, fmt.Errorf("distribute shares: %w", err)
-	}
-
-	if d.broadcastOnly {
-		return nil, nil
-	}
-
-	select {
-	case <-distributionEnd:
-		// Do nothing
-	case <-ctx.Done():
-		// The context is cancelled when an unexpected error has occurred in one of the goroutines
-		return nil, g.Wait()
+	polyProver, err := NewProver(config.MountSource, pipe)
+	if err != nil {
+		return nil, fmt.Errorf("prover: %w", err)
 	}
 
-	disputeEnd := d.DisputeSharePeriodEnd()
-
-	<-broadcastsCollected
-
-	select {
-	case <-disputeEnd:
-		// Do nothing
-	case <-ctx.Done():
-		// The context is cancelled when an unexpected error has occurred in one of the goroutines
-		return nil, g.Wait()
+	if err := params.CheckCircuits(polyProver); err != nil {
+		return nil, fmt.Errorf("artifacts in %s don't match the contract: %w", config.MountSource, err)
 	}
 
-	if err := d.checkExpiredDisputes(); err != nil {
-		return nil, fmt.Errorf("check expired disputes: %w", err)
+	chain := &contractChain{
+		client:      client,
+		chainID:     chainID,
+		contract:    contract,
+		bound:       bind.NewBoundContract(contractAddress, contractAbi, client, client, client),
+		contractAbi: contractAbi,
+		address:     contractAddress,
+		fromBlock:   config.DeploymentBlock,
+		key:         ethereumPrivateKey,
+		from:        ethereumAddress,
+		policy:      config.Retry.orDefault(DefaultRetryPolicy),
 	}
 
-	pub, err := d.ComputePublicKey()
-	if err != nil {
-		return nil, fmt.Errorf("compute public key: %w", err)
+	d := newDistKeyGenerator(ctx, suite, params, &retryChain{chain, chain.policy}, polyProver, ethereumAddress, long)
+	d.contractAddress = contractAddress
+	d.proverRetry = config.ProverRetry.orDefault(DefaultProverRetryPolicy)
+	if config.SubmissionBackoff > 0 {
+		d.submissionBackoff = config.SubmissionBackoff
 	}
+	d.disputeValid = disputeValid
+	d.broadcastOnly = broadcastOnly
+	d.compressedKey = config.CompressedRegistration
+	return d, nil
+}
 
-	pkLog := make(chan struct{})
-	g.Go(func() error {
-		if err := d.WatchPublicKeySubmissionLog(ctx, pub); err != nil {
-			return fmt.Errorf("watching public key submission log failed: %w", err)
-		}
-		close(pkLog)
-		return nil
-	})
-
-	if err := d.SubmitPublicKey(pub); err != nil {
-		if errors.Is(err, errAbortion) {
-			return nil, err
-		}
-
-		if ctx.Err() != nil {
-			return nil, g.Wait()
-		}
-
-		log.Warnf("Public key submission failed, waiting for other participant's submission: %v", err)
-
-		select {
-		case <-pkLog:
-			// Do nothing
-		case <-ctx.Done():
-			// The context is cancelled when an unexpected error has occurred in one of the goroutines
-			return nil, g.Wait()
-		}
+func newDistKeyGenerator(ctx context.Context, suite suites.Suite, params *Params, chain chain, prover prover, address common.Address, long kyber.Scalar) *DistKeyGenerator {
+	return &DistKeyGenerator{
+		ctx:               ctx,
+		suite:             suite,
+		polyProver:        prover,
+		curveParams:       curve25519.ParamBabyJubJub(),
+		params:            params,
+		chain:             chain,
+		ethereumAddress:   address,
+		long:              long,
+		pub:               suite.Point().Mul(long, nil),
+		participants:      make(map[uint16]*Participant),
+		shares:            make(map[uint16]kyber.Scalar),
+		commitments:       make(map[uint16][]kyber.Point),
+		encryptedShares:   make(map[uint16][]*big.Int),
+		proverRetry:       DefaultProverRetryPolicy,
+		submissionBackoff: DefaultSubmissionBackoff,
+		excluded:          make(map[uint16]string),
+		slashed:           make(map[uint16]*big.Int),
 	}
-
-	return pub, nil
 }
 
 func (d *DistKeyGenerator) Register(ctx context.Context) error {
-	opts, err := bind.NewKeyedTransactorWithChainID(d.ethereumPrivateKey, d.chainID)
-	if err != nil {
-		return fmt.Errorf("keyed transactor with chainID: %w", err)
-	}
-	opts.GasPrice = big.NewInt(1000000000)
-
-	pub := PointToBigUncompressed(d.pub)
-
-	estimate, err := d.estimateGas(ctx, "register", pub)
+	pub, err := PointToBigUncompressed(d.pub)
 	if err != nil {
-		return fmt.Errorf("estimate gas: %w", err)
+		return fmt.Errorf("public key coordinates: %w", err)
 	}
 
-	opts.GasLimit = estimate + 30000
-
-	tx, err := d.contract.Register(opts, pub)
+	proof, err := ProvePossession(d.suite, d.long, d.ethereumAddress, d.contractAddress)
 	if err != nil {
-		return fmt.Errorf("register: %w", err)
+		return fmt.Errorf("prove possession: %w", err)
 	}
 
-	receipt, err := bind.WaitMined(ctx, d.client, tx)
+	index, err := d.chain.Register(ctx, pub, proof, d.compressedKey, d.params.Stake)
 	if err != nil {
-		return fmt.Errorf("wait mined register: %w", err)
-	}
-
-	if receipt.Status == types.ReceiptStatusFailed {
-		return errors.New("receipt status failed")
-	}
-
-	index, err := d.contract.Participants(nil, d.ethereumAddress)
-	if err != nil {
-		return fmt.Errorf("participants: %w", err)
+		return err
 	}
 
 	d.index = index
+	d.batch = NewBatchVerifier(d.suite, index)
 
 	log.Infof("Registered as participant with index %d", d.index)
 	return nil
@@ -309,119 +205,31 @@ func (d *DistKeyGenerator) CollectParticipants() error {
 
 	log.Info("Collecting participants...")
 
-	// TODO Pass context to this and similar calls
-	pks, err := d.contract.PublicKeys(nil)
+	pks, err := d.chain.PublicKeys(d.ctx)
 	if err != nil {
 		return fmt.Errorf("collect public keys: %w", err)
 	}
 
+	// Check all keys before failing so that every invalid one is reported
+	var invalid []uint16
 	for i := uint16(1); i <= uint16(len(pks)); i++ {
 		pk := pks[i-1]
-		pub := d.suite.Point().(*curve25519.ProjPoint)
-		pub.X.V = *pk[0]
-		pub.Y.V = *pk[1]
+		pub, err := zk.PointFromCoordinates(d.suite, pk[0], pk[1])
+		if err != nil {
+			log.Warnf("Public key of participant %d is invalid: %v", i, err)
+			invalid = append(invalid, i)
+			continue
+		}
 
 		d.participants[i] = &Participant{index: i, pub: pub}
 	}
 
-	return nil
-
-}
-
-func WatchEvent[K any](
-	ctx context.Context,
-	subscribeLog func(*bind.WatchOpts, chan<- K) (event.Subscription, error),
-	afterSubscribe func() error,
-	handleEvent func(K) error,
-	once bool,
-) error {
-	events := make(chan K)
-	defer close(events)
-
-	sub, err := subscribeLog(
-		&bind.WatchOpts{
-			Context: ctx,
-		},
-		events,
-	)
-	if err != nil {
-		return fmt.Errorf("subscribe log: %w", err)
-	}
-	defer sub.Unsubscribe()
-
-	if afterSubscribe != nil {
-		if err := afterSubscribe(); err != nil {
-			return fmt.Errorf("after subscribe: %w", err)
-		}
-	}
-
-	for {
-		select {
-		case event := <-events:
-			if handleEvent != nil {
-				if err := handleEvent(event); err != nil {
-					return fmt.Errorf("handle event: %w", err)
-				}
-			}
-		case err := <-sub.Err():
-			return fmt.Errorf("subscription: %w", err)
-		case <-ctx.Done():
-			return fmt.Errorf("context: %w", ctx.Err())
-		}
-
-		if once {
-			return nil
-		}
+	if len(invalid) > 0 {
+		return fmt.Errorf("invalid public keys of participants %v", invalid)
 	}
-}
-
-func (d *DistKeyGenerator) RegisterAndWait(ctx context.Context) error {
-	return WatchEvent(
-		ctx,
-		d.contract.WatchRegistrationEndLog,
-		func() error {
-			if err := d.Register(ctx); err != nil {
-				return fmt.Errorf("register: %w", err)
-			}
-
-			log.Info("Waiting until registration is finished...")
-			return nil
-		},
-		nil,
-		true,
-	)
-}
-
-func (d *DistKeyGenerator) DisputeSharePeriodEnd() <-chan struct{} {
-	end := make(chan struct{})
-
-	go func() {
-		timer := time.NewTimer(d.durationUntilPhaseEnd())
-
-		loop:
-		for {
-			select {
-			case <-d.periodChange:
-				timer.Reset(d.durationUntilPhaseEnd())
-			case <-timer.C:
-				break loop
-			}
-		}
-
-		close(end)
-	}()
 
-	return end
-}
+	return d.verifyPossession()
 
-func (d *DistKeyGenerator) durationUntilPhaseEnd() time.Duration {
-	if period, err := d.contract.PhaseEnd(nil); err != nil {
-		log.Warnf("Failed to retrieve current phase end, using fallback value: %v", err)
-		duration, _ := time.ParseDuration("5m")
-		return duration
-	} else {
-		return time.Until(time.Unix(int64(period + bufferTimeInSecs), 0))
-	}
 }
 
 func (d *DistKeyGenerator) ComputePublicKey() (kyber.Point, error) {
@@ -440,130 +248,87 @@ func (d *DistKeyGenerator) ComputePublicKey() (kyber.Point, error) {
 	if !test.V.Equal(fig) {
 		return nil, errors.New("overall share is invalid")
 	}
+	d.distKeyShare = distKeyShare
 
 	return distKeyShare.Public(), nil
 }
 
 func (d *DistKeyGenerator) checkExpiredDisputes() error {
-	indices, err := d.contract.ExpiredDisputes(nil)
+	indices, err := d.chain.ExpiredDisputes(d.ctx)
 	if err != nil {
 		return fmt.Errorf("contract call: %w", err)
 	}
 
 	for _, index := range indices {
-		d.HandleExclusion(index)
+		if err := d.HandleExclusion(index); err != nil {
+			return err
+		}
 	}
 
 	return nil
 }
 
 func (d *DistKeyGenerator) SubmitPublicKey(pub kyber.Point) error {
-	defer d.polyProver.Close()
-
-	args := make([]*big.Int, 0)
-
-	firstCoefficients := make([]byte, 0)
-	for i := uint16(1); i <= uint16(len(d.participants)); i++ {
-		firstCoefficient := d.commitments[i][0]
-
-		coeffProj, _ := firstCoefficient.(*curve25519.ProjPoint)
-		coeffX, coeffY := coeffProj.GetXY()
-
-		coeffBin, err := firstCoefficient.MarshalBinary()
-		if err != nil {
-			return fmt.Errorf("marshal coefficient: %w", err)
-		}
-		firstCoefficients = append(firstCoefficients, coeffBin...)
-
-		args = append(args, &coeffX.V, &coeffY.V)
+	input := &zk.KeyDerivInput{
+		FirstCoefficients: make([]kyber.Point, len(d.participants)),
 	}
-
-	hash := TruncateHash(crypto.Keccak256(firstCoefficients))
-
-	args = append(args, new(big.Int).SetBytes(hash))
-
-	// This is actually necessary instead of simply accessing via the X and Y properties due to the normalization that takes place in GetXY
-	pubX, pubY := pub.(*curve25519.ProjPoint).GetXY()
-	pubXY := [2]*big.Int{&pubX.V, &pubY.V}
-
-	log.Infof("Args: %d", args)
-
-	if err := d.polyProver.ComputeWitness(d.ctx, KeyDerivProof, args); err != nil {
-		return fmt.Errorf("compute witness for public key proof: %w", err)
+	for i := uint16(1); i <= uint16(len(d.participants)); i++ {
+		input.FirstCoefficients[i-1] = d.commitments[i][0]
 	}
 
-	proof, err := d.polyProver.GenerateProof(d.ctx, KeyDerivProof)
-	if err != nil {
-		return fmt.Errorf("generate proof for public key: %w", err)
+	if !input.PublicKey(d.suite).Equal(pub) {
+		return errors.New("first coefficients don't add up to the public key")
 	}
 
-	opts, err := bind.NewKeyedTransactorWithChainID(d.ethereumPrivateKey, d.chainID)
+	witness, err := input.Witness()
 	if err != nil {
-		return fmt.Errorf("keyed transactor with chainID: %w", err)
+		return fmt.Errorf("key deriv witness: %w", err)
 	}
-	opts.GasPrice = big.NewInt(1000000000)
 
-	submitTx, err := d.contract.SubmitPublicKey(opts, pubXY, KeyVerifierProof(*proof.Proof))
+	pubXY, err := input.Output(d.suite)
 	if err != nil {
-		return fmt.Errorf("submit public key: %w", err)
+		return fmt.Errorf("key deriv output: %w", err)
 	}
 
-	receipt, err := bind.WaitMined(d.ctx, d.client, submitTx)
+	proof, err := d.prove(KeyDerivProof, witness)
 	if err != nil {
-		return fmt.Errorf("wait mined submit: %w", err)
+		return err
 	}
 
-	for _, eventLog := range receipt.Logs {
-		if eventLog.Topics[0] == crypto.Keccak256Hash([]byte("Abortion()")) {
-			return errAbortion
-		}
-	}
-
-	if receipt.Status == types.ReceiptStatusFailed {
-		return errors.New("receipt status failed")
+	if err := d.chain.SubmitPublicKey(d.ctx, pubXY, KeyVerifierProof(*proof.Proof)); err != nil {
+		return err
 	}
 	log.Info("Submitted public key")
 
 	return nil
 }
 
-func (d *DistKeyGenerator) WatchBroadcastSharesLog(ctx context.Context, distributionEnd, broadcastsCollected chan struct{}) error {
-	return WatchEvent(
-		ctx,
-		d.contract.WatchBroadcastSharesLog,
-		nil,
-		func(event *ZKDKGContractBroadcastSharesLog) error {
-			return d.HandleBroadcastSharesLog(event, distributionEnd, broadcastsCollected)
-		},
-		false,
-	)
-}
-
-func (d *DistKeyGenerator) HandleBroadcastSharesLog(broadcastSharesLog *ZKDKGContractBroadcastSharesLog, distributionEnd, broadcastsCollected chan struct{}) error {
+func (d *DistKeyGenerator) HandleBroadcastSharesLog(broadcastSharesLog *ZKDKGContractBroadcastSharesLog) error {
 	if d.ethereumAddress == broadcastSharesLog.Sender {
 		// Ignore own broadcast
 		return nil
 	}
 
-	inputs, err := d.getTxInputs(broadcastSharesLog.Raw.TxHash)
+	dealerIndex := broadcastSharesLog.BroadcasterIndex
+	if _, ok := d.shares[dealerIndex]; ok {
+		// The dealer got excluded before its broadcast was received
+		return nil
+	}
+
+	commitments, shares, err := d.chain.BroadcastInputs(d.ctx, broadcastSharesLog.Raw.TxHash)
 	if err != nil {
 		return fmt.Errorf("get tx inputs: %w", err)
 	}
 
-	commitments := inputs[0].([]*big.Int)
-	shares := inputs[1].([]*big.Int)
-
-	dealerIndex := broadcastSharesLog.BroadcasterIndex
 	pubKeyDealer := d.participants[dealerIndex].pub
 
 	valid := true
-	var decryptedShare kyber.Scalar
 	var commits []kyber.Point
 
 	if d.disputeValid && dealerIndex == 1 {
 		valid = false
 		log.Info("Disputing broadcast of dealer 1 due to --dispute-valid flag")
-		d.scheduleDispute(dealerIndex, shares, distributionEnd)
+		d.scheduleDispute(dealerIndex, shares)
 	} else {
 		i := d.index
 		j := i
@@ -571,86 +336,82 @@ func (d *DistKeyGenerator) HandleBroadcastSharesLog(broadcastSharesLog *ZKDKGCon
 			j -= 1
 		}
 
-		fie := mod.NewInt(new(big.Int).SetBytes(shares[j - 1].Bytes()), &d.curveParams.P)
-		
+		fie := mod.NewInt(new(big.Int).SetBytes(shares[j-1].Bytes()), &d.curveParams.P)
+
 		commits, err = BigToPoints(d.suite, commitments)
 		if err != nil {
 			valid = false
 
 			log.Infof("Received invalid curve points from dealer %d", dealerIndex)
-			d.scheduleDispute(dealerIndex, shares, distributionEnd)
+			d.scheduleDispute(dealerIndex, shares)
 		} else {
 			sharedKey, err := d.PreSharedKey(d.long, pubKeyDealer, commits)
 			if err != nil {
 				return fmt.Errorf("pre shared key: %w", err)
 			}
 
-			fi := &share.PriShare{
-				I: int(i) - 1,
-				V: d.suite.Scalar().Sub(fie, sharedKey),
-			}
+			// The share is checked together with the other received shares once all broadcasts are collected
+			d.batch.Add(dealerIndex, d.suite.Scalar().Sub(fie, sharedKey), commits)
+			d.encryptedShares[dealerIndex] = shares
+
+			d.commitments[dealerIndex] = commits
+		}
+	}
 
-			pubPoly := share.NewPubPoly(d.suite, nil, commits)
+	if !valid {
+		d.invalidateBroadcast(dealerIndex, len(commitments))
+	}
 
-			if pubPoly.Check(fi) {
-				decryptedShare = fi.V
-			} else {
-				log.Infof("Received invalid share from dealer %d", dealerIndex)
-				valid = false
+	return d.checkCollected()
+}
 
-				d.scheduleDispute(dealerIndex, shares, distributionEnd)
-			}
-		}
+// verifyReceivedShares checks all shares that were queued for verification
+// and schedules disputes against the dealers of invalid shares.
+func (d *DistKeyGenerator) verifyReceivedShares() {
+	pending := make(map[uint16]kyber.Scalar, d.batch.Len())
+	for _, e := range d.batch.entries {
+		pending[e.dealer] = e.share
 	}
 
-	if valid {
-		log.Infof("Received valid broadcast from dealer %d", dealerIndex)
-	} else {
-		decryptedShare = d.suite.Scalar()
+	invalid := make(map[uint16]bool)
+	for _, dealerIndex := range d.batch.Verify() {
+		invalid[dealerIndex] = true
+	}
 
-		commits = make([]kyber.Point, len(commitments))
-		for i := range commits {
-			commits[i] = d.suite.Point()
+	for dealerIndex, decryptedShare := range pending {
+		if _, ok := d.shares[dealerIndex]; ok {
+			// The dealer got excluded in the meantime
+			continue
 		}
-	}
 
-	d.shares[dealerIndex] = decryptedShare
-	d.commitments[dealerIndex] = commits
+		if invalid[dealerIndex] {
+			log.Infof("Received invalid share from dealer %d", dealerIndex)
 
-	if len(d.shares) == len(d.participants) {
-		close(broadcastsCollected)
-	}
+			d.scheduleDispute(dealerIndex, d.encryptedShares[dealerIndex])
+			d.invalidateBroadcast(dealerIndex, len(d.commitments[dealerIndex]))
+			continue
+		}
 
-	return nil
+		log.Infof("Received valid broadcast from dealer %d", dealerIndex)
+		d.shares[dealerIndex] = decryptedShare
+	}
 }
 
-func (d *DistKeyGenerator) WatchDistributionEndLog(ctx context.Context) error {
-	return WatchEvent(
-		ctx,
-		d.contract.WatchDistributionEndLog,
-		nil,
-		nil,
-		true,
-	)
-}
+// invalidateBroadcast replaces the share and commitments of a dealer with neutral values,
+// so the dealer's broadcast doesn't contribute to the distributed key.
+func (d *DistKeyGenerator) invalidateBroadcast(dealerIndex uint16, noCommitments int) {
+	commits := make([]kyber.Point, noCommitments)
+	for i := range commits {
+		commits[i] = d.suite.Point().Null()
+	}
 
-func (d *DistKeyGenerator) WatchDisputeShareLog(ctx context.Context) error {
-	return WatchEvent(
-		ctx,
-		d.contract.WatchDisputeShare,
-		nil,
-		d.HandleDisputeShareLog,
-		false,
-	)
+	d.shares[dealerIndex] = d.suite.Scalar().Zero()
+	d.commitments[dealerIndex] = commits
 }
 
 func (d *DistKeyGenerator) HandleDisputeShareLog(disputeShareEvent *ZKDKGContractDisputeShare) error {
 	log.Infof("Received dispute for dealer %d", disputeShareEvent.DisputeeIndex)
-
-	select {
-	case d.periodChange <- struct{}{}:
-	default:
-	}
+	d.recordDispute(disputeShareEvent.DisputerIndex, disputeShareEvent.DisputeeIndex)
 
 	if d.index != disputeShareEvent.DisputeeIndex {
 		return nil
@@ -658,92 +419,50 @@ func (d *DistKeyGenerator) HandleDisputeShareLog(disputeShareEvent *ZKDKGContrac
 
 	log.Info("Received dispute against own broadcast, defending")
 
-	args := make([]*big.Int, 0)
-
-	for _, commitment := range d.commitments[d.index] {
-		c, _ := commitment.(*curve25519.ProjPoint)
-		x, y := c.GetXY()
-		args = append(args, &x.V, &y.V)
+	priShare, err := d.EncryptedPrivateShare(disputeShareEvent.DisputerIndex, d.commitments[d.index])
+	if err != nil {
+		return fmt.Errorf("encrypted private share: %w", err)
 	}
 
-	sk, _ := d.long.MarshalBinary()
-	args = append(args, new(big.Int).SetBytes(sk))
-
-	pubProofer := d.pub.(*curve25519.ProjPoint)
-	pubProoferX, pubProoferY := pubProofer.GetXY()
-	args = append(args, &pubProoferX.V, &pubProoferY.V)
-
-	pubDisputer := d.participants[disputeShareEvent.DisputerIndex].pub.(*curve25519.ProjPoint)
-	pubDisputerX, pubDisputerY := pubDisputer.GetXY()
-	args = append(args, &pubDisputerX.V, &pubDisputerY.V)
-
-	index := big.NewInt(int64(disputeShareEvent.DisputerIndex))
-	args = append(args, index)
-
-	priShare, _ := d.EncryptedPrivateShare(disputeShareEvent.DisputerIndex, d.commitments[d.index])
-	fiBinary, _ := priShare.V.MarshalBinary()
-	fiBig := new(big.Int).SetBytes(fiBinary)
-	args = append(args, fiBig)
-
-	buf := make([]byte, 32)
-
-	hashInput := make([]byte, 0)
-
-	a, err := d.contract.Addresses(nil, big.NewInt(int64(d.index) - 1))
-	if err != nil {
-		return fmt.Errorf("get address: %w", err)
+	input := &zk.PolyEvalInput{
+		Commits:        d.commitments[d.index],
+		SecretKey:      d.long,
+		PubKeyProofer:  d.pub,
+		PubKeyDisputer: d.participants[disputeShareEvent.DisputerIndex].pub,
+		Index:          disputeShareEvent.DisputerIndex,
+		EncryptedShare: &priShare.V.(*mod.Int).V,
 	}
 
-	commitmentsHash, err := d.contract.CommitmentHashes(nil, a)
+	commitsHash, err := input.CommitsHash()
 	if err != nil {
-		return fmt.Errorf("commitment hashes: %w", err)
+		return fmt.Errorf("commits hash: %w", err)
 	}
 
-	hashInput = append(hashInput, commitmentsHash[:]...)
-
-	hashInput = append(hashInput, pubProoferX.V.FillBytes(buf)...)
-	hashInput = append(hashInput, pubProoferY.V.FillBytes(buf)...)
-	hashInput = append(hashInput, pubDisputerX.V.FillBytes(buf)...)
-	hashInput = append(hashInput, pubDisputerY.V.FillBytes(buf)...)
-
-	hashInput = append(hashInput, index.FillBytes(buf)...)
-
-	hashInput = append(hashInput, fiBig.FillBytes(buf)...)
-
-	hash := TruncateHash(crypto.Keccak256(hashInput))
-
-	args = append(args, new(big.Int).SetBytes(hash))
-
-	log.Infof("Args: %d", args)
-
-	err = d.polyProver.ComputeWitness(d.ctx, EvalPolyProof, args)
+	storedHash, err := d.chain.CommitmentHash(d.ctx, d.index)
 	if err != nil {
-		return fmt.Errorf("compute witness: %w", err)
+		return fmt.Errorf("commitment hash: %w", err)
 	}
 
-	proof, err := d.polyProver.GenerateProof(d.ctx, EvalPolyProof)
-	if err != nil {
-		return fmt.Errorf("compute witness: %w", err)
+	if !bytes.Equal(commitsHash, storedHash[:]) {
+		return errors.New("hash of own commitments differs from the one stored in the contract")
 	}
 
-	opts, err := bind.NewKeyedTransactorWithChainID(d.ethereumPrivateKey, d.chainID)
-	if err != nil {
-		return fmt.Errorf("keyed transactor with chainID: %w", err)
+	if err := input.Validate(d.suite); err != nil {
+		return fmt.Errorf("validate poly eval input: %w", err)
 	}
-	opts.GasPrice = big.NewInt(1000000000)
 
-	tx, err := d.contract.DefendShare(opts, ShareVerifierProof(*proof.Proof))
+	witness, err := input.Witness()
 	if err != nil {
-		return fmt.Errorf("dispute share: %w", err)
+		return fmt.Errorf("poly eval witness: %w", err)
 	}
 
-	receipt, err := bind.WaitMined(d.ctx, d.client, tx)
+	proof, err := d.prove(EvalPolyProof, witness)
 	if err != nil {
-		return fmt.Errorf("wait mined: %w", err)
+		return err
 	}
 
-	if receipt.Status == types.ReceiptStatusFailed {
-		return errors.New("receipt status failed")
+	if err := d.chain.DefendShare(d.ctx, ShareVerifierProof(*proof.Proof)); err != nil {
+		return err
 	}
 
 	log.Infoln("Share successfully defended")
@@ -751,104 +470,64 @@ func (d *DistKeyGenerator) HandleDisputeShareLog(disputeShareEvent *ZKDKGContrac
 	return nil
 }
 
-func (d *DistKeyGenerator) WatchExclusion(ctx context.Context) error {
-	return WatchEvent(
-		ctx,
-		d.contract.WatchExclusion,
-		nil,
-		func(event *ZKDKGContractExclusion) error {
-			err := d.HandleExclusion(event.Index)
-
-			select {
-			case d.periodChange <- struct{}{}:
-			default:
-			}
+// prove computes the witness and generates the proof of a program, retrying according to the prover policy.
+func (d *DistKeyGenerator) prove(proofType ProofType, witness zk.Witness) (*Proof, error) {
+	log.Infof("Witness: %v", witness)
 
-			return err
-		},
-		false,
-	)
-}
+	retryable := func(error) bool { return true }
+	return retry(d.ctx, d.proverRetry, retryable, func() (*Proof, error) {
+		if err := d.polyProver.ComputeWitness(d.ctx, proofType, witness); err != nil {
+			return nil, &ProverError{ProofType: proofType, Step: "compute witness", Err: err}
+		}
 
-func (d *DistKeyGenerator) WatchAbortion(ctx context.Context) error {
-	return WatchEvent(
-		ctx,
-		d.contract.WatchAbortion,
-		nil,
-		func(*ZKDKGContractAbortion) error {
-			return errAbortion
-		},
-		true,
-	)
+		proof, err := d.polyProver.GenerateProof(d.ctx, proofType)
+		if err != nil {
+			return nil, &ProverError{ProofType: proofType, Step: "generate proof", Err: err}
+		}
+		return proof, nil
+	})
 }
 
 func (d *DistKeyGenerator) HandleExclusion(index uint16) error {
+	d.recordExclusion(index)
 	if d.index == index {
-		return errors.New("this node got excluded by the protocol")
+		return ErrExcluded
 	}
 
 	log.Infof("Excluding node %d", index)
+	if _, ok := d.commitments[index]; !ok {
+		// The node is excluded before its broadcast was received
+		d.invalidateBroadcast(index, int(d.params.MinimumThreshold))
+		return d.checkCollected()
+	}
 	for i := range d.commitments[index] {
 		d.commitments[index][i] = d.suite.Point().Null()
 	}
-	d.shares[index] = d.suite.Scalar()
+	d.shares[index] = d.suite.Scalar().Zero()
 
 	return nil
 }
 
-func (d *DistKeyGenerator) WatchPublicKeySubmissionLog(ctx context.Context, computedPk kyber.Point) error {
-	return WatchEvent(
-		ctx,
-		d.contract.WatchPublicKeySubmission,
-		nil,
-		func(event *ZKDKGContractPublicKeySubmission) error {
-			return d.HandlePublicKeySubmissionLog(ctx, computedPk, event)
-		},
-		true,
-	)
-}
-
-func (d *DistKeyGenerator) HandlePublicKeySubmissionLog(ctx context.Context, computedPk kyber.Point, event *ZKDKGContractPublicKeySubmission) error {
-	inputs, err := d.getTxInputs(event.Raw.TxHash)
+func (d *DistKeyGenerator) HandlePublicKeySubmissionLog(computedPk kyber.Point, event *ZKDKGContractPublicKeySubmission) error {
+	submittedPkBig, err := d.chain.SubmittedPublicKey(d.ctx, event.Raw.TxHash)
 	if err != nil {
 		return fmt.Errorf("get tx inputs: %w", err)
 	}
 
-	submittedPkBig := inputs[0].([2]*big.Int)
-	submittedPk := d.suite.Point().(*curve25519.ProjPoint)
-	submittedPkX, submittedPkY := submittedPk.GetXY()
-	submittedPkX.V.Set(submittedPkBig[0])
-	submittedPkY.V.Set(submittedPkBig[1])
+	submittedPk, err := zk.PointFromCoordinates(d.suite, submittedPkBig[0], submittedPkBig[1])
+	if err != nil {
+		return fmt.Errorf("invalid submitted public key: %w", err)
+	}
 
 	if !computedPk.Equal(submittedPk) {
 		return errors.New("computed public key differs from submitted public key")
 	}
-	
+
 	return nil
 }
 
 func (d *DistKeyGenerator) DisputeShare(disputeeIndex uint16, shares []*big.Int) error {
-	opts, err := bind.NewKeyedTransactorWithChainID(d.ethereumPrivateKey, d.chainID)
-	if err != nil {
-		return fmt.Errorf("keyed transactor with chainID: %w", err)
-	}
-	opts.GasPrice = big.NewInt(1000000000)
-
-	tx, err := d.contract.DisputeShare(opts, disputeeIndex, shares)
-	if err != nil {
-		return fmt.Errorf("dispute share: %w", err)
-	}
-
-	receipt, err := bind.WaitMined(d.ctx, d.client, tx)
-	if err != nil {
-		return fmt.Errorf("wait mined register: %w", err)
-	}
-
-	if receipt.Status == types.ReceiptStatusFailed {
-		return errors.New("receipt status failed")
-	}
-
-	return nil
+	return d.chain.DisputeShare(d.ctx, disputeeIndex, shares)
 }
 
 func (d *DistKeyGenerator) DistKeyShare() (*DistKeyShare, error) {
@@ -878,16 +557,46 @@ func (d *DistKeyGenerator) DistKeyShare() (*DistKeyShare, error) {
 	}, nil
 }
 
-func (d *DistKeyGenerator) DistributeShares() error {
-	threshold, err := d.contract.MinimumThreshold(nil)
+// verifyPossession checks the proofs of possession of the other participants' keys.
+// Shares encrypted to a rogue key couldn't be defended against its disputes, and the contract doesn't check
+// the proofs, so a rogue participant could still dispute a share withheld from it. No shares are dealt at all if any proof fails.
+func (d *DistKeyGenerator) verifyPossession() error {
+	registrations, err := d.chain.Registrations(d.ctx)
 	if err != nil {
-		return fmt.Errorf("threshold: %w", err)
+		return fmt.Errorf("registrations: %w", err)
+	}
+	proven := make(map[uint16]bool, len(registrations))
+	for _, r := range registrations {
+		participant, ok := d.participants[r.Index]
+		if !ok || r.Index == d.index {
+			continue
+		}
+		if err := VerifyPossession(d.suite, participant.pub, r.PossessionProof, r.Sender, d.contractAddress); err != nil {
+			log.Warnf("Proof of possession of participant %d is invalid: %v", r.Index, err)
+			continue
+		}
+		proven[r.Index] = true
+	}
+
+	var rogue []uint16
+	for i := uint16(1); i <= uint16(len(d.participants)); i++ {
+		if i != d.index && !proven[i] {
+			rogue = append(rogue, i)
+		}
+	}
+	if len(rogue) > 0 {
+		return fmt.Errorf("participants %v failed to prove the possession of their keys", rogue)
 	}
+	return nil
+}
 
+func (d *DistKeyGenerator) DistributeShares() error {
 	log.Info("Generating commitments and shares...")
 
+	// The contract and the circuits expect exactly minimumThreshold commitments,
+	// the user threshold only determines when the protocol is aborted
 	secret := d.suite.Scalar().Pick(d.suite.RandomStream())
-	d.priPoly = share.NewPriPoly(d.suite, int(threshold), secret, d.suite.RandomStream())
+	d.priPoly = share.NewPriPoly(d.suite, int(d.params.MinimumThreshold), secret, d.suite.RandomStream())
 	pubPoly := d.priPoly.Commit(nil)
 
 	_, commits := pubPoly.Info()
@@ -921,33 +630,7 @@ func (d *DistKeyGenerator) DistributeShares() error {
 		shares = append(shares, new(big.Int).SetBytes(b))
 	}
 
-	opts, err := bind.NewKeyedTransactorWithChainID(d.ethereumPrivateKey, d.chainID)
-	if err != nil {
-		return fmt.Errorf("keyed transactor with chainID: %w", err)
-	}
-	opts.GasPrice = big.NewInt(1000000000)
-
-	estimate, err := d.estimateGas(d.ctx, "broadcastShares", commitments, shares)
-	if err != nil {
-		return fmt.Errorf("estimate gas: %w", err)
-	}
-	opts.GasLimit = estimate + 30000
-
-	tx, err := d.contract.BroadcastShares(opts, commitments, shares)
-	if err != nil {
-		return fmt.Errorf("broadcast shares: %w", err)
-	}
-
-	receipt, err := bind.WaitMined(d.ctx, d.client, tx)
-	if err != nil {
-		return fmt.Errorf("wait mined register: %w", err)
-	}
-
-	if receipt.Status == types.ReceiptStatusFailed {
-		return errors.New("receipt status failed")
-	}
-
-	return nil
+	return d.chain.BroadcastShares(d.ctx, commitments, shares)
 }
 
 func (d *DistKeyGenerator) EncryptedPrivateShare(i uint16, commits []kyber.Point) (*share.PriShare, error) {
@@ -965,75 +648,15 @@ func (d *DistKeyGenerator) EncryptedPrivateShare(i uint16, commits []kyber.Point
 }
 
 func (d *DistKeyGenerator) PreSharedKey(privateKey kyber.Scalar, publicKey kyber.Point, commits []kyber.Point) (kyber.Scalar, error) {
-	pre := DhExchange(d.suite, privateKey, publicKey)
-
-	sharedKey, _ := pre.(*curve25519.ProjPoint)
-	sharedKeyX, _ := sharedKey.GetXY()
-
-	commit, _ := commits[0].(*curve25519.ProjPoint)
-	commitX, _ := commit.GetXY()
-
-	hash, err := poseidon.Hash([]*big.Int{&sharedKeyX.V, &commitX.V})
-	if err != nil {
-		return nil, fmt.Errorf("poseidon: %w", err)
-	}
-
-	return mod.NewInt(hash, &d.curveParams.P), nil
-}
-
-func (d *DistKeyGenerator) estimateGas(ctx context.Context, fn string, args ...interface{}) (uint64, error) {
-	data, err := d.contractAbi.Pack(fn, args...)
-	if err != nil {
-		return 0, fmt.Errorf("pack args: %w", err)
-	}
-
-	return d.client.EstimateGas(ctx, ethereum.CallMsg{
-		From: d.ethereumAddress,
-		To: &d.contractAddress,
-		Data: data,
-	})
-}
-
-func (d *DistKeyGenerator) scheduleDispute(dealerIndex uint16, shares []*big.Int, distributionEnd <-chan struct{}) {
-	log.Infof("Starting dispute against dealer %d after distribution end", dealerIndex)
-
-	go func() {
-		<-distributionEnd
-
-		log.Infof("Disputing invalid broadcast from dealer %d", dealerIndex)
-
-		if err := d.DisputeShare(dealerIndex, shares); err != nil {
-			log.Errorf("Dispute commits: %v", err)
-		}
-	}()
-}
-
-func (d *DistKeyGenerator) getTxInputs(txHash common.Hash) ([]interface{}, error) {
-	tx, _, err := d.client.TransactionByHash(d.ctx, txHash)
-	if err != nil {
-		return nil, fmt.Errorf("transaction by hash: %w", err)
-	}
-
-	txData := tx.Data()
-	a, err := abi.JSON(strings.NewReader(ZKDKGContractABI))
-	if err != nil {
-		return nil, fmt.Errorf("abi from json: %w", err)
-	}
-
-	method, err := a.MethodById(txData[:4])
-	if err != nil {
-		return nil, fmt.Errorf("method by id: %w", err)
-	}
-
-	inputs, err := method.Inputs.Unpack(txData[4:])
+	key, err := zk.SharedKey(d.suite, privateKey, publicKey, commits)
 	if err != nil {
-		return nil, fmt.Errorf("unpack inputs: %w", err)
+		return nil, err
 	}
 
-	return inputs, nil
+	return mod.NewInt(key, &d.curveParams.P), nil
 }
 
-func TruncateHash(hash []byte) ([]byte) {
+func TruncateHash(hash []byte) []byte {
 	// Truncate the hash s.t. its value range is limited to exactly all field elements
-	return new(big.Int).Mod(new(big.Int).SetBytes(hash), &ParamBabyJubJub().P).Bytes()
+	return zk.KeccakToField(hash).Bytes()
 }
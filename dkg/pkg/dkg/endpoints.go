@@ -0,0 +1,237 @@
+package dkg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+	log "github.com/sirupsen/logrus"
+)
+
+// healthCheckTimeout bounds dialing and checking an endpoint, so an unresponsive one doesn't stall the failover.
+const healthCheckTimeout = 10 * time.Second
+
+// Endpoints is a client of several RPC endpoints of the same chain.
+// Calls go to the current endpoint. If it fails to answer, the next healthy endpoint takes over
+// and the call is repeated there. Subscriptions aren't moved, see logSubscription for resubscribing.
+type Endpoints struct {
+	urls    []string
+	chainID *big.Int
+	dial    func(ctx context.Context, url string) (*ethclient.Client, error)
+
+	mu      sync.Mutex
+	current int
+	client  *ethclient.Client
+}
+
+// DialEndpoints connects to the first healthy endpoint, which determines the chain all others must serve.
+func DialEndpoints(ctx context.Context, urls []string) (*Endpoints, error) {
+	if len(urls) == 0 {
+		return nil, errors.New("no RPC endpoint configured")
+	}
+	e := &Endpoints{urls: urls, dial: ethclient.DialContext, current: len(urls) - 1}
+	if _, err := e.failover(ctx, nil); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// ChainID returns the chain ID of the endpoints.
+func (e *Endpoints) ChainID(context.Context) (*big.Int, error) {
+	return new(big.Int).Set(e.chainID), nil
+}
+
+// Close closes the connection to the current endpoint.
+func (e *Endpoints) Close() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.client != nil {
+		e.client.Close()
+		e.client = nil
+	}
+}
+
+func (e *Endpoints) get() *ethclient.Client {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.client
+}
+
+// failover replaces the failed client with the next healthy endpoint, starting after the current one
+// and trying the current one last. Concurrent callers that observed the same failure share one replacement.
+func (e *Endpoints) failover(ctx context.Context, failed *ethclient.Client) (*ethclient.Client, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.client != failed {
+		return e.client, nil
+	}
+	if failed != nil {
+		failed.Close()
+		e.client = nil
+	}
+
+	var errs []string
+	for i := 1; i <= len(e.urls); i++ {
+		next := (e.current + i) % len(e.urls)
+		client, err := e.connect(ctx, e.urls[next])
+		if err != nil {
+			log.Warnf("RPC endpoint %s is unhealthy: %v", e.urls[next], err)
+			errs = append(errs, fmt.Sprintf("%s: %v", e.urls[next], err))
+			continue
+		}
+
+		if failed != nil {
+			log.Infof("Failed over to RPC endpoint %s", e.urls[next])
+		}
+		e.current, e.client = next, client
+		return client, nil
+	}
+	return nil, fmt.Errorf("no healthy RPC endpoint: %s", strings.Join(errs, "; "))
+}
+
+// connect dials an endpoint and checks that it serves the chain and answers requests.
+func (e *Endpoints) connect(ctx context.Context, url string) (*ethclient.Client, error) {
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+
+	client, err := e.dial(ctx, url)
+	if err != nil {
+		return nil, fmt.Errorf("dial: %w", err)
+	}
+
+	chainID, err := client.ChainID(ctx)
+	if err != nil {
+		client.Close()
+		return nil, fmt.Errorf("chainID: %w", err)
+	}
+	if e.chainID == nil {
+		e.chainID = chainID
+	} else if e.chainID.Cmp(chainID) != 0 {
+		client.Close()
+		return nil, fmt.Errorf("serves chain %s instead of %s", chainID, e.chainID)
+	}
+
+	if _, err := client.BlockNumber(ctx); err != nil {
+		client.Close()
+		return nil, fmt.Errorf("block number: %w", err)
+	}
+	return client, nil
+}
+
+// isConnectionError reports whether a call failed because of the endpoint rather than the request.
+// Endpoints answer invalid requests, e.g. reverting calls, with JSON-RPC errors.
+func isConnectionError(ctx context.Context, err error) bool {
+	if ctx.Err() != nil || errors.Is(err, ethereum.NotFound) {
+		return false
+	}
+	var rpcErr rpc.Error
+	return !errors.As(err, &rpcErr)
+}
+
+// call runs fn on the current endpoint and repeats it on the next healthy one if the endpoint fails to answer.
+func call[T any](ctx context.Context, e *Endpoints, fn func(*ethclient.Client) (T, error)) (T, error) {
+	client := e.get()
+	if client == nil {
+		var err error
+		if client, err = e.failover(ctx, nil); err != nil {
+			return *new(T), err
+		}
+	}
+
+	v, err := fn(client)
+	if err == nil || !isConnectionError(ctx, err) {
+		return v, err
+	}
+
+	log.Warnf("RPC endpoint failed: %v", err)
+	client, failoverErr := e.failover(ctx, client)
+	if failoverErr != nil {
+		return v, fmt.Errorf("%v, failover: %w", err, failoverErr)
+	}
+	return fn(client)
+}
+
+func (e *Endpoints) BlockNumber(ctx context.Context) (uint64, error) {
+	return call(ctx, e, func(c *ethclient.Client) (uint64, error) { return c.BlockNumber(ctx) })
+}
+
+func (e *Endpoints) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	return call(ctx, e, func(c *ethclient.Client) (*types.Header, error) { return c.HeaderByNumber(ctx, number) })
+}
+
+func (e *Endpoints) CodeAt(ctx context.Context, account common.Address, blockNumber *big.Int) ([]byte, error) {
+	return call(ctx, e, func(c *ethclient.Client) ([]byte, error) { return c.CodeAt(ctx, account, blockNumber) })
+}
+
+func (e *Endpoints) CallContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	return call(ctx, e, func(c *ethclient.Client) ([]byte, error) { return c.CallContract(ctx, msg, blockNumber) })
+}
+
+func (e *Endpoints) PendingCodeAt(ctx context.Context, account common.Address) ([]byte, error) {
+	return call(ctx, e, func(c *ethclient.Client) ([]byte, error) { return c.PendingCodeAt(ctx, account) })
+}
+
+func (e *Endpoints) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	return call(ctx, e, func(c *ethclient.Client) (uint64, error) { return c.PendingNonceAt(ctx, account) })
+}
+
+func (e *Endpoints) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	return call(ctx, e, func(c *ethclient.Client) (*big.Int, error) { return c.SuggestGasPrice(ctx) })
+}
+
+func (e *Endpoints) SuggestGasTipCap(ctx context.Context) (*big.Int, error) {
+	return call(ctx, e, func(c *ethclient.Client) (*big.Int, error) { return c.SuggestGasTipCap(ctx) })
+}
+
+func (e *Endpoints) EstimateGas(ctx context.Context, msg ethereum.CallMsg) (uint64, error) {
+	return call(ctx, e, func(c *ethclient.Client) (uint64, error) { return c.EstimateGas(ctx, msg) })
+}
+
+// SendTransaction sends a signed transaction. If the failed endpoint forwarded it before failing,
+// the next one already knows it, which counts as success.
+func (e *Endpoints) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	resent := false
+	_, err := call(ctx, e, func(c *ethclient.Client) (struct{}, error) {
+		err := c.SendTransaction(ctx, tx)
+		if resent && err != nil && strings.Contains(err.Error(), "already known") {
+			return struct{}{}, nil
+		}
+		resent = true
+		return struct{}{}, err
+	})
+	return err
+}
+
+func (e *Endpoints) TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	return call(ctx, e, func(c *ethclient.Client) (*types.Receipt, error) { return c.TransactionReceipt(ctx, txHash) })
+}
+
+// TransactionByHash returns a transaction without telling whether it is pending.
+func (e *Endpoints) TransactionByHash(ctx context.Context, txHash common.Hash) (*types.Transaction, error) {
+	return call(ctx, e, func(c *ethclient.Client) (*types.Transaction, error) {
+		tx, _, err := c.TransactionByHash(ctx, txHash)
+		return tx, err
+	})
+}
+
+func (e *Endpoints) FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error) {
+	return call(ctx, e, func(c *ethclient.Client) ([]types.Log, error) { return c.FilterLogs(ctx, q) })
+}
+
+func (e *Endpoints) SubscribeFilterLogs(ctx context.Context, q ethereum.FilterQuery, ch chan<- types.Log) (ethereum.Subscription, error) {
+	return call(ctx, e, func(c *ethclient.Client) (ethereum.Subscription, error) { return c.SubscribeFilterLogs(ctx, q, ch) })
+}
+
+func (e *Endpoints) SubscribeNewHead(ctx context.Context, ch chan<- *types.Header) (ethereum.Subscription, error) {
+	return call(ctx, e, func(c *ethclient.Client) (ethereum.Subscription, error) { return c.SubscribeNewHead(ctx, ch) })
+}
@@ -0,0 +1,114 @@
+package dkg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeEth serves the health checks of Endpoints.
+type fakeEth struct {
+	chainID int64
+	block   uint64
+}
+
+func (f *fakeEth) ChainId() *hexutil.Big {
+	return (*hexutil.Big)(big.NewInt(f.chainID))
+}
+
+func (f *fakeEth) BlockNumber() hexutil.Uint64 {
+	return hexutil.Uint64(f.block)
+}
+
+func (f *fakeEth) GasPrice() (*hexutil.Big, error) {
+	return nil, errors.New("gas price unavailable")
+}
+
+// fakeEndpoints serves a fakeEth in process per URL and refuses connections to URLs that are down.
+type fakeEndpoints struct {
+	mu   sync.Mutex
+	eths map[string]*fakeEth
+	down map[string]bool
+}
+
+func (f *fakeEndpoints) dial(_ context.Context, url string) (*ethclient.Client, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	eth, ok := f.eths[url]
+	if !ok || f.down[url] {
+		return nil, fmt.Errorf("connection refused")
+	}
+	server := rpc.NewServer()
+	if err := server.RegisterName("eth", eth); err != nil {
+		return nil, err
+	}
+	return ethclient.NewClient(rpc.DialInProc(server)), nil
+}
+
+func TestEndpointsFailover(t *testing.T) {
+	f := &fakeEndpoints{
+		eths: map[string]*fakeEth{
+			"b": {chainID: 1, block: 10},
+			"c": {chainID: 2, block: 20},
+			"d": {chainID: 1, block: 30},
+		},
+		down: map[string]bool{"a": true},
+	}
+	e := &Endpoints{urls: []string{"a", "b", "c", "d"}, dial: f.dial, current: 3}
+	ctx := context.Background()
+
+	_, err := e.failover(ctx, nil)
+	require.NoError(t, err)
+	require.Equal(t, "b", e.urls[e.current])
+
+	chainID, err := e.ChainID(ctx)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), chainID.Int64())
+
+	block, err := e.BlockNumber(ctx)
+	require.NoError(t, err)
+	require.Equal(t, uint64(10), block)
+
+	// JSON-RPC errors are answers, the endpoint stays
+	_, err = e.SuggestGasPrice(ctx)
+	require.Error(t, err)
+	require.Equal(t, "b", e.urls[e.current])
+
+	// The connection drops, the endpoint of another chain is skipped
+	f.mu.Lock()
+	f.down["b"] = true
+	f.mu.Unlock()
+	e.get().Close()
+
+	block, err = e.BlockNumber(ctx)
+	require.NoError(t, err)
+	require.Equal(t, uint64(30), block)
+	require.Equal(t, "d", e.urls[e.current])
+}
+
+func TestEndpointsNoneHealthy(t *testing.T) {
+	f := &fakeEndpoints{down: map[string]bool{}}
+	_, err := DialEndpoints(context.Background(), nil)
+	require.Error(t, err)
+
+	e := &Endpoints{urls: []string{"a", "b"}, dial: f.dial, current: 1}
+	_, err = e.failover(context.Background(), nil)
+	require.Error(t, err)
+}
+
+func TestConfigEndpoints(t *testing.T) {
+	config := &Config{EthereumNode: "ws://a", EthereumNodes: []string{"ws://b", "", "ws://c"}}
+	require.Equal(t, []string{"ws://a", "ws://b", "ws://c"}, config.Endpoints())
+
+	config = &Config{EthereumNodes: []string{"ws://b"}}
+	require.Equal(t, []string{"ws://b"}, config.Endpoints())
+}
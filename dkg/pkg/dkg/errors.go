@@ -0,0 +1,173 @@
+package dkg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+var (
+	// ErrExcluded is returned once the contract excluded this node from the protocol.
+	ErrExcluded = errors.New("this node got excluded by the protocol")
+	// ErrAborted is returned once the contract aborted the protocol due to insufficient remaining participants.
+	ErrAborted = errors.New("protocol aborted due to insufficient remaining participants")
+	// ErrPhaseExpired matches reverts of actions that came after the end of their phase.
+	ErrPhaseExpired = errors.New("phase expired")
+	// ErrAlreadyDisputed matches reverts of disputes against a dealer another participant disputed first.
+	ErrAlreadyDisputed = errors.New("dealer already disputed")
+)
+
+// phaseExpiredReasons are the revert reasons of the contract for actions after the end of their phase.
+var phaseExpiredReasons = map[string]bool{
+	"registration phase is over":   true,
+	"broadcast period has expired": true,
+	"not in dispute period":        true,
+	"defense period expired":       true,
+}
+
+// RevertError is a call or transaction the contract reverted.
+type RevertError struct {
+	Method string
+	Reason string // Empty if the node didn't return it
+}
+
+func (e *RevertError) Error() string {
+	if e.Reason == "" {
+		return fmt.Sprintf("%s reverted", e.Method)
+	}
+	return fmt.Sprintf("%s reverted: %s", e.Method, e.Reason)
+}
+
+// Is matches ErrPhaseExpired for the reverts due to the end of a phase and ErrAlreadyDisputed for redundant disputes.
+func (e *RevertError) Is(target error) bool {
+	switch target {
+	case ErrPhaseExpired:
+		return phaseExpiredReasons[e.Reason]
+	case ErrAlreadyDisputed:
+		return e.Reason == "disputee already disputed"
+	}
+	return false
+}
+
+// ProverError is a failure to compute a witness or generate a proof with ZoKrates.
+type ProverError struct {
+	ProofType ProofType
+	Step      string // "compute witness" or "generate proof"
+	Err       error
+}
+
+func (e *ProverError) Error() string {
+	return fmt.Sprintf("%s for %s: %v", e.Step, e.ProofType, e.Err)
+}
+
+func (e *ProverError) Unwrap() error {
+	return e.Err
+}
+
+// TransientError is a failure to reach the RPC endpoints, which may succeed when retried.
+type TransientError struct {
+	Method string
+	Err    error
+}
+
+func (e *TransientError) Error() string {
+	return fmt.Sprintf("%s: endpoint unavailable: %v", e.Method, e.Err)
+}
+
+func (e *TransientError) Unwrap() error {
+	return e.Err
+}
+
+// IsTransient reports whether an error is worth retrying.
+func IsTransient(err error) bool {
+	var transient *TransientError
+	return errors.As(err, &transient)
+}
+
+// Exit codes of the node commands, 2 is left to usage errors of the flag package.
+const (
+	ExitOK           = 0
+	ExitFailure      = 1 // Any failure without a more specific code
+	ExitExcluded     = 3
+	ExitAborted      = 4
+	ExitPhaseExpired = 5
+	ExitReverted     = 6
+	ExitProver       = 7
+	ExitUnavailable  = 8 // The RPC endpoints stayed unavailable despite retrying
+)
+
+// ExitCode maps the outcome of a run to the exit code of the node.
+func ExitCode(err error) int {
+	var revert *RevertError
+	var prover *ProverError
+	switch {
+	case err == nil:
+		return ExitOK
+	case errors.Is(err, ErrExcluded):
+		return ExitExcluded
+	case errors.Is(err, ErrAborted):
+		return ExitAborted
+	case errors.Is(err, ErrPhaseExpired):
+		return ExitPhaseExpired
+	case errors.As(err, &revert):
+		return ExitReverted
+	case errors.As(err, &prover):
+		return ExitProver
+	case IsTransient(err):
+		return ExitUnavailable
+	}
+	return ExitFailure
+}
+
+// hardhatRevert extracts the reason from the revert messages of Hardhat.
+var hardhatRevert = regexp.MustCompile(`reverted with reason string '(.*)'`)
+
+// revertReason extracts the reason of a revert from the error of an RPC call, if it is one.
+func revertReason(err error) (string, bool) {
+	var dataErr rpc.DataError
+	if errors.As(err, &dataErr) {
+		if data, ok := dataErr.ErrorData().(string); ok {
+			if b, decodeErr := hexutil.Decode(data); decodeErr == nil {
+				if reason, unpackErr := abi.UnpackRevert(b); unpackErr == nil {
+					return reason, true
+				}
+			}
+		}
+	}
+
+	var rpcErr rpc.Error
+	if !errors.As(err, &rpcErr) {
+		return "", false
+	}
+	msg := rpcErr.Error()
+	if m := hardhatRevert.FindStringSubmatch(msg); m != nil {
+		return m[1], true
+	}
+	if i := strings.Index(msg, "execution reverted"); i >= 0 {
+		return strings.TrimPrefix(msg[i+len("execution reverted"):], ": "), true
+	}
+	if strings.Contains(msg, "reverted") {
+		return "", true
+	}
+	return "", false
+}
+
+// classifyError turns the error of an RPC call to the contract into a RevertError or TransientError if it is one.
+func classifyError(ctx context.Context, method string, err error) error {
+	if err == nil {
+		return nil
+	}
+	if reason, ok := revertReason(err); ok {
+		return &RevertError{Method: method, Reason: reason}
+	}
+	if isConnectionError(ctx, err) {
+		return &TransientError{Method: method, Err: err}
+	}
+	return fmt.Errorf("%s: %w", method, err)
+}
@@ -0,0 +1,168 @@
+package dkg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+// jsonError is a JSON-RPC error as returned by the rpc package.
+type jsonError struct {
+	msg  string
+	data interface{}
+}
+
+func (e *jsonError) Error() string          { return e.msg }
+func (e *jsonError) ErrorCode() int         { return 3 }
+func (e *jsonError) ErrorData() interface{} { return e.data }
+
+func revertData(t *testing.T, reason string) string {
+	stringType, err := abi.NewType("string", "", nil)
+	require.NoError(t, err)
+	packed, err := abi.Arguments{{Type: stringType}}.Pack(reason)
+	require.NoError(t, err)
+	return hexutil.Encode(append(crypto.Keccak256([]byte("Error(string)"))[:4], packed...))
+}
+
+func TestClassifyError(t *testing.T) {
+	ctx := context.Background()
+
+	err := classifyError(ctx, "disputeShare", &jsonError{msg: "execution reverted: not in dispute period", data: revertData(t, "not in dispute period")})
+	var revert *RevertError
+	require.ErrorAs(t, err, &revert)
+	require.Equal(t, "not in dispute period", revert.Reason)
+	require.ErrorIs(t, err, ErrPhaseExpired)
+	require.Equal(t, ExitPhaseExpired, ExitCode(fmt.Errorf("dispute: %w", err)))
+
+	err = classifyError(ctx, "disputeShare", &jsonError{msg: "execution reverted: disputee already disputed"})
+	require.ErrorIs(t, err, ErrAlreadyDisputed)
+	require.NotErrorIs(t, err, ErrPhaseExpired)
+
+	// Hardhat includes the reason in the message only
+	err = classifyError(ctx, "broadcastShares", &jsonError{msg: "Error: VM Exception while processing transaction: reverted with reason string 'already broadcasted before'"})
+	require.ErrorAs(t, err, &revert)
+	require.Equal(t, "already broadcasted before", revert.Reason)
+	require.NotErrorIs(t, err, ErrPhaseExpired)
+	require.Equal(t, ExitReverted, ExitCode(err))
+
+	err = classifyError(ctx, "phaseEnd", errors.New("websocket: close 1006 (abnormal closure)"))
+	require.True(t, IsTransient(err))
+	require.Equal(t, ExitUnavailable, ExitCode(err))
+
+	err = classifyError(ctx, "phaseEnd", &jsonError{msg: "header not found"})
+	require.False(t, IsTransient(err))
+	require.False(t, errors.As(err, &revert))
+
+	require.NoError(t, classifyError(ctx, "phaseEnd", nil))
+}
+
+func TestExitCode(t *testing.T) {
+	for err, code := range map[error]int{
+		nil:                                   ExitOK,
+		errors.New("boom"):                    ExitFailure,
+		fmt.Errorf("handle: %w", ErrExcluded): ExitExcluded,
+		ErrAborted:                            ExitAborted,
+		&ProverError{ProofType: EvalPolyProof, Step: "generate proof", Err: errors.New("exit status 1")}: ExitProver,
+	} {
+		require.Equal(t, code, ExitCode(err), "%v", err)
+	}
+}
+
+func TestRetry(t *testing.T) {
+	ctx := context.Background()
+	policy := RetryPolicy{Attempts: 3, MinBackoff: time.Millisecond, MaxBackoff: time.Millisecond}
+	transient := &TransientError{Method: "phaseEnd", Err: errors.New("connection reset")}
+
+	calls := 0
+	v, err := retry(ctx, policy, IsTransient, func() (int, error) {
+		if calls++; calls < 3 {
+			return 0, transient
+		}
+		return 42, nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, 42, v)
+
+	calls = 0
+	_, err = retry(ctx, policy, IsTransient, func() (int, error) {
+		calls++
+		return 0, transient
+	})
+	require.ErrorIs(t, err, transient)
+	require.Equal(t, 3, calls)
+
+	// Reverts are final
+	calls = 0
+	_, err = retry(ctx, policy, IsTransient, func() (int, error) {
+		calls++
+		return 0, &RevertError{Method: "broadcastShares"}
+	})
+	require.Error(t, err)
+	require.Equal(t, 1, calls)
+
+	require.Equal(t, DefaultRetryPolicy, RetryPolicy{}.orDefault(DefaultRetryPolicy))
+}
+
+// fakeSender answers the sends of a transaction with errs in turn and succeeds once they are used up.
+type fakeSender struct {
+	errs  []error
+	sends []common.Hash
+	mined bool
+}
+
+func (f *fakeSender) SendTransaction(_ context.Context, tx *types.Transaction) error {
+	f.sends = append(f.sends, tx.Hash())
+	if len(f.sends) > len(f.errs) {
+		return nil
+	}
+	return f.errs[len(f.sends)-1]
+}
+
+func (f *fakeSender) TransactionReceipt(context.Context, common.Hash) (*types.Receipt, error) {
+	if !f.mined {
+		return nil, ethereum.NotFound
+	}
+	return &types.Receipt{Status: types.ReceiptStatusSuccessful}, nil
+}
+
+func TestSendSigned(t *testing.T) {
+	ctx := context.Background()
+	policy := RetryPolicy{Attempts: 3, MinBackoff: time.Millisecond, MaxBackoff: time.Millisecond}
+	tx := types.NewTransaction(7, common.Address{}, nil, 21000, big.NewInt(1), nil)
+	unreachable := errors.New("connection reset")
+
+	// The same transaction is resent while the endpoints are unreachable
+	f := &fakeSender{errs: []error{unreachable, unreachable}}
+	require.NoError(t, sendSigned(ctx, policy, f, "register", tx))
+	require.Equal(t, []common.Hash{tx.Hash(), tx.Hash(), tx.Hash()}, f.sends)
+
+	// The first send got through before the connection failed
+	f = &fakeSender{errs: []error{unreachable, &jsonError{msg: "already known"}}}
+	require.NoError(t, sendSigned(ctx, policy, f, "register", tx))
+
+	f = &fakeSender{errs: []error{unreachable, &jsonError{msg: "nonce too low"}}, mined: true}
+	require.NoError(t, sendSigned(ctx, policy, f, "register", tx))
+
+	// Another transaction used the nonce
+	f = &fakeSender{errs: []error{unreachable, &jsonError{msg: "nonce too low"}}}
+	require.Error(t, sendSigned(ctx, policy, f, "register", tx))
+	require.Len(t, f.sends, 2)
+
+	// Errors the endpoints answer with prove that the transaction wasn't accepted
+	f = &fakeSender{errs: []error{&jsonError{msg: "insufficient funds for gas * price + value"}}}
+	err := sendSigned(ctx, policy, f, "register", tx)
+	require.Error(t, err)
+	require.False(t, IsTransient(err))
+	require.Len(t, f.sends, 1)
+}
@@ -0,0 +1,501 @@
+package dkg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	log "github.com/sirupsen/logrus"
+	"go.dedis.ch/kyber/v3"
+)
+
+// DefaultSubmissionBackoff is the time each qualified participant leaves the one before it to submit the public key,
+// enough to generate the proof and mine the transaction.
+const DefaultSubmissionBackoff = 2 * time.Minute
+
+// state is the progress of a DistKeyGenerator through the protocol.
+// The first states mirror the Phase of the contract, the later ones only exist locally
+// while the contract remains in BROADCAST_DISPUTE until the public key is submitted.
+type state uint8
+
+const (
+	stateRegister         state = iota // Registered, waiting for the registration to end
+	stateBroadcastSubmit               // Shares broadcast, collecting the broadcasts of the other dealers
+	stateBroadcastDispute              // Distribution ended, disputes are raised and defended until the phase ends
+	stateKeySubmission                 // Dispute phase over, waiting for the submission of the public key
+	stateSettlement                    // Broadcast only, waiting for the protocol to complete or abort to withdraw the stake
+	stateDone
+)
+
+func (s state) String() string {
+	switch s {
+	case stateRegister:
+		return PhaseRegister.String()
+	case stateBroadcastSubmit:
+		return PhaseBroadcastSubmit.String()
+	case stateBroadcastDispute:
+		return PhaseBroadcastDispute.String()
+	case stateKeySubmission:
+		return "KEY_SUBMISSION"
+	case stateSettlement:
+		return "SETTLEMENT"
+	case stateDone:
+		return "DONE"
+	}
+	return fmt.Sprintf("state(%d)", uint8(s))
+}
+
+// loopState is the state of the event loop beyond the protocol data of the DistKeyGenerator.
+type loopState struct {
+	state state
+
+	// Events that arrived before the registration ended, as the subscriptions to different events aren't ordered
+	deferred []interface{}
+	// Shares of dealers to dispute once the distribution ends
+	pendingDisputes map[uint16][]*big.Int
+
+	collected  bool        // All broadcasts were received and their shares verified
+	clock      *ChainClock // Follows the block timestamps the contract checks its deadlines against
+	phaseEnd   uint64      // End of the dispute phase as last read from the contract, 0 if unknown
+	phaseEnded bool        // The dispute phase is over
+	// Fires if the chain stops producing blocks after the end of the dispute phase
+	idleTimer   *time.Timer
+	submission  *ZKDKGContractPublicKeySubmission // Submission of the public key observed before it was computed
+	computedKey kyber.Point
+	// Fires when the slot of this node to submit the public key starts
+	submitTimer *time.Timer
+}
+
+// Generate runs the protocol to completion and returns the distributed public key,
+// or nil if the generator only broadcasts its shares.
+//
+// Contract events, new blocks and the local actions they trigger are serialized through a single loop,
+// which is the only goroutine that accesses the state of the generator.
+// The dispute phase ends with the first block later than the phase end of the contract, see ChainClock.
+func (d *DistKeyGenerator) Generate() (kyber.Point, error) {
+	log.Info("Generating distributed private key...")
+	defer d.polyProver.Close()
+
+	ctx, cancel := context.WithCancel(d.ctx)
+	defer cancel()
+	d.ctx = ctx
+
+	events := make(chan interface{})
+	sub, err := d.chain.Subscribe(ctx, events)
+	if err != nil {
+		return nil, fmt.Errorf("subscribe: %w", err)
+	}
+	defer sub.Unsubscribe()
+
+	head, err := d.chain.LatestHeader(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("latest header: %w", err)
+	}
+
+	d.loop = loopState{
+		state:           stateRegister,
+		pendingDisputes: make(map[uint16][]*big.Int),
+		clock:           NewChainClock(),
+	}
+	d.loop.clock.Observe(head)
+	defer d.stopIdleTimer()
+	defer d.stopSubmitTimer()
+
+	if err := d.Register(ctx); err != nil {
+		return nil, fmt.Errorf("register: %w", err)
+	}
+	log.Info("Waiting until registration is finished...")
+
+	for d.loop.state != stateDone {
+		var idle, submit <-chan time.Time
+		if d.loop.idleTimer != nil {
+			idle = d.loop.idleTimer.C
+		}
+		if d.loop.submitTimer != nil {
+			submit = d.loop.submitTimer.C
+		}
+
+		select {
+		case e := <-events:
+			err = d.handleEvent(e)
+		case <-idle:
+			d.loop.idleTimer = nil
+			log.Warnf("No block later than phase end %d since block at %d, assuming the phase ended", d.loop.phaseEnd, d.loop.clock.Head())
+			err = d.handlePhaseEnd()
+		case <-submit:
+			d.loop.submitTimer = nil
+			err = d.submitKey()
+		case err := <-sub.Err():
+			return nil, fmt.Errorf("subscription: %w", err)
+		case <-ctx.Done():
+			return nil, fmt.Errorf("context: %w", ctx.Err())
+		}
+
+		if err != nil {
+			if errors.Is(err, ErrAborted) {
+				d.claimPayout()
+			}
+			return nil, err
+		}
+	}
+
+	// A broadcast-only node without a stake left right after its broadcast and has nothing to withdraw
+	if !d.broadcastOnly || d.params.Stake.Sign() > 0 {
+		d.claimPayout()
+	}
+	return d.loop.computedKey, nil
+}
+
+func (d *DistKeyGenerator) handleEvent(e interface{}) error {
+	if header, ok := e.(*types.Header); ok {
+		return d.handleHeader(header)
+	}
+
+	if d.loop.state == stateSettlement {
+		return d.handleSettlementEvent(e)
+	}
+
+	if d.loop.state == stateRegister {
+		switch e.(type) {
+		case *ZKDKGContractRegistrationEndLog, *ZKDKGContractAbortion:
+		default:
+			d.loop.deferred = append(d.loop.deferred, e)
+			return nil
+		}
+	}
+
+	switch e := e.(type) {
+	case *ZKDKGContractRegistrationEndLog:
+		return d.handleRegistrationEnd()
+	case *ZKDKGContractBroadcastSharesLog:
+		if err := d.HandleBroadcastSharesLog(e); err != nil {
+			return fmt.Errorf("handle broadcast of dealer %d: %w", e.BroadcasterIndex, err)
+		}
+	case *ZKDKGContractDistributionEndLog:
+		return d.handleDistributionEnd()
+	case *ZKDKGContractDisputeShare:
+		if err := d.extendPhase(); err != nil {
+			return err
+		}
+		if err := d.HandleDisputeShareLog(e); err != nil {
+			return fmt.Errorf("handle dispute: %w", err)
+		}
+	case *ZKDKGContractExclusion:
+		if err := d.extendPhase(); err != nil {
+			return err
+		}
+		if err := d.HandleExclusion(e.Index); err != nil {
+			return fmt.Errorf("handle exclusion: %w", err)
+		}
+	case *ZKDKGContractSlashing:
+		d.recordSlashing(e.Index, e.Amount)
+	case *ZKDKGContractAbortion:
+		return ErrAborted
+	case *ZKDKGContractPublicKeySubmission:
+		return d.handlePublicKeySubmission(e)
+	}
+	return nil
+}
+
+// handleSettlementEvent follows a broadcast-only node, which doesn't take part in the protocol after its broadcast,
+// until the contract settles the stakes.
+func (d *DistKeyGenerator) handleSettlementEvent(e interface{}) error {
+	switch e := e.(type) {
+	case *ZKDKGContractExclusion:
+		d.recordExclusion(e.Index)
+		if e.Index == d.index {
+			return ErrExcluded
+		}
+	case *ZKDKGContractSlashing:
+		d.recordSlashing(e.Index, e.Amount)
+	case *ZKDKGContractAbortion:
+		return ErrAborted
+	case *ZKDKGContractPublicKeySubmission:
+		d.loop.state = stateDone
+	}
+	return nil
+}
+
+func (d *DistKeyGenerator) handleRegistrationEnd() error {
+	if d.loop.state != stateRegister {
+		return nil
+	}
+
+	if err := d.CollectParticipants(); err != nil {
+		return fmt.Errorf("collect participants: %w", err)
+	}
+
+	if err := d.DistributeShares(); err != nil {
+		return fmt.Errorf("distribute shares: %w", err)
+	}
+
+	if d.broadcastOnly {
+		if d.params.Stake.Sign() == 0 {
+			d.loop.state = stateDone
+			return nil
+		}
+		log.Info("Waiting for the protocol to complete or abort to withdraw the stake...")
+		d.loop.state = stateSettlement
+		return nil
+	}
+	d.loop.state = stateBroadcastSubmit
+
+	deferred := d.loop.deferred
+	d.loop.deferred = nil
+	for _, e := range deferred {
+		if err := d.handleEvent(e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkCollected verifies the received shares once a broadcast, or its replacement due to an exclusion,
+// is known for every participant.
+func (d *DistKeyGenerator) checkCollected() error {
+	if d.loop.collected || len(d.commitments) != len(d.participants) {
+		return nil
+	}
+
+	d.verifyReceivedShares()
+	d.loop.collected = true
+	return d.submitWhenReady()
+}
+
+func (d *DistKeyGenerator) handleDistributionEnd() error {
+	if d.loop.state != stateBroadcastSubmit {
+		return nil
+	}
+	d.loop.state = stateBroadcastDispute
+
+	dealers := make([]uint16, 0, len(d.loop.pendingDisputes))
+	for dealerIndex := range d.loop.pendingDisputes {
+		dealers = append(dealers, dealerIndex)
+	}
+	sort.Slice(dealers, func(i, j int) bool { return dealers[i] < dealers[j] })
+
+	for _, dealerIndex := range dealers {
+		d.raiseDispute(dealerIndex, d.loop.pendingDisputes[dealerIndex])
+	}
+	d.loop.pendingDisputes = nil
+
+	return d.refreshPhaseEnd()
+}
+
+// scheduleDispute disputes the broadcast of a dealer, which the contract only accepts after the distribution ended.
+func (d *DistKeyGenerator) scheduleDispute(dealerIndex uint16, shares []*big.Int) {
+	if d.loop.state >= stateBroadcastDispute {
+		d.raiseDispute(dealerIndex, shares)
+		return
+	}
+
+	log.Infof("Starting dispute against dealer %d after distribution end", dealerIndex)
+	d.loop.pendingDisputes[dealerIndex] = shares
+}
+
+// raiseDispute disputes the broadcast of a dealer unless another participant did so first
+// or the contract would reject the dispute.
+func (d *DistKeyGenerator) raiseDispute(dealerIndex uint16, shares []*big.Int) {
+	if disputer, ok := d.disputer(dealerIndex); ok {
+		log.Infof("Dealer %d already disputed by %d, skipping own dispute", dealerIndex, disputer)
+		return
+	}
+
+	err := d.checkDispute(dealerIndex, shares)
+	if err == nil {
+		log.Infof("Disputing invalid broadcast from dealer %d", dealerIndex)
+		err = d.DisputeShare(dealerIndex, shares)
+	}
+	switch {
+	case errors.Is(err, ErrAlreadyDisputed):
+		// The event of the other dispute is on its way
+		log.Infof("Dealer %d already disputed by another participant, skipping own dispute", dealerIndex)
+	case err != nil:
+		log.Errorf("Dispute against dealer %d: %v", dealerIndex, err)
+	}
+}
+
+// disputer returns the participant whose dispute against the dealer is pending or excluded the dealer,
+// as known from the events so far. A defended dispute only proves the share of its disputer, so it doesn't count.
+func (d *DistKeyGenerator) disputer(dealerIndex uint16) (uint16, bool) {
+	for _, dispute := range d.disputes {
+		if dispute.Disputee == dealerIndex && dispute.Outcome != DisputeDefended {
+			return dispute.Disputer, true
+		}
+	}
+	return 0, false
+}
+
+// checkDispute verifies the preconditions of the contract for a dispute before paying for its transaction:
+// the dispute phase didn't end, the shares match the ones the dealer broadcast and a simulation of the call succeeds.
+func (d *DistKeyGenerator) checkDispute(dealerIndex uint16, shares []*big.Int) error {
+	phaseEnd, err := d.chain.PhaseEnd(d.ctx)
+	if err != nil {
+		return fmt.Errorf("phase end: %w", err)
+	}
+	if d.loop.clock.Passed(phaseEnd) {
+		return fmt.Errorf("dispute phase ended at %d: %w", phaseEnd, ErrPhaseExpired)
+	}
+
+	hash, err := d.chain.ShareHash(d.ctx, dealerIndex)
+	if err != nil {
+		return fmt.Errorf("share hash: %w", err)
+	}
+	if hash != sharesHash(shares) {
+		return errors.New("shares differ from the ones stored in the contract")
+	}
+
+	return d.chain.SimulateDisputeShare(d.ctx, dealerIndex, shares)
+}
+
+// extendPhase rereads the end of the dispute phase, as disputes and exclusions may move it.
+func (d *DistKeyGenerator) extendPhase() error {
+	if d.loop.state != stateBroadcastDispute || d.loop.phaseEnded {
+		return nil
+	}
+	return d.refreshPhaseEnd()
+}
+
+// refreshPhaseEnd reads the end of the dispute phase from the contract and checks whether it passed.
+// If it can't be read, it is read again with the next block.
+func (d *DistKeyGenerator) refreshPhaseEnd() error {
+	phaseEnd, err := d.chain.PhaseEnd(d.ctx)
+	if err != nil {
+		log.Warnf("Failed to retrieve current phase end, retrying with the next block: %v", err)
+		d.loop.phaseEnd = 0
+		d.stopIdleTimer()
+		return nil
+	}
+	d.loop.phaseEnd = phaseEnd
+	return d.checkPhaseEnd()
+}
+
+// checkPhaseEnd ends the dispute phase once a block later than its end was mined,
+// or waits for the chain to mine one otherwise.
+func (d *DistKeyGenerator) checkPhaseEnd() error {
+	d.stopIdleTimer()
+	if d.loop.state != stateBroadcastDispute || d.loop.phaseEnded || d.loop.phaseEnd == 0 {
+		return nil
+	}
+
+	if d.loop.clock.Passed(d.loop.phaseEnd) {
+		return d.handlePhaseEnd()
+	}
+	if wait, ok := d.loop.clock.UntilIdle(d.loop.phaseEnd); ok {
+		d.loop.idleTimer = time.NewTimer(wait)
+	}
+	return nil
+}
+
+func (d *DistKeyGenerator) handleHeader(header *types.Header) error {
+	d.loop.clock.Observe(header)
+	if d.loop.state != stateBroadcastDispute || d.loop.phaseEnded {
+		return nil
+	}
+	if d.loop.phaseEnd == 0 {
+		return d.refreshPhaseEnd()
+	}
+	return d.checkPhaseEnd()
+}
+
+func (d *DistKeyGenerator) stopIdleTimer() {
+	if d.loop.idleTimer != nil {
+		d.loop.idleTimer.Stop()
+		d.loop.idleTimer = nil
+	}
+}
+
+func (d *DistKeyGenerator) handlePhaseEnd() error {
+	if d.loop.state != stateBroadcastDispute || d.loop.phaseEnded {
+		return nil
+	}
+	d.loop.phaseEnded = true
+	d.stopIdleTimer()
+	return d.submitWhenReady()
+}
+
+// submitWhenReady computes and submits the public key once the dispute phase is over and all broadcasts are known.
+func (d *DistKeyGenerator) submitWhenReady() error {
+	if d.loop.state != stateBroadcastDispute || !d.loop.phaseEnded || !d.loop.collected {
+		return nil
+	}
+
+	if err := d.checkExpiredDisputes(); err != nil {
+		return fmt.Errorf("check expired disputes: %w", err)
+	}
+
+	pub, err := d.ComputePublicKey()
+	if err != nil {
+		return fmt.Errorf("compute public key: %w", err)
+	}
+	d.loop.computedKey = pub
+	d.loop.state = stateKeySubmission
+
+	if d.loop.submission != nil {
+		// Another participant was faster
+		return d.handlePublicKeySubmission(d.loop.submission)
+	}
+
+	// Only one submission succeeds, so the qualified participants take turns instead of all proving at once
+	slot := d.submissionSlot()
+	if slot == 0 {
+		return d.submitKey()
+	}
+	wait := time.Duration(slot) * d.submissionBackoff
+	log.Infof("Waiting %s for the submission of the public key by the %d participants before this one", wait, slot)
+	d.loop.submitTimer = time.NewTimer(wait)
+	return nil
+}
+
+// submissionSlot returns the turn of this node to submit the public key, its position among the qualified participants.
+func (d *DistKeyGenerator) submissionSlot() int {
+	slot := 0
+	for index := range d.participants {
+		if _, excluded := d.excluded[index]; !excluded && index < d.index {
+			slot++
+		}
+	}
+	return slot
+}
+
+func (d *DistKeyGenerator) submitKey() error {
+	if d.loop.state != stateKeySubmission {
+		return nil
+	}
+
+	if err := d.SubmitPublicKey(d.loop.computedKey); err != nil {
+		if errors.Is(err, ErrAborted) || d.ctx.Err() != nil {
+			return err
+		}
+		log.Warnf("Public key submission failed, waiting for other participant's submission: %v", err)
+		return nil
+	}
+
+	d.loop.state = stateDone
+	return nil
+}
+
+func (d *DistKeyGenerator) handlePublicKeySubmission(e *ZKDKGContractPublicKeySubmission) error {
+	if d.loop.state != stateKeySubmission {
+		d.loop.submission = e
+		return nil
+	}
+
+	d.stopSubmitTimer()
+	if err := d.HandlePublicKeySubmissionLog(d.loop.computedKey, e); err != nil {
+		return fmt.Errorf("handle public key submission: %w", err)
+	}
+	d.loop.state = stateDone
+	return nil
+}
+
+func (d *DistKeyGenerator) stopSubmitTimer() {
+	if d.loop.submitTimer != nil {
+		d.loop.submitTimer.Stop()
+		d.loop.submitTimer = nil
+	}
+}
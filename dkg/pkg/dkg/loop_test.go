@@ -0,0 +1,852 @@
+package dkg
+
+import (
+	"client/internal/pkg/group/curve25519"
+	"client/pkg/zk"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/stretchr/testify/require"
+	"go.dedis.ch/kyber/v3"
+	"go.dedis.ch/kyber/v3/suites"
+)
+
+// memContract simulates the contract in memory for several participants, trusting all proofs.
+// Every transaction is mined in a block of its own, like on a development chain.
+type memContract struct {
+	suite        suites.Suite
+	participants int
+	period       uint64        // Seconds
+	offset       time.Duration // Offset of the block timestamps from the local clock
+	blockTime    time.Duration // Interval of empty blocks, if any
+	stake        *big.Int      // Required stake of the participants
+
+	mu            sync.Mutex
+	turn          *sync.Cond // Signals registrations, which happen in the order of the nodes
+	events        []interface{}
+	notify        chan struct{} // Closed and replaced whenever an event is emitted
+	txs           map[common.Hash][]*big.Int
+	keys          [][2]*big.Int
+	registrations []*ZKDKGContractRegistration
+	indices       map[common.Address]uint16
+	hashes        map[uint16][32]byte
+	shareHashes   map[uint16][32]byte
+	first         map[uint16]kyber.Point
+	disputed      map[uint16]uint16 // Disputers by disputee
+	excluded      map[uint16]bool
+	stakes        map[uint16]*big.Int
+	balances      map[uint16]*big.Int
+	slashed       *big.Int
+	settled       bool   // The stakes were paid out
+	head          uint64 // Timestamp of the latest block
+	phaseEnd      uint64
+	submitted     bool
+}
+
+func newMemContract(participants int) *memContract {
+	c := &memContract{
+		suite:        curve25519.NewBlakeSHA256BabyJubJub(false),
+		participants: participants,
+		period:       1,
+		stake:        new(big.Int),
+		notify:       make(chan struct{}),
+		txs:          make(map[common.Hash][]*big.Int),
+		indices:      make(map[common.Address]uint16),
+		hashes:       make(map[uint16][32]byte),
+		shareHashes:  make(map[uint16][32]byte),
+		first:        make(map[uint16]kyber.Point),
+		disputed:     make(map[uint16]uint16),
+		excluded:     make(map[uint16]bool),
+		stakes:       make(map[uint16]*big.Int),
+		balances:     make(map[uint16]*big.Int),
+		slashed:      new(big.Int),
+	}
+	c.turn = sync.NewCond(&c.mu)
+	return c
+}
+
+// emit must be called with mu held.
+func (c *memContract) emit(e interface{}) {
+	c.events = append(c.events, e)
+	close(c.notify)
+	c.notify = make(chan struct{})
+}
+
+// nextBlock returns the timestamp of the next block, must be called with mu held.
+func (c *memContract) nextBlock() uint64 {
+	timestamp := uint64(time.Now().Add(c.offset).Unix())
+	if timestamp < c.head {
+		return c.head
+	}
+	return timestamp
+}
+
+// mine emits the header of a new block, must be called with mu held.
+func (c *memContract) mine(timestamp uint64) {
+	c.head = timestamp
+	c.emit(&types.Header{Time: timestamp})
+}
+
+// mineEmpty mines empty blocks until the context is done.
+func (c *memContract) mineEmpty(ctx context.Context) {
+	ticker := time.NewTicker(c.blockTime)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			c.mu.Lock()
+			c.mine(c.nextBlock())
+			c.mu.Unlock()
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// tx records the inputs of a transaction and returns its hash, must be called with mu held.
+func (c *memContract) tx(inputs ...*big.Int) common.Hash {
+	hash := common.BigToHash(big.NewInt(int64(len(c.txs) + 1)))
+	c.txs[hash] = inputs
+	return hash
+}
+
+// memChain is the view of a single participant on a memContract.
+type memChain struct {
+	*memContract
+	from     common.Address
+	order    int // Number of participants registering before this one
+	defended int
+	sent     []SentTransaction
+	// Returned by BroadcastShares and Withdraw instead of broadcasting and withdrawing the balance
+	broadcastErr error
+	withdrawErr  error
+}
+
+// record tracks a transaction of the participant, must be called with mu held.
+func (c *memChain) record(method string, hash common.Hash) {
+	c.sent = append(c.sent, SentTransaction{Method: method, Hash: hash})
+}
+
+func (c *memChain) Transactions() []SentTransaction {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]SentTransaction(nil), c.sent...)
+}
+
+func (c *memChain) Subscribe(_ context.Context, events chan<- interface{}) (event.Subscription, error) {
+	c.mu.Lock()
+	next := len(c.events)
+	c.mu.Unlock()
+
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		for {
+			c.mu.Lock()
+			pending, notify := c.events[next:], c.notify
+			c.mu.Unlock()
+
+			for _, e := range pending {
+				select {
+				case events <- e:
+					next++
+				case <-quit:
+					return nil
+				}
+			}
+			if len(pending) == 0 {
+				select {
+				case <-notify:
+				case <-quit:
+					return nil
+				}
+			}
+		}
+	}), nil
+}
+
+func (c *memChain) Register(_ context.Context, pub, possessionProof [2]*big.Int, _ bool, stake *big.Int) (uint16, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for len(c.keys) != c.order {
+		c.turn.Wait()
+	}
+	c.turn.Broadcast()
+
+	c.keys = append(c.keys, pub)
+	c.record("register", c.tx())
+	index := uint16(len(c.keys))
+	c.indices[c.from] = index
+	c.stakes[index] = stake
+	c.registrations = append(c.registrations, &ZKDKGContractRegistration{Sender: c.from, Index: index, PossessionProof: possessionProof})
+	if len(c.keys) == c.participants {
+		c.emit(&ZKDKGContractRegistrationEndLog{})
+	}
+	c.mine(c.nextBlock())
+	return index, nil
+}
+
+func (c *memChain) LatestHeader(context.Context) (*types.Header, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return &types.Header{Time: c.nextBlock()}, nil
+}
+
+func (c *memChain) PublicKeys(context.Context) ([][2]*big.Int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([][2]*big.Int(nil), c.keys...), nil
+}
+
+func (c *memChain) Registrations(context.Context) ([]*ZKDKGContractRegistration, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]*ZKDKGContractRegistration(nil), c.registrations...), nil
+}
+
+func (c *memChain) BroadcastShares(_ context.Context, commitments, shares []*big.Int) error {
+	if c.broadcastErr != nil {
+		return c.broadcastErr
+	}
+
+	points, err := BigToPoints(c.suite, commitments)
+	if err != nil {
+		return err
+	}
+	compressed, err := zk.CompressPoints(points)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	index := c.indices[c.from]
+	hash := [32]byte{}
+	copy(hash[:], crypto.Keccak256(compressed))
+	c.hashes[index] = hash
+	c.shareHashes[index] = sharesHash(shares)
+	c.first[index] = points[0]
+
+	txHash := c.tx(append(append([]*big.Int{big.NewInt(int64(len(commitments)))}, commitments...), shares...)...)
+	c.record("broadcastShares", txHash)
+	c.emit(&ZKDKGContractBroadcastSharesLog{Sender: c.from, BroadcasterIndex: index, Raw: types.Log{TxHash: txHash}})
+
+	timestamp := c.nextBlock()
+	if len(c.hashes) == c.participants {
+		c.phaseEnd = timestamp + c.period
+		c.emit(&ZKDKGContractDistributionEndLog{})
+	}
+	c.mine(timestamp)
+	return nil
+}
+
+// checkDispute reverts like the contract, must be called with mu held.
+func (c *memChain) checkDispute(timestamp uint64, disputeeIndex uint16, shares []*big.Int) error {
+	switch {
+	case timestamp > c.phaseEnd:
+		return &RevertError{Method: "disputeShare", Reason: "not in dispute period"}
+	case c.disputed[disputeeIndex] != 0:
+		return &RevertError{Method: "disputeShare", Reason: "disputee already disputed"}
+	case c.shareHashes[disputeeIndex] != sharesHash(shares):
+		return &RevertError{Method: "disputeShare", Reason: "invalid shares"}
+	}
+	return nil
+}
+
+func (c *memChain) SimulateDisputeShare(_ context.Context, disputeeIndex uint16, shares []*big.Int) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.checkDispute(c.head, disputeeIndex, shares)
+}
+
+func (c *memChain) DisputeShare(_ context.Context, disputeeIndex uint16, shares []*big.Int) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	timestamp := c.nextBlock()
+	if err := c.checkDispute(timestamp, disputeeIndex, shares); err != nil {
+		return err
+	}
+	c.disputed[disputeeIndex] = c.indices[c.from]
+	c.record("disputeShare", c.tx())
+	c.phaseEnd = timestamp + c.period
+	c.emit(&ZKDKGContractDisputeShare{DisputerIndex: c.indices[c.from], DisputeeIndex: disputeeIndex})
+	c.mine(timestamp)
+	return nil
+}
+
+func (c *memChain) DefendShare(context.Context, ShareVerifierProof) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	index := c.indices[c.from]
+	disputer, ok := c.disputed[index]
+	if !ok {
+		return errors.New("not disputed")
+	}
+	delete(c.disputed, index)
+	c.defended++
+	c.record("defendShare", c.tx())
+	c.exclude(disputer)
+	if c.submitted {
+		c.payNodes()
+	}
+	c.mine(c.nextBlock())
+	return nil
+}
+
+func (c *memChain) SubmitPublicKey(_ context.Context, pub [2]*big.Int, _ KeyVerifierProof) error {
+	key, err := zk.PointFromCoordinates(c.suite, pub[0], pub[1])
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.submitted {
+		return errors.New("public key already submitted")
+	}
+	timestamp := c.nextBlock()
+	if timestamp <= c.phaseEnd {
+		return errors.New("dispute period still ongoing")
+	}
+
+	// The proof of the key derivation ties the key to the broadcast first coefficients
+	expected := c.suite.Point().Null()
+	for index, first := range c.first {
+		if !c.excluded[index] {
+			expected.Add(expected, first)
+		}
+	}
+	if !key.Equal(expected) {
+		return errors.New("invalid public key")
+	}
+
+	c.submitted = true
+	txHash := c.tx(pub[0], pub[1])
+	c.record("submitPublicKey", txHash)
+	c.emit(&ZKDKGContractPublicKeySubmission{Raw: types.Log{TxHash: txHash}})
+	c.payNodes()
+	c.mine(timestamp)
+	return nil
+}
+
+// exclude slashes the stake of the participant, or its balance once settled, must be called with mu held.
+func (c *memContract) exclude(index uint16) {
+	c.excluded[index] = true
+	c.emit(&ZKDKGContractExclusion{Index: index})
+
+	amount := new(big.Int)
+	for _, m := range []map[uint16]*big.Int{c.stakes, c.balances} {
+		if v, ok := m[index]; ok {
+			amount.Add(amount, v)
+			delete(m, index)
+		}
+	}
+	if amount.Sign() > 0 {
+		c.slashed.Add(c.slashed, amount)
+		c.emit(&ZKDKGContractSlashing{Index: index, Amount: amount})
+	}
+}
+
+// payNodes credits the remaining participants with their stakes and an equal part of the slashed ones,
+// the remainder going to the one with the lowest index, must be called with mu held.
+func (c *memContract) payNodes() {
+	c.settled = true
+
+	var remaining []uint16
+	for index := uint16(1); index <= uint16(len(c.registrations)); index++ {
+		if !c.excluded[index] {
+			remaining = append(remaining, index)
+		}
+	}
+	if len(remaining) == 0 {
+		return
+	}
+
+	reward, remainder := new(big.Int).DivMod(c.slashed, big.NewInt(int64(len(remaining))), new(big.Int))
+	c.slashed = new(big.Int)
+	for _, index := range remaining {
+		credit := new(big.Int).Add(reward, remainder)
+		if stake, ok := c.stakes[index]; ok {
+			credit.Add(credit, stake)
+			delete(c.stakes, index)
+		}
+		if balance, ok := c.balances[index]; ok {
+			credit.Add(credit, balance)
+		}
+		c.balances[index] = credit
+		remainder = new(big.Int)
+	}
+}
+
+// settle aborts a stalled run and pays out the stakes like settle of the contract, must be called with mu held.
+func (c *memContract) settle() {
+	c.emit(&ZKDKGContractAbortion{})
+	c.payNodes()
+	c.mine(c.nextBlock())
+}
+
+func (c *memChain) Settled(context.Context) (bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.settled, nil
+}
+
+func (c *memChain) Withdraw(context.Context) (*big.Int, error) {
+	if c.withdrawErr != nil {
+		return nil, c.withdrawErr
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	index := c.indices[c.from]
+	balance, ok := c.balances[index]
+	if !ok || balance.Sign() == 0 {
+		return new(big.Int), nil
+	}
+	delete(c.balances, index)
+	c.record("withdraw", c.tx())
+	c.mine(c.nextBlock())
+	return balance, nil
+}
+
+func (c *memChain) BroadcastInputs(_ context.Context, txHash common.Hash) ([]*big.Int, []*big.Int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	inputs := c.txs[txHash]
+	n := int(inputs[0].Int64())
+	return inputs[1 : n+1], inputs[n+1:], nil
+}
+
+func (c *memChain) SubmittedPublicKey(_ context.Context, txHash common.Hash) ([2]*big.Int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	inputs := c.txs[txHash]
+	return [2]*big.Int{inputs[0], inputs[1]}, nil
+}
+
+func (c *memChain) CommitmentHash(_ context.Context, index uint16) ([32]byte, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.hashes[index], nil
+}
+
+func (c *memChain) ShareHash(_ context.Context, index uint16) ([32]byte, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.shareHashes[index], nil
+}
+
+func (c *memChain) PhaseEnd(context.Context) (uint64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.phaseEnd, nil
+}
+
+func (c *memChain) ExpiredDisputes(context.Context) ([]uint16, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var expired []uint16
+	if c.head > c.phaseEnd {
+		for index := range c.disputed {
+			expired = append(expired, index)
+		}
+	}
+	return expired, nil
+}
+
+// stubProver returns empty proofs, which memContract accepts.
+type stubProver struct{}
+
+func (stubProver) ComputeWitness(context.Context, ProofType, zk.Witness) error { return nil }
+
+func (stubProver) GenerateProof(context.Context, ProofType) (*Proof, error) {
+	return &Proof{Proof: &ZKProof{}}, nil
+}
+
+func (stubProver) Close() {}
+
+// failingKeyProver fails to generate proofs of the key derivation.
+type failingKeyProver struct{ stubProver }
+
+func (failingKeyProver) GenerateProof(ctx context.Context, proofType ProofType) (*Proof, error) {
+	if proofType == KeyDerivProof {
+		return nil, errors.New("exit status 1")
+	}
+	return stubProver{}.GenerateProof(ctx, proofType)
+}
+
+// delayedProver takes its time to generate proofs.
+type delayedProver struct {
+	stubProver
+	delay time.Duration
+}
+
+func (p delayedProver) GenerateProof(ctx context.Context, proofType ProofType) (*Proof, error) {
+	select {
+	case <-time.After(p.delay):
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	return p.stubProver.GenerateProof(ctx, proofType)
+}
+
+// submitters returns the indices of the nodes that sent a submission of the public key.
+func submitters(chains []*memChain) []uint16 {
+	var indices []uint16
+	for i, c := range chains {
+		for _, tx := range c.Transactions() {
+			if tx.Method == "submitPublicKey" {
+				indices = append(indices, uint16(i+1))
+			}
+		}
+	}
+	return indices
+}
+
+type generateResult struct {
+	pub kyber.Point
+	err error
+	gen *DistKeyGenerator
+}
+
+// runNodes runs the protocol with one generator per participant on a memContract.
+func runNodes(t *testing.T, contract *memContract, configure func(i int, d *DistKeyGenerator)) ([]generateResult, []*memChain) {
+	suite, participants := contract.suite, contract.participants
+	params := &Params{
+		NoParticipants:   uint16(participants),
+		MinimumThreshold: MinimumThreshold(uint16(participants)),
+		UserThreshold:    DefaultUserThreshold(uint16(participants)),
+		Stake:            contract.stake,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	t.Cleanup(cancel)
+	if contract.blockTime > 0 {
+		go contract.mineEmpty(ctx)
+	}
+
+	results := make([]generateResult, participants)
+	chains := make([]*memChain, participants)
+	var wg sync.WaitGroup
+	for i := range results {
+		chains[i] = &memChain{memContract: contract, from: common.BigToAddress(big.NewInt(int64(i + 1))), order: i}
+		d := newDistKeyGenerator(ctx, suite, params, chains[i], stubProver{}, chains[i].from, suite.Scalar().Pick(suite.RandomStream()))
+		if configure != nil {
+			configure(i, d)
+		}
+
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			pub, err := d.Generate()
+			results[i] = generateResult{pub, err, d}
+		}(i)
+	}
+	wg.Wait()
+
+	return results, chains
+}
+
+func TestGenerateMultipleNodes(t *testing.T) {
+	results, _ := runNodes(t, newMemContract(4), nil)
+
+	for i, r := range results {
+		require.NoError(t, r.err, "node %d", i+1)
+		require.NotNil(t, r.pub)
+		require.True(t, r.pub.Equal(results[0].pub), "node %d computed a different public key", i+1)
+	}
+}
+
+func TestGenerateSingleSubmitter(t *testing.T) {
+	results, chains := runNodes(t, newMemContract(4), nil)
+
+	for i, r := range results {
+		require.NoError(t, r.err, "node %d", i+1)
+		require.True(t, r.pub.Equal(results[0].pub), "node %d computed a different public key", i+1)
+	}
+	// The lowest qualified index submits, the others only check its submission
+	require.Equal(t, []uint16{1}, submitters(chains))
+}
+
+func TestGenerateSubmitterFallback(t *testing.T) {
+	results, chains := runNodes(t, newMemContract(4), func(i int, d *DistKeyGenerator) {
+		d.submissionBackoff = 200 * time.Millisecond
+		if i == 0 {
+			d.polyProver = failingKeyProver{}
+		}
+	})
+
+	for i, r := range results {
+		require.NoError(t, r.err, "node %d", i+1)
+		require.True(t, r.pub.Equal(results[0].pub), "node %d computed a different public key", i+1)
+	}
+	// The next index takes over after its backoff
+	require.Equal(t, []uint16{2}, submitters(chains))
+}
+
+func TestGenerateDefendsDispute(t *testing.T) {
+	// The last node disputes the valid broadcast of the first one
+	const disputer = 3
+	results, chains := runNodes(t, newMemContract(4), func(i int, d *DistKeyGenerator) {
+		d.disputeValid = i == disputer
+	})
+	require.Equal(t, 1, chains[0].defended)
+
+	for i, r := range results {
+		if i == disputer {
+			// The contract excludes the disputer of a defended broadcast
+			require.ErrorIs(t, r.err, ErrExcluded)
+			require.Equal(t, ExitExcluded, ExitCode(r.err))
+			continue
+		}
+		require.NoError(t, r.err, "node %d", i+1)
+		require.True(t, r.pub.Equal(results[0].pub), "node %d computed a different public key", i+1)
+	}
+}
+
+func TestGenerateSkipsRedundantDispute(t *testing.T) {
+	// Two nodes dispute the valid broadcast of the first one, only one of them sends the dispute
+	// The defense is delayed, as a dispute after it would be a new one
+	results, chains := runNodes(t, newMemContract(4), func(i int, d *DistKeyGenerator) {
+		d.disputeValid = i >= 2
+		if i == 0 {
+			d.polyProver = delayedProver{delay: 500 * time.Millisecond}
+		}
+	})
+	require.Equal(t, 1, chains[0].defended)
+
+	disputes := 0
+	for _, c := range chains {
+		for _, tx := range c.Transactions() {
+			if tx.Method == "disputeShare" {
+				disputes++
+			}
+		}
+	}
+	require.Equal(t, 1, disputes)
+
+	for i, r := range results[:2] {
+		require.NoError(t, r.err, "node %d", i+1)
+		require.Len(t, r.gen.disputes, 1)
+		require.Equal(t, DisputeDefended, r.gen.disputes[0].Outcome)
+	}
+}
+
+func TestGenerateBroadcastOnly(t *testing.T) {
+	results, chains := runNodes(t, newMemContract(3), func(_ int, d *DistKeyGenerator) {
+		d.broadcastOnly = true
+	})
+
+	for i, r := range results {
+		require.NoError(t, r.err, "node %d", i+1)
+		require.Nil(t, r.pub)
+	}
+	require.Len(t, chains[0].hashes, 3)
+}
+
+func TestGenerateFollowsBlockTimestamps(t *testing.T) {
+	// The phase end must be judged by the block timestamps, however far the local clock is off
+	for _, offset := range []time.Duration{-time.Hour, time.Hour} {
+		t.Run(offset.String(), func(t *testing.T) {
+			contract := newMemContract(3)
+			contract.offset = offset
+			contract.blockTime = 100 * time.Millisecond
+			results, _ := runNodes(t, contract, nil)
+
+			for i, r := range results {
+				require.NoError(t, r.err, "node %d", i+1)
+				require.True(t, r.pub.Equal(results[0].pub), "node %d computed a different public key", i+1)
+			}
+			require.True(t, contract.submitted)
+		})
+	}
+}
+
+func TestGenerateStakes(t *testing.T) {
+	// The disputer of a valid broadcast is excluded and its stake is shared among the others,
+	// including a node that only broadcast its shares
+	const disputer, broadcaster = 3, 1
+	contract := newMemContract(4)
+	contract.stake = big.NewInt(90)
+	results, chains := runNodes(t, contract, func(i int, d *DistKeyGenerator) {
+		d.disputeValid = i == disputer
+		d.broadcastOnly = i == broadcaster
+	})
+
+	for i, r := range results {
+		result, err := r.gen.Result(r.pub, r.err)
+		require.NoError(t, err)
+
+		if i == disputer {
+			require.ErrorIs(t, r.err, ErrExcluded)
+			require.Empty(t, result.Payout)
+			continue
+		}
+		require.NoError(t, r.err, "node %d", i+1)
+		require.Equal(t, "120", result.Payout)
+		require.Equal(t, []StakeStatus{
+			{Index: 1, Status: StakeReleased, Amount: "90"},
+			{Index: 2, Status: StakeReleased, Amount: "90"},
+			{Index: 3, Status: StakeReleased, Amount: "90"},
+			{Index: 4, Status: StakeSlashed, Amount: "90"},
+		}, result.Stakes)
+		require.Equal(t, "withdraw", chains[i].Transactions()[len(chains[i].Transactions())-1].Method)
+	}
+	require.Empty(t, contract.balances)
+	require.Zero(t, contract.slashed.Sign())
+}
+
+func TestGenerateUnclaimedPayout(t *testing.T) {
+	contract := newMemContract(3)
+	contract.stake = big.NewInt(90)
+	results, _ := runNodes(t, contract, func(i int, d *DistKeyGenerator) {
+		if i == 0 {
+			d.chain.(*memChain).withdrawErr = &TransientError{Method: "withdraw", Err: errors.New("connection refused")}
+		}
+	})
+
+	// The run succeeds, but the stake of the node stays credited to it
+	r := results[0]
+	require.NoError(t, r.err)
+	result, err := r.gen.Result(r.pub, r.err)
+	require.NoError(t, err)
+	require.Empty(t, result.Payout)
+	require.Equal(t, []StakeStatus{
+		{Index: 1, Status: StakeUnclaimed, Amount: "90"},
+		{Index: 2, Status: StakeReleased, Amount: "90"},
+		{Index: 3, Status: StakeReleased, Amount: "90"},
+	}, result.Stakes)
+	require.Equal(t, map[uint16]*big.Int{1: big.NewInt(90)}, contract.balances)
+}
+
+func TestGenerateMissingBroadcast(t *testing.T) {
+	// The third node never broadcasts, so the others wait until the run is aborted
+	for _, settle := range []bool{false, true} {
+		t.Run(fmt.Sprintf("settle=%t", settle), func(t *testing.T) {
+			contract := newMemContract(3)
+			contract.stake = big.NewInt(90)
+			go func() {
+				for {
+					contract.mu.Lock()
+					if len(contract.hashes) == 2 {
+						if settle {
+							contract.settle()
+						} else {
+							// An abortion the contract didn't settle yet
+							contract.emit(&ZKDKGContractAbortion{})
+						}
+						contract.mu.Unlock()
+						return
+					}
+					contract.mu.Unlock()
+					time.Sleep(10 * time.Millisecond)
+				}
+			}()
+			results, chains := runNodes(t, contract, func(i int, d *DistKeyGenerator) {
+				if i == 2 {
+					d.chain.(*memChain).broadcastErr = errors.New("withheld")
+				}
+			})
+
+			status, payout := StakeLocked, ""
+			if settle {
+				status, payout = StakeReleased, "90"
+			}
+			for i, r := range results[:2] {
+				require.ErrorIs(t, r.err, ErrAborted, "node %d", i+1)
+				result, err := r.gen.Result(r.pub, r.err)
+				require.NoError(t, err)
+				require.Equal(t, payout, result.Payout)
+				require.Equal(t, []StakeStatus{
+					{Index: 1, Status: status, Amount: "90"},
+					{Index: 2, Status: status, Amount: "90"},
+					{Index: 3, Status: status, Amount: "90"},
+				}, result.Stakes)
+				require.Equal(t, settle, chains[i].Transactions()[len(chains[i].Transactions())-1].Method == "withdraw")
+			}
+		})
+	}
+}
+
+func TestGenerateResult(t *testing.T) {
+	const disputer = 3
+	results, _ := runNodes(t, newMemContract(4), func(i int, d *DistKeyGenerator) {
+		d.disputeValid = i == disputer
+	})
+	r := results[0]
+	require.NoError(t, r.err)
+
+	dir := t.TempDir()
+	resultFile, shareFile := filepath.Join(dir, "result.json"), filepath.Join(dir, "share.json")
+	require.NoError(t, r.gen.WriteOutputs(r.pub, r.err, resultFile, shareFile))
+
+	b, err := os.ReadFile(resultFile)
+	require.NoError(t, err)
+	var result Result
+	require.NoError(t, json.Unmarshal(b, &result))
+
+	require.Equal(t, uint16(1), result.Index)
+	require.Equal(t, []uint16{1, 2, 3}, result.Qualified)
+	require.Equal(t, []ExcludedParticipant{{Index: 4, Reason: "disputed the valid broadcast of 1"}}, result.Excluded)
+	require.Equal(t, []DisputeResult{{Disputer: 4, Disputee: 1, Outcome: DisputeDefended}}, result.Disputes)
+	require.Equal(t, shareFile, result.ShareFile)
+	require.Equal(t, ExitOK, result.ExitCode)
+
+	var methods []string
+	for _, tx := range result.Transactions {
+		methods = append(methods, tx.Method)
+	}
+	require.Subset(t, methods, []string{"register", "broadcastShares", "defendShare"})
+
+	expected, err := NewPublicKeyEncodings(r.pub)
+	require.NoError(t, err)
+	require.Equal(t, expected, result.PublicKey)
+	x, ok := new(big.Int).SetString(result.PublicKey.Coordinates[0], 10)
+	require.True(t, ok)
+	y, ok := new(big.Int).SetString(result.PublicKey.Coordinates[1], 10)
+	require.True(t, ok)
+	pub, err := zk.PointFromCoordinates(r.gen.suite, x, y)
+	require.NoError(t, err)
+	require.True(t, pub.Equal(r.pub))
+
+	info, err := os.Stat(shareFile)
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0600), info.Mode().Perm())
+	keyShare, err := ReadDistKeyShare(r.gen.suite, shareFile)
+	require.NoError(t, err)
+	require.True(t, keyShare.Public().Equal(r.pub))
+	require.True(t, keyShare.Share.V.Equal(r.gen.distKeyShare.Share.V))
+	require.Equal(t, 0, keyShare.Share.I)
+
+	// The disputer still reports its exclusion
+	d := results[disputer]
+	resultFile = filepath.Join(dir, "disputer.json")
+	require.NoError(t, d.gen.WriteOutputs(d.pub, d.err, resultFile, filepath.Join(dir, "disputer-share.json")))
+	b, err = os.ReadFile(resultFile)
+	require.NoError(t, err)
+	result = Result{}
+	require.NoError(t, json.Unmarshal(b, &result))
+	require.Equal(t, ExitExcluded, result.ExitCode)
+	require.Nil(t, result.PublicKey)
+	require.Empty(t, result.ShareFile)
+	require.NoFileExists(t, filepath.Join(dir, "disputer-share.json"))
+}
@@ -1,17 +0,0 @@
-package dkg
-
-import "client/internal/pkg/group/curve25519"
-
-// Specification: https://eips.ethereum.org/EIPS/eip-2494
-func ParamBabyJubJub() *curve25519.Param {
-	var p curve25519.Param
-	p.Name = "Baby Jubjub"
-	p.P.SetString("21888242871839275222246405745257275088548364400416034343698204186575808495617", 10)
-	p.Q.SetString("21888242871839275222246405745257275088614511777268538073601725287587578984328", 10)
-	p.R = 8
-	p.A.SetInt64(168700)
-	p.D.SetInt64(168696)
-	p.PBX.SetString("16540640123574156134436876038791482806971768689494387082833631921987005038935", 10)
-	p.PBY.SetString("20819045374670962167435360035096875258406992893633759881276124905556507972311", 10)
-	return &p
-}
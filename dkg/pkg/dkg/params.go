@@ -0,0 +1,94 @@
+package dkg
+
+import (
+	"client/pkg/zk"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+)
+
+// Params are the protocol parameters the contract was deployed with.
+type Params struct {
+	NoParticipants   uint16
+	MinimumThreshold uint16   // Number of coefficients of each dealer's polynomial
+	UserThreshold    uint16   // Number of remaining participants below which the protocol aborts
+	PeriodLength     uint16   // Length of the submission and dispute periods in seconds, 0 in evaluation mode
+	Stake            *big.Int // Value to send with the registration
+}
+
+// MinimumThreshold returns the minimum threshold the contract derives from the number of participants.
+func MinimumThreshold(noParticipants uint16) uint16 {
+	return noParticipants/2 + 1
+}
+
+// ReadParams queries the protocol parameters from the contract.
+func ReadParams(opts *bind.CallOpts, contract *ZKDKGContractCaller) (*Params, error) {
+	var p Params
+	var err error
+
+	if p.NoParticipants, err = contract.NoParticipants(opts); err != nil {
+		return nil, fmt.Errorf("number of participants: %w", err)
+	}
+	if p.MinimumThreshold, err = contract.MinimumThreshold(opts); err != nil {
+		return nil, fmt.Errorf("minimum threshold: %w", err)
+	}
+	if p.UserThreshold, err = contract.UserThreshold(opts); err != nil {
+		return nil, fmt.Errorf("user threshold: %w", err)
+	}
+	if p.PeriodLength, err = contract.PeriodLength(opts); err != nil {
+		return nil, fmt.Errorf("period length: %w", err)
+	}
+	if p.Stake, err = contract.STAKE(opts); err != nil {
+		return nil, fmt.Errorf("stake: %w", err)
+	}
+
+	return &p, nil
+}
+
+// CheckCircuits verifies that the compiled programs of the prover were built for the parameters.
+// A mismatch would otherwise only surface as a failing witness computation during a dispute or the key submission.
+func (p *Params) CheckCircuits(prover *Prover) error {
+	polyEval, err := prover.ABI(EvalPolyProof)
+	if err != nil {
+		return fmt.Errorf("%s: %w", EvalPolyProof, err)
+	}
+	threshold, err := zk.PolyEvalThreshold(polyEval)
+	if err != nil {
+		return fmt.Errorf("%s: %w", EvalPolyProof, err)
+	}
+
+	keyDeriv, err := prover.ABI(KeyDerivProof)
+	if err != nil {
+		return fmt.Errorf("%s: %w", KeyDerivProof, err)
+	}
+	participants, err := zk.KeyDerivParticipants(keyDeriv)
+	if err != nil {
+		return fmt.Errorf("%s: %w", KeyDerivProof, err)
+	}
+
+	return p.checkCircuitSizes(participants, threshold)
+}
+
+func (p *Params) checkCircuitSizes(participants, threshold int) error {
+	if participants != int(p.NoParticipants) {
+		return fmt.Errorf(
+			"%s was compiled for %d participants, but the contract has %d; build the artifacts for %d participants with scripts/build.sh",
+			KeyDerivProof, participants, p.NoParticipants, p.NoParticipants,
+		)
+	}
+	if threshold != int(p.MinimumThreshold) {
+		return fmt.Errorf(
+			"%s was compiled for %d commitments, but the contract has a minimum threshold of %d; build the artifacts for %d participants with scripts/build.sh",
+			EvalPolyProof, threshold, p.MinimumThreshold, p.NoParticipants,
+		)
+	}
+	return nil
+}
+
+func (p *Params) String() string {
+	return fmt.Sprintf(
+		"participants %d, minimum threshold %d, user threshold %d, period length %ds, stake %s wei",
+		p.NoParticipants, p.MinimumThreshold, p.UserThreshold, p.PeriodLength, p.Stake,
+	)
+}
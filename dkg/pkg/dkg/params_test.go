@@ -0,0 +1,44 @@
+package dkg
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// writeABI writes an abi.json containing a single array argument of the given length, as zokrates compile would.
+func writeABI(t *testing.T, dir string, proofType ProofType, name string, size int) {
+	abi := fmt.Sprintf(
+		`{"inputs": [{"name": %q, "public": false, "type": "array", "components": {"size": %d, "type": "array", "components": {"size": 2, "type": "field"}}}]}`,
+		name, size,
+	)
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, string(proofType)), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, string(proofType), "abi.json"), []byte(abi), 0644))
+}
+
+func TestCheckCircuits(t *testing.T) {
+	dir := t.TempDir()
+	prover := &Prover{mountSource: dir}
+	params := &Params{NoParticipants: 5, MinimumThreshold: MinimumThreshold(5)}
+
+	require.Error(t, params.CheckCircuits(prover))
+
+	writeABI(t, dir, EvalPolyProof, "commits", 3)
+	writeABI(t, dir, KeyDerivProof, "firstCoefficients", 5)
+	require.NoError(t, params.CheckCircuits(prover))
+
+	// Artifacts built for 4 participants share the threshold, but not the number of participants
+	writeABI(t, dir, KeyDerivProof, "firstCoefficients", 4)
+	err := params.CheckCircuits(prover)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "compiled for 4 participants, but the contract has 5")
+
+	writeABI(t, dir, KeyDerivProof, "firstCoefficients", 5)
+	writeABI(t, dir, EvalPolyProof, "commits", 4)
+	err = params.CheckCircuits(prover)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "compiled for 4 commitments, but the contract has a minimum threshold of 3")
+}
@@ -0,0 +1,72 @@
+package dkg
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"go.dedis.ch/kyber/v3"
+	"go.dedis.ch/kyber/v3/suites"
+)
+
+// possessionDomain separates the challenges of proofs of possession from other uses of the hash.
+const possessionDomain = "zkDKG proof of possession"
+
+// ProvePossession proves the knowledge of the secret key of a participant with a Schnorr proof (c, s), as passed to register.
+// The proof is bound to the Ethereum address registering the key and to the contract, so it can't be replayed by a rogue
+// participant registering the key of somebody else or in another run.
+func ProvePossession(suite suites.Suite, secret kyber.Scalar, sender, contract common.Address) ([2]*big.Int, error) {
+	k := suite.Scalar().Pick(suite.RandomStream())
+	c, err := possessionChallenge(suite, suite.Point().Mul(secret, nil), suite.Point().Mul(k, nil), sender, contract)
+	if err != nil {
+		return [2]*big.Int{}, err
+	}
+	s := suite.Scalar().Add(k, suite.Scalar().Mul(c, secret))
+
+	var proof [2]*big.Int
+	for i, v := range []kyber.Scalar{c, s} {
+		if proof[i], err = ScalarToBig(v); err != nil {
+			return [2]*big.Int{}, fmt.Errorf("scalar to big: %w", err)
+		}
+	}
+	return proof, nil
+}
+
+// VerifyPossession checks a proof of ProvePossession for the public key registered by the sender.
+func VerifyPossession(suite suites.Suite, pub kyber.Point, proof [2]*big.Int, sender, contract common.Address) error {
+	c, err := BigToScalar(suite, proof[0])
+	if err != nil {
+		return fmt.Errorf("challenge: %w", err)
+	}
+	s, err := BigToScalar(suite, proof[1])
+	if err != nil {
+		return fmt.Errorf("response: %w", err)
+	}
+
+	// s*G - c*P recovers the commitment k*G of an honest prover
+	commitment := suite.Point().Sub(suite.Point().Mul(s, nil), suite.Point().Mul(c, pub))
+	expected, err := possessionChallenge(suite, pub, commitment, sender, contract)
+	if err != nil {
+		return err
+	}
+	if !expected.Equal(c) {
+		return errors.New("invalid proof of possession")
+	}
+	return nil
+}
+
+func possessionChallenge(suite suites.Suite, pub, commitment kyber.Point, sender, contract common.Address) (kyber.Scalar, error) {
+	h := suite.Hash()
+	h.Write([]byte(possessionDomain))
+	for _, p := range []kyber.Point{pub, commitment} {
+		b, err := p.MarshalBinary()
+		if err != nil {
+			return nil, fmt.Errorf("marshal point: %w", err)
+		}
+		h.Write(b)
+	}
+	h.Write(sender.Bytes())
+	h.Write(contract.Bytes())
+	return suite.Scalar().SetBytes(h.Sum(nil)), nil
+}
@@ -0,0 +1,56 @@
+package dkg
+
+import (
+	"client/internal/pkg/group/curve25519"
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPossession(t *testing.T) {
+	suite := curve25519.NewBlakeSHA256BabyJubJub(false)
+	secret := suite.Scalar().Pick(suite.RandomStream())
+	pub := suite.Point().Mul(secret, nil)
+	sender, contract := common.HexToAddress("0x01"), common.HexToAddress("0x02")
+
+	proof, err := ProvePossession(suite, secret, sender, contract)
+	require.NoError(t, err)
+	require.NoError(t, VerifyPossession(suite, pub, proof, sender, contract))
+
+	// The proof is bound to the key, the sender and the contract
+	other := suite.Point().Pick(suite.RandomStream())
+	require.Error(t, VerifyPossession(suite, other, proof, sender, contract))
+	require.Error(t, VerifyPossession(suite, pub, proof, contract, contract))
+	require.Error(t, VerifyPossession(suite, pub, proof, sender, sender))
+
+	tampered := [2]*big.Int{proof[0], new(big.Int).Add(proof[1], big.NewInt(1))}
+	require.Error(t, VerifyPossession(suite, pub, tampered, sender, contract))
+	require.Error(t, VerifyPossession(suite, pub, [2]*big.Int{proof[0], new(big.Int).Lsh(big.NewInt(1), 256)}, sender, contract))
+}
+
+func TestCollectParticipantsRejectsForgedPossession(t *testing.T) {
+	contract := newMemContract(3)
+	suite := contract.suite
+	params := &Params{NoParticipants: 3, MinimumThreshold: MinimumThreshold(3), UserThreshold: DefaultUserThreshold(3), Stake: new(big.Int)}
+	ctx := context.Background()
+
+	generators := make([]*DistKeyGenerator, contract.participants)
+	for i := range generators {
+		c := &memChain{memContract: contract, from: common.BigToAddress(big.NewInt(int64(i + 1))), order: i}
+		generators[i] = newDistKeyGenerator(ctx, suite, params, c, stubProver{}, c.from, suite.Scalar().Pick(suite.RandomStream()))
+		require.NoError(t, generators[i].Register(ctx))
+	}
+	require.NoError(t, generators[0].CollectParticipants())
+
+	// Participant 2 replays the proof of participant 3 for its own registration
+	contract.registrations[1].PossessionProof = contract.registrations[2].PossessionProof
+	err := generators[0].CollectParticipants()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "participants [2] failed to prove the possession of their keys")
+
+	// A participant doesn't check its own proof
+	require.NoError(t, generators[1].CollectParticipants())
+}
@@ -1,11 +1,11 @@
 package dkg
 
 import (
+	"client/pkg/zk"
 	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
-	"math/big"
 	"os"
 	"os/user"
 	"path"
@@ -45,14 +45,22 @@ func NewProver(mountSource string, pipe *os.File) (*Prover, error) {
 	}, nil
 }
 
-func (p *Prover) ComputeWitness(ctx context.Context, proofType ProofType, args []*big.Int) error {
-	var a []string
-	for _, arg := range args {
-		a = append(a, arg.String())
-	}
-
+// ComputeWitness runs zokrates compute-witness on the witness of the given program.
+// The arguments are streamed to the container over its standard input, as command line arguments
+// would expose the private ones to everyone able to list processes or inspect containers.
+// The witness is removed again if computing it fails, GenerateProof removes it otherwise.
+func (p *Prover) ComputeWitness(ctx context.Context, proofType ProofType, witness zk.Witness) (err error) {
 	basePath := path.Join("./build", string(proofType))
 
+	if err := p.createWitness(proofType); err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			os.Remove(p.witnessPath(proofType))
+		}
+	}()
+
 	cmd := []string{
 		"zokrates",
 		"compute-witness",
@@ -62,7 +70,7 @@ func (p *Prover) ComputeWitness(ctx context.Context, proofType ProofType, args [
 		path.Join(basePath, "out"),
 		"-s",
 		path.Join(basePath, "abi.json"),
-		"-a",
+		"--stdin",
 	}
 
 	user, err := user.Current()
@@ -73,7 +81,10 @@ func (p *Prover) ComputeWitness(ctx context.Context, proofType ProofType, args [
 	resp, err := p.dc.ContainerCreate(ctx, &container.Config{
 		Image: zokratesImage,
 		User: fmt.Sprintf("%s:%s", user.Uid, user.Gid),
-		Cmd:   append(cmd, a...),
+		Cmd:   cmd,
+		AttachStdin: true,
+		OpenStdin:   true,
+		StdinOnce:   true,
 	}, &container.HostConfig{
 		Binds: []string{
 			p.bind,
@@ -82,10 +93,24 @@ func (p *Prover) ComputeWitness(ctx context.Context, proofType ProofType, args [
 	if err != nil {
 		return fmt.Errorf("create container: %w", err)
 	}
+
+	stdin, err := p.dc.ContainerAttach(ctx, resp.ID, types.ContainerAttachOptions{Stream: true, Stdin: true})
+	if err != nil {
+		return fmt.Errorf("attach container: %w", err)
+	}
+	defer stdin.Close()
+
 	if err := p.dc.ContainerStart(ctx, resp.ID, types.ContainerStartOptions{}); err != nil {
 		return fmt.Errorf("start container: %w", err)
 	}
 
+	if _, err := witness.WriteTo(stdin.Conn); err != nil {
+		return fmt.Errorf("write arguments: %w", err)
+	}
+	if err := stdin.CloseWrite(); err != nil {
+		return fmt.Errorf("close stdin: %w", err)
+	}
+
 	statusCh, errCh := p.dc.ContainerWait(ctx, resp.ID, container.WaitConditionNotRunning)
 	select {
 	case err := <-errCh:
@@ -107,8 +132,36 @@ func (p *Prover) ComputeWitness(ctx context.Context, proofType ProofType, args [
 	return nil
 }
 
-func (p *Prover) GenerateProof(ctx context.Context, proofType ProofType) (*Proof, error) {
+// createWitness creates the empty witness file readable only by its owner, since the witness holds the private
+// arguments in plain. Zokrates truncates the file when writing to it, which keeps its permissions.
+func (p *Prover) createWitness(proofType ProofType) error {
+	file, err := os.OpenFile(p.witnessPath(proofType), os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return fmt.Errorf("create witness: %w", err)
+	}
+	defer file.Close()
+
+	// A witness left behind by an earlier run may have been created with wider permissions
+	if err := file.Chmod(0600); err != nil {
+		return fmt.Errorf("restrict witness: %w", err)
+	}
+	return nil
+}
+
+func (p *Prover) witnessPath(proofType ProofType) string {
+	return path.Join(p.mountSource, string(proofType), "witness")
+}
+
+// GenerateProof runs zokrates generate-proof on the witness computed by ComputeWitness and removes the witness,
+// whether the proof is generated or not.
+func (p *Prover) GenerateProof(ctx context.Context, proofType ProofType) (proof *Proof, err error) {
 	basePath := path.Join("./build", string(proofType))
+	defer func() {
+		if removeErr := os.Remove(p.witnessPath(proofType)); removeErr != nil && !os.IsNotExist(removeErr) && err == nil {
+			proof, err = nil, fmt.Errorf("remove witness: %w", removeErr)
+		}
+	}()
+
 	user, err := user.Current()
 	if err != nil {
 		return nil, fmt.Errorf("get user: %w", err)
@@ -176,7 +229,6 @@ func (p *Prover) GenerateProof(ctx context.Context, proofType ProofType) (*Proof
 		return nil, fmt.Errorf("read file: %w", err)
 	}
 
-	var proof *Proof
 	if err := json.Unmarshal(file, &proof); err != nil {
 		return nil, fmt.Errorf("unmarshal proof: %w", err)
 	}
@@ -184,6 +236,17 @@ func (p *Prover) GenerateProof(ctx context.Context, proofType ProofType) (*Proof
 	return proof, nil
 }
 
+// ABI reads the interface of the compiled program of the given proof type from the mounted build directory.
+func (p *Prover) ABI(proofType ProofType) (*zk.ABI, error) {
+	file, err := os.Open(path.Join(p.mountSource, string(proofType), "abi.json"))
+	if err != nil {
+		return nil, fmt.Errorf("open abi: %w", err)
+	}
+	defer file.Close()
+
+	return zk.ReadABI(file)
+}
+
 func (p *Prover) Close() {
 	if p.pipe != nil {
 		p.pipe.Close()
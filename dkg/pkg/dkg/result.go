@@ -0,0 +1,187 @@
+package dkg
+
+import (
+	"client/pkg/zk"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	log "github.com/sirupsen/logrus"
+	"go.dedis.ch/kyber/v3"
+)
+
+// Outcomes of disputes.
+const (
+	DisputePending    = "pending"
+	DisputeDefended   = "defended"   // The dealer proved its share, the disputer got excluded
+	DisputeUndefended = "undefended" // The dealer didn't defend in time and got excluded
+)
+
+// Result is the outcome of a run of the protocol from the view of a node, written by WriteOutputs.
+type Result struct {
+	Index        uint16                `json:"index"`
+	PublicKey    *PublicKeyEncodings   `json:"publicKey,omitempty"`
+	Qualified    []uint16              `json:"qualified"`
+	Excluded     []ExcludedParticipant `json:"excluded"`
+	Disputes     []DisputeResult       `json:"disputes"`
+	Transactions []SentTransaction     `json:"transactions"`
+	Stakes       []StakeStatus         `json:"stakes"`
+	Payout       string                `json:"payout,omitempty"` // Wei withdrawn by the node once the protocol completed or aborted
+	ShareFile    string                `json:"shareFile,omitempty"`
+	Error        string                `json:"error,omitempty"`
+	ExitCode     int                   `json:"exitCode"`
+}
+
+// PublicKeyEncodings are the encodings of the distributed public key.
+type PublicKeyEncodings struct {
+	Compressed  string    `json:"compressed"`  // Decimal, as accepted by registerCompressed of the contract
+	Coordinates [2]string `json:"coordinates"` // Decimal x and y
+	Hex         string    `json:"hex"`         // Binary encoding of kyber
+}
+
+type ExcludedParticipant struct {
+	Index  uint16 `json:"index"`
+	Reason string `json:"reason"`
+}
+
+type DisputeResult struct {
+	Disputer uint16 `json:"disputer"`
+	Disputee uint16 `json:"disputee"`
+	Outcome  string `json:"outcome"`
+}
+
+// SentTransaction is a transaction the node sent to the contract.
+type SentTransaction struct {
+	Method   string      `json:"method"`
+	Hash     common.Hash `json:"hash"`
+	GasUsed  uint64      `json:"gasUsed"`
+	Reverted bool        `json:"reverted,omitempty"`
+}
+
+func NewPublicKeyEncodings(pub kyber.Point) (*PublicKeyEncodings, error) {
+	x, y, err := zk.Coordinates(pub)
+	if err != nil {
+		return nil, fmt.Errorf("coordinates: %w", err)
+	}
+	b, err := pub.MarshalBinary()
+	if err != nil {
+		return nil, fmt.Errorf("marshal: %w", err)
+	}
+	return &PublicKeyEncodings{
+		Compressed:  zk.Compress(x, y).String(),
+		Coordinates: [2]string{x.String(), y.String()},
+		Hex:         hex.EncodeToString(b),
+	}, nil
+}
+
+// recordDispute tracks a dispute until its outcome is known.
+func (d *DistKeyGenerator) recordDispute(disputer, disputee uint16) {
+	d.disputes = append(d.disputes, &DisputeResult{Disputer: disputer, Disputee: disputee, Outcome: DisputePending})
+}
+
+// recordExclusion derives the reason of an exclusion from the pending disputes and settles them.
+func (d *DistKeyGenerator) recordExclusion(index uint16) {
+	if _, ok := d.excluded[index]; ok {
+		return
+	}
+
+	reason := "excluded by the contract"
+	for _, dispute := range d.disputes {
+		if dispute.Outcome != DisputePending {
+			continue
+		}
+		switch index {
+		case dispute.Disputer:
+			dispute.Outcome = DisputeDefended
+			reason = fmt.Sprintf("disputed the valid broadcast of %d", dispute.Disputee)
+		case dispute.Disputee:
+			dispute.Outcome = DisputeUndefended
+			reason = fmt.Sprintf("didn't defend against the dispute of %d", dispute.Disputer)
+		default:
+			continue
+		}
+		log.Infof("Dispute of %d against dealer %d settled: %s, excluding %d", dispute.Disputer, dispute.Disputee, dispute.Outcome, index)
+	}
+	d.excluded[index] = reason
+}
+
+// Result summarizes the run that ended with the given public key and error.
+// It must not be called while Generate is running.
+func (d *DistKeyGenerator) Result(pub kyber.Point, runErr error) (*Result, error) {
+	r := &Result{
+		Index:        d.index,
+		Qualified:    []uint16{},
+		Excluded:     []ExcludedParticipant{},
+		Disputes:     []DisputeResult{},
+		Transactions: append([]SentTransaction{}, d.chain.Transactions()...),
+		Stakes:       d.stakeStatuses(),
+		ExitCode:     ExitCode(runErr),
+	}
+	if runErr != nil {
+		r.Error = runErr.Error()
+	}
+
+	if d.payout != nil {
+		r.Payout = d.payout.String()
+	}
+
+	if pub != nil {
+		encodings, err := NewPublicKeyEncodings(pub)
+		if err != nil {
+			return nil, fmt.Errorf("public key: %w", err)
+		}
+		r.PublicKey = encodings
+	}
+
+	for index := range d.participants {
+		if reason, ok := d.excluded[index]; ok {
+			r.Excluded = append(r.Excluded, ExcludedParticipant{Index: index, Reason: reason})
+		} else {
+			r.Qualified = append(r.Qualified, index)
+		}
+	}
+	sort.Slice(r.Qualified, func(i, j int) bool { return r.Qualified[i] < r.Qualified[j] })
+	sort.Slice(r.Excluded, func(i, j int) bool { return r.Excluded[i].Index < r.Excluded[j].Index })
+
+	for _, dispute := range d.disputes {
+		r.Disputes = append(r.Disputes, *dispute)
+	}
+	return r, nil
+}
+
+// Write writes the result to the file with the given name.
+func (r *Result) Write(name string) error {
+	b, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode result: %w", err)
+	}
+	if err := os.WriteFile(name, append(b, '\n'), 0644); err != nil {
+		return fmt.Errorf("write result: %w", err)
+	}
+	return nil
+}
+
+// WriteOutputs writes the share of a successful run to shareFile and the result of the run to resultFile,
+// skipping files without a name.
+func (d *DistKeyGenerator) WriteOutputs(pub kyber.Point, runErr error, resultFile, shareFile string) error {
+	written := ""
+	if shareFile != "" && runErr == nil && d.distKeyShare != nil {
+		if err := d.distKeyShare.Write(shareFile); err != nil {
+			return err
+		}
+		written = shareFile
+	}
+
+	if resultFile == "" {
+		return nil
+	}
+	r, err := d.Result(pub, runErr)
+	if err != nil {
+		return err
+	}
+	r.ShareFile = written
+	return r.Write(resultFile)
+}
@@ -0,0 +1,132 @@
+package dkg
+
+import (
+	"context"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+	log "github.com/sirupsen/logrus"
+)
+
+// RetryPolicy repeats failed operations, doubling the backoff after every attempt.
+type RetryPolicy struct {
+	Attempts   int // Including the first one, a policy without attempts uses the default
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+}
+
+var (
+	// DefaultRetryPolicy rides out brief outages of the RPC endpoints.
+	DefaultRetryPolicy = RetryPolicy{Attempts: 5, MinBackoff: time.Second, MaxBackoff: 30 * time.Second}
+	// DefaultProverRetryPolicy doesn't retry, as proofs mostly fail for good.
+	DefaultProverRetryPolicy = RetryPolicy{Attempts: 1}
+)
+
+func (p RetryPolicy) orDefault(def RetryPolicy) RetryPolicy {
+	if p.Attempts <= 0 {
+		return def
+	}
+	return p
+}
+
+// retry runs fn until it succeeds, fails with an error retryable rejects or the attempts of the policy are used up.
+func retry[T any](ctx context.Context, p RetryPolicy, retryable func(error) bool, fn func() (T, error)) (T, error) {
+	backoff := p.MinBackoff
+	for attempt := 1; ; attempt++ {
+		v, err := fn()
+		if err == nil || attempt >= p.Attempts || !retryable(err) {
+			return v, err
+		}
+
+		log.Warnf("Attempt %d of %d failed, retrying in %s: %v", attempt, p.Attempts, backoff, err)
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return v, err
+		}
+
+		if backoff *= 2; backoff > p.MaxBackoff {
+			backoff = p.MaxBackoff
+		}
+	}
+}
+
+// retryChain retries the calls that fail with a TransientError.
+// Transactions aren't repeated as a whole, contractChain.transact only retries sending the signed transaction.
+type retryChain struct {
+	chain
+	policy RetryPolicy
+}
+
+func retryTransient[T any](ctx context.Context, c *retryChain, fn func() (T, error)) (T, error) {
+	return retry(ctx, c.policy, IsTransient, fn)
+}
+
+// retryView runs a call of contractChain that doesn't change any state, retrying it on transient failures.
+func retryView[T any](ctx context.Context, c *contractChain, method string, fn func() (T, error)) (T, error) {
+	return retry(ctx, c.policy, IsTransient, func() (T, error) {
+		v, err := fn()
+		return v, classifyError(ctx, method, err)
+	})
+}
+
+func retryTransientErr(ctx context.Context, c *retryChain, fn func() error) error {
+	_, err := retryTransient(ctx, c, func() (struct{}, error) { return struct{}{}, fn() })
+	return err
+}
+
+func (c *retryChain) Subscribe(ctx context.Context, events chan<- interface{}) (event.Subscription, error) {
+	return retryTransient(ctx, c, func() (event.Subscription, error) { return c.chain.Subscribe(ctx, events) })
+}
+
+func (c *retryChain) LatestHeader(ctx context.Context) (*types.Header, error) {
+	return retryTransient(ctx, c, func() (*types.Header, error) { return c.chain.LatestHeader(ctx) })
+}
+
+func (c *retryChain) PublicKeys(ctx context.Context) ([][2]*big.Int, error) {
+	return retryTransient(ctx, c, func() ([][2]*big.Int, error) { return c.chain.PublicKeys(ctx) })
+}
+
+func (c *retryChain) Registrations(ctx context.Context) ([]*ZKDKGContractRegistration, error) {
+	return retryTransient(ctx, c, func() ([]*ZKDKGContractRegistration, error) { return c.chain.Registrations(ctx) })
+}
+
+func (c *retryChain) SimulateDisputeShare(ctx context.Context, disputeeIndex uint16, shares []*big.Int) error {
+	return retryTransientErr(ctx, c, func() error { return c.chain.SimulateDisputeShare(ctx, disputeeIndex, shares) })
+}
+
+func (c *retryChain) BroadcastInputs(ctx context.Context, txHash common.Hash) ([]*big.Int, []*big.Int, error) {
+	var shares []*big.Int
+	commitments, err := retryTransient(ctx, c, func() (commitments []*big.Int, err error) {
+		commitments, shares, err = c.chain.BroadcastInputs(ctx, txHash)
+		return commitments, err
+	})
+	return commitments, shares, err
+}
+
+func (c *retryChain) SubmittedPublicKey(ctx context.Context, txHash common.Hash) ([2]*big.Int, error) {
+	return retryTransient(ctx, c, func() ([2]*big.Int, error) { return c.chain.SubmittedPublicKey(ctx, txHash) })
+}
+
+func (c *retryChain) CommitmentHash(ctx context.Context, index uint16) ([32]byte, error) {
+	return retryTransient(ctx, c, func() ([32]byte, error) { return c.chain.CommitmentHash(ctx, index) })
+}
+
+func (c *retryChain) ShareHash(ctx context.Context, index uint16) ([32]byte, error) {
+	return retryTransient(ctx, c, func() ([32]byte, error) { return c.chain.ShareHash(ctx, index) })
+}
+
+func (c *retryChain) PhaseEnd(ctx context.Context) (uint64, error) {
+	return retryTransient(ctx, c, func() (uint64, error) { return c.chain.PhaseEnd(ctx) })
+}
+
+func (c *retryChain) Settled(ctx context.Context) (bool, error) {
+	return retryTransient(ctx, c, func() (bool, error) { return c.chain.Settled(ctx) })
+}
+
+func (c *retryChain) ExpiredDisputes(ctx context.Context) ([]uint16, error) {
+	return retryTransient(ctx, c, func() ([]uint16, error) { return c.chain.ExpiredDisputes(ctx) })
+}
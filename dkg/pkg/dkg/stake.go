@@ -0,0 +1,74 @@
+package dkg
+
+import (
+	"math/big"
+	"sort"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// Statuses of the stakes of participants.
+const (
+	StakeLocked   = "locked"   // Staked at registration until the contract settles the protocol
+	StakeSlashed  = "slashed"  // Forfeited by the exclusion of the participant
+	StakeReleased = "released" // Credited back together with a part of the slashed stakes
+	// Released, but the node failed to withdraw it, which can be repeated through withdraw of the contract
+	StakeUnclaimed = "unclaimed"
+)
+
+type StakeStatus struct {
+	Index  uint16 `json:"index"`
+	Status string `json:"status"`
+	Amount string `json:"amount"` // Wei
+}
+
+// recordSlashing tracks the stake the contract slashed from an excluded participant.
+func (d *DistKeyGenerator) recordSlashing(index uint16, amount *big.Int) {
+	log.Warnf("Slashed stake of %s wei of participant %d", amount, index)
+	d.slashed[index] = amount
+}
+
+// claimPayout withdraws the stake and reward the contract credited to the node once the protocol completed or aborted.
+// Stakes the contract didn't pay out yet stay locked until settle of the contract is called.
+// A failed withdrawal doesn't fail the run, as it can be repeated through withdraw of the contract.
+func (d *DistKeyGenerator) claimPayout() {
+	settled, err := d.chain.Settled(d.ctx)
+	if err != nil {
+		log.Warnf("Failed to check whether the contract settled the stakes, leaving the payout: %v", err)
+		return
+	}
+	if !settled {
+		log.Warn("The contract didn't settle the stakes yet, they stay locked until settle of the contract is called")
+		return
+	}
+	d.released = true
+
+	amount, err := d.chain.Withdraw(d.ctx)
+	if err != nil {
+		log.Warnf("Failed to withdraw the payout, it stays credited to the node: %v", err)
+		return
+	}
+	d.settled = true
+	d.payout = amount
+	if amount.Sign() > 0 {
+		log.Infof("Withdrew payout of %s wei", amount)
+	}
+}
+
+// stakeStatuses returns the status of the stake of every participant.
+func (d *DistKeyGenerator) stakeStatuses() []StakeStatus {
+	statuses := []StakeStatus{}
+	for index := range d.participants {
+		status := StakeStatus{Index: index, Status: StakeLocked, Amount: d.params.Stake.String()}
+		if amount, ok := d.slashed[index]; ok {
+			status.Status, status.Amount = StakeSlashed, amount.String()
+		} else if index == d.index && d.released && !d.settled {
+			status.Status = StakeUnclaimed
+		} else if d.released {
+			status.Status = StakeReleased
+		}
+		statuses = append(statuses, status)
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Index < statuses[j].Index })
+	return statuses
+}
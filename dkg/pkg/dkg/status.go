@@ -0,0 +1,138 @@
+package dkg
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Phase mirrors the Phase enum of the contract.
+type Phase uint8
+
+const (
+	PhaseUninitialized Phase = iota
+	PhaseRegister
+	PhaseBroadcastSubmit
+	PhaseBroadcastDispute
+)
+
+func (p Phase) String() string {
+	switch p {
+	case PhaseUninitialized:
+		return "UNINITIALIZED"
+	case PhaseRegister:
+		return "REGISTER"
+	case PhaseBroadcastSubmit:
+		return "BROADCAST_SUBMIT"
+	case PhaseBroadcastDispute:
+		return "BROADCAST_DISPUTE"
+	}
+	return fmt.Sprintf("Phase(%d)", uint8(p))
+}
+
+func (p Phase) MarshalText() ([]byte, error) {
+	return []byte(p.String()), nil
+}
+
+// pointInFuture mirrors POINT_IN_FUTURE of the contract, the phase end in evaluation mode.
+const pointInFuture = 7258118400
+
+// Status is a snapshot of the state of a deployed contract.
+type Status struct {
+	Params       *Params             `json:"params"`
+	Phase        Phase               `json:"phase"`
+	PhaseEnd     *time.Time          `json:"phaseEnd"` // nil if the phase doesn't end at a fixed time
+	Participants []ParticipantStatus `json:"participants"`
+	Disputes     []DisputeStatus     `json:"disputes"`
+
+	// Indices of disputed participants that haven't defended their shares yet.
+	// They are excluded once the dispute period expires.
+	ExpiredDisputes []uint16 `json:"expiredDisputes"`
+}
+
+// ParticipantStatus describes a registered participant.
+type ParticipantStatus struct {
+	Index       uint16         `json:"index"`
+	Address     common.Address `json:"address"`
+	Broadcasted bool           `json:"broadcasted"`
+}
+
+// DisputeStatus describes a dispute raised since the deployment of the contract.
+type DisputeStatus struct {
+	Block         uint64 `json:"block"`
+	DisputerIndex uint16 `json:"disputerIndex"`
+	DisputeeIndex uint16 `json:"disputeeIndex"`
+}
+
+// QueryStatus collects the state of the contract, filtering its events from the given block on.
+func QueryStatus(ctx context.Context, contract *ZKDKGContract, fromBlock uint64) (*Status, error) {
+	opts := &bind.CallOpts{Context: ctx}
+
+	params, err := ReadParams(opts, &contract.ZKDKGContractCaller)
+	if err != nil {
+		return nil, fmt.Errorf("read params: %w", err)
+	}
+	s := &Status{Params: params}
+
+	phase, err := contract.Phase(opts)
+	if err != nil {
+		return nil, fmt.Errorf("phase: %w", err)
+	}
+	s.Phase = Phase(phase)
+
+	phaseEnd, err := contract.PhaseEnd(opts)
+	if err != nil {
+		return nil, fmt.Errorf("phase end: %w", err)
+	}
+	if phaseEnd != math.MaxUint64 && phaseEnd != pointInFuture {
+		end := time.Unix(int64(phaseEnd), 0)
+		s.PhaseEnd = &end
+	}
+
+	pks, err := contract.PublicKeys(opts)
+	if err != nil {
+		return nil, fmt.Errorf("public keys: %w", err)
+	}
+	for i := range pks {
+		address, err := contract.Addresses(opts, big.NewInt(int64(i)))
+		if err != nil {
+			return nil, fmt.Errorf("address of participant %d: %w", i+1, err)
+		}
+		hash, err := contract.CommitmentHashes(opts, address)
+		if err != nil {
+			return nil, fmt.Errorf("commitment hash of participant %d: %w", i+1, err)
+		}
+		s.Participants = append(s.Participants, ParticipantStatus{
+			Index:       uint16(i + 1),
+			Address:     address,
+			Broadcasted: hash != [32]byte{},
+		})
+	}
+
+	it, err := contract.FilterDisputeShare(&bind.FilterOpts{Start: fromBlock, Context: ctx})
+	if err != nil {
+		return nil, fmt.Errorf("filter disputes: %w", err)
+	}
+	defer it.Close()
+	for it.Next() {
+		s.Disputes = append(s.Disputes, DisputeStatus{
+			Block:         it.Event.Raw.BlockNumber,
+			DisputerIndex: it.Event.DisputerIndex,
+			DisputeeIndex: it.Event.DisputeeIndex,
+		})
+	}
+	if err := it.Error(); err != nil {
+		return nil, fmt.Errorf("iterate disputes: %w", err)
+	}
+
+	if s.ExpiredDisputes, err = contract.ExpiredDisputes(opts); err != nil {
+		return nil, fmt.Errorf("expired disputes: %w", err)
+	}
+
+	return s, nil
+}
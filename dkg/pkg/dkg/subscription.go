@@ -0,0 +1,242 @@
+package dkg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	minResubscribeBackoff = time.Second
+	maxResubscribeBackoff = 30 * time.Second
+
+	// Logs and headers arrive through separate subscriptions and reorganizations replace recent blocks,
+	// so backfilling starts this many blocks before the latest processed one, dropping duplicates.
+	backfillOverlap = 16
+)
+
+// logBackend is the part of Endpoints a logSubscription relies on.
+type logBackend interface {
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+	FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error)
+	SubscribeFilterLogs(ctx context.Context, q ethereum.FilterQuery, ch chan<- types.Log) (ethereum.Subscription, error)
+	SubscribeNewHead(ctx context.Context, ch chan<- *types.Header) (ethereum.Subscription, error)
+}
+
+type logID struct {
+	txHash common.Hash
+	index  uint
+}
+
+// logSubscription delivers the logs of a contract and the headers of new blocks without gaps.
+// When the subscriptions of the endpoint fail, it resubscribes with backoff and backfills the logs
+// of the blocks it missed in the meantime, so a dropped connection doesn't lose events.
+type logSubscription struct {
+	backend logBackend
+	query   ethereum.FilterQuery
+	parse   func(types.Log) (interface{}, error) // Returns nil for logs to skip
+	events  chan<- interface{}
+
+	start      uint64 // First block to deliver logs of
+	head       uint64 // Latest block delivered
+	seen       map[logID]bool
+	logs       chan types.Log
+	headers    chan *types.Header
+	logSub     ethereum.Subscription
+	headSub    ethereum.Subscription
+	minBackoff time.Duration // Doubles with every failed attempt to resubscribe, up to maxBackoff
+	maxBackoff time.Duration
+}
+
+// subscribeLogs delivers the parsed logs matching the query and the headers of new blocks to events.
+// It returns once subscribed, so no log emitted afterwards is missed.
+func subscribeLogs(ctx context.Context, backend logBackend, query ethereum.FilterQuery, parse func(types.Log) (interface{}, error), events chan<- interface{}) (event.Subscription, error) {
+	head, err := backend.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("latest header: %w", err)
+	}
+
+	s := &logSubscription{
+		backend:    backend,
+		query:      query,
+		parse:      parse,
+		events:     events,
+		start:      head.Number.Uint64() + 1,
+		head:       head.Number.Uint64(),
+		seen:       make(map[logID]bool),
+		logs:       make(chan types.Log),
+		headers:    make(chan *types.Header),
+		minBackoff: minResubscribeBackoff,
+		maxBackoff: maxResubscribeBackoff,
+	}
+	if err := s.subscribe(ctx); err != nil {
+		return nil, err
+	}
+
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer s.unsubscribe()
+		// Logs emitted between reading the latest header and subscribing
+		if err := s.backfill(ctx, quit); err == errQuit {
+			return nil
+		} else if err != nil {
+			if err := s.resubscribe(ctx, quit, err); err != nil {
+				return err
+			}
+		}
+		return s.run(ctx, quit)
+	}), nil
+}
+
+func (s *logSubscription) subscribe(ctx context.Context) error {
+	logSub, err := s.backend.SubscribeFilterLogs(ctx, s.query, s.logs)
+	if err != nil {
+		return fmt.Errorf("subscribe logs: %w", err)
+	}
+	headSub, err := s.backend.SubscribeNewHead(ctx, s.headers)
+	if err != nil {
+		logSub.Unsubscribe()
+		return fmt.Errorf("subscribe new heads: %w", err)
+	}
+	s.logSub, s.headSub = logSub, headSub
+	return nil
+}
+
+func (s *logSubscription) unsubscribe() {
+	if s.logSub != nil {
+		s.logSub.Unsubscribe()
+		s.headSub.Unsubscribe()
+		s.logSub, s.headSub = nil, nil
+	}
+}
+
+func (s *logSubscription) run(ctx context.Context, quit <-chan struct{}) error {
+	for {
+		var err error
+		select {
+		case l := <-s.logs:
+			err = s.deliverLog(quit, l)
+		case h := <-s.headers:
+			err = s.deliverHeader(quit, h)
+		case err = <-s.logSub.Err():
+			err = fmt.Errorf("log subscription: %w", err)
+		case err = <-s.headSub.Err():
+			err = fmt.Errorf("head subscription: %w", err)
+		case <-quit:
+			return nil
+		}
+
+		if err == errQuit {
+			return nil
+		} else if err != nil {
+			if err := s.resubscribe(ctx, quit, err); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// errQuit signals that the subscription was closed while delivering.
+var errQuit = errors.New("subscription closed")
+
+// resubscribe subscribes again and backfills the missed logs, retrying with backoff until it succeeds or the subscription is closed.
+func (s *logSubscription) resubscribe(ctx context.Context, quit <-chan struct{}, cause error) error {
+	for backoff := s.minBackoff; ; backoff *= 2 {
+		if backoff > s.maxBackoff {
+			backoff = s.maxBackoff
+		}
+		s.unsubscribe()
+		log.Warnf("Resubscribing to contract events in %s: %v", backoff, cause)
+
+		select {
+		case <-time.After(backoff):
+		case <-quit:
+			return nil
+		}
+
+		if cause = s.subscribe(ctx); cause != nil {
+			continue
+		}
+		if cause = s.backfill(ctx, quit); cause == errQuit {
+			return nil
+		} else if cause != nil {
+			continue
+		}
+		log.Infof("Resubscribed to contract events at block %d", s.head)
+		return nil
+	}
+}
+
+func (s *logSubscription) backfillFrom() uint64 {
+	if s.head < s.start+backfillOverlap {
+		return s.start
+	}
+	return s.head - backfillOverlap
+}
+
+// backfill delivers the logs since the latest processed block that the subscriptions may have missed,
+// followed by the latest header.
+func (s *logSubscription) backfill(ctx context.Context, quit <-chan struct{}) error {
+	head, err := s.backend.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("latest header: %w", err)
+	}
+
+	query := s.query
+	query.FromBlock = new(big.Int).SetUint64(s.backfillFrom())
+	query.ToBlock = head.Number
+	logs, err := s.backend.FilterLogs(ctx, query)
+	if err != nil {
+		return fmt.Errorf("filter logs: %w", err)
+	}
+
+	for _, l := range logs {
+		if err := s.deliverLog(quit, l); err != nil {
+			return err
+		}
+	}
+	return s.deliverHeader(quit, head)
+}
+
+func (s *logSubscription) deliverLog(quit <-chan struct{}, l types.Log) error {
+	id := logID{l.TxHash, l.Index}
+	if l.Removed || l.BlockNumber < s.start || s.seen[id] {
+		return nil
+	}
+
+	s.seen[id] = true
+
+	e, err := s.parse(l)
+	if err != nil {
+		// Retrying wouldn't help, the log doesn't match the ABI
+		log.Errorf("Skipping log %d of transaction %s: %v", l.Index, l.TxHash, err)
+		return nil
+	}
+	return s.deliver(quit, e)
+}
+
+func (s *logSubscription) deliverHeader(quit <-chan struct{}, h *types.Header) error {
+	if n := h.Number.Uint64(); n > s.head {
+		s.head = n
+	}
+	return s.deliver(quit, h)
+}
+
+func (s *logSubscription) deliver(quit <-chan struct{}, e interface{}) error {
+	if e == nil {
+		return nil
+	}
+	select {
+	case s.events <- e:
+		return nil
+	case <-quit:
+		return errQuit
+	}
+}
@@ -0,0 +1,176 @@
+package dkg
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeSub struct {
+	logs    chan<- types.Log
+	headers chan<- *types.Header
+	err     chan error
+	quit    chan struct{}
+	once    sync.Once
+}
+
+func (s *fakeSub) Err() <-chan error { return s.err }
+
+func (s *fakeSub) Unsubscribe() { s.once.Do(func() { close(s.quit) }) }
+
+// fakeLogBackend is a chain that mines one log per block and whose connection can drop.
+type fakeLogBackend struct {
+	mu    sync.Mutex
+	block uint64
+	logs  []types.Log
+	subs  []*fakeSub
+	down  bool
+}
+
+var errDown = errors.New("connection refused")
+
+func (b *fakeLogBackend) header() *types.Header {
+	return &types.Header{Number: new(big.Int).SetUint64(b.block)}
+}
+
+func (b *fakeLogBackend) HeaderByNumber(context.Context, *big.Int) (*types.Header, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.down {
+		return nil, errDown
+	}
+	return b.header(), nil
+}
+
+func (b *fakeLogBackend) FilterLogs(_ context.Context, q ethereum.FilterQuery) ([]types.Log, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.down {
+		return nil, errDown
+	}
+
+	var logs []types.Log
+	for _, l := range b.logs {
+		if l.BlockNumber >= q.FromBlock.Uint64() && l.BlockNumber <= q.ToBlock.Uint64() {
+			logs = append(logs, l)
+		}
+	}
+	return logs, nil
+}
+
+func (b *fakeLogBackend) subscribe(s *fakeSub) (ethereum.Subscription, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.down {
+		return nil, errDown
+	}
+	s.err, s.quit = make(chan error, 1), make(chan struct{})
+	b.subs = append(b.subs, s)
+	return s, nil
+}
+
+func (b *fakeLogBackend) SubscribeFilterLogs(_ context.Context, _ ethereum.FilterQuery, ch chan<- types.Log) (ethereum.Subscription, error) {
+	return b.subscribe(&fakeSub{logs: ch})
+}
+
+func (b *fakeLogBackend) SubscribeNewHead(_ context.Context, ch chan<- *types.Header) (ethereum.Subscription, error) {
+	return b.subscribe(&fakeSub{headers: ch})
+}
+
+func (b *fakeLogBackend) mine() {
+	b.mu.Lock()
+	b.block++
+	l := types.Log{BlockNumber: b.block, TxHash: common.BigToHash(new(big.Int).SetUint64(b.block))}
+	b.logs = append(b.logs, l)
+	header, subs := b.header(), b.subs
+	if b.down {
+		subs = nil
+	}
+	b.mu.Unlock()
+
+	for _, s := range subs {
+		if s.logs != nil {
+			select {
+			case s.logs <- l:
+			case <-s.quit:
+			}
+		} else {
+			select {
+			case s.headers <- header:
+			case <-s.quit:
+			}
+		}
+	}
+}
+
+func (b *fakeLogBackend) drop() {
+	b.mu.Lock()
+	b.down = true
+	subs := b.subs
+	b.subs = nil
+	b.mu.Unlock()
+
+	for _, s := range subs {
+		s.err <- errors.New("connection lost")
+	}
+}
+
+func (b *fakeLogBackend) up() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.down = false
+}
+
+func TestLogSubscriptionBackfills(t *testing.T) {
+	backend := &fakeLogBackend{}
+	backend.mine() // Before subscribing, not delivered
+
+	events := make(chan interface{}, 100)
+	parse := func(l types.Log) (interface{}, error) { return l, nil }
+	sub, err := subscribeLogs(context.Background(), backend, ethereum.FilterQuery{}, parse, events)
+	require.NoError(t, err)
+	defer sub.Unsubscribe()
+
+	backend.mine()
+	backend.drop()
+	backend.mine()
+	backend.mine()
+	backend.up()
+
+	var blocks []uint64
+	timeout := time.After(10 * time.Second)
+	for len(blocks) < 4 {
+		select {
+		case e := <-events:
+			if l, ok := e.(types.Log); ok {
+				blocks = append(blocks, l.BlockNumber)
+				if len(blocks) == 3 {
+					// Resubscribed, as the missed logs were backfilled
+					backend.mine()
+				}
+			}
+		case err := <-sub.Err():
+			t.Fatal(err)
+		case <-timeout:
+			t.Fatalf("received logs of blocks %v", blocks)
+		}
+	}
+	require.Equal(t, []uint64{2, 3, 4, 5}, blocks)
+
+	// No duplicates follow
+	select {
+	case e := <-events:
+		if l, ok := e.(types.Log); ok {
+			t.Fatalf("duplicate log of block %d", l.BlockNumber)
+		}
+	case <-time.After(100 * time.Millisecond):
+	}
+}
@@ -0,0 +1,27 @@
+package dkg
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+)
+
+// DecodeCall decodes the calldata of a transaction to the contract into the called method and its arguments.
+func DecodeCall(contractAbi abi.ABI, data []byte) (*abi.Method, []interface{}, error) {
+	if len(data) < 4 {
+		return nil, nil, errors.New("calldata lacks method id")
+	}
+
+	method, err := contractAbi.MethodById(data[:4])
+	if err != nil {
+		return nil, nil, fmt.Errorf("method by id: %w", err)
+	}
+
+	inputs, err := method.Inputs.Unpack(data[4:])
+	if err != nil {
+		return nil, nil, fmt.Errorf("unpack inputs: %w", err)
+	}
+
+	return method, inputs, nil
+}
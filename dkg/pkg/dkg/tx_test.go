@@ -0,0 +1,44 @@
+package dkg
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeCall(t *testing.T) {
+	contractAbi, err := abi.JSON(strings.NewReader(ZKDKGContractABI))
+	require.NoError(t, err)
+
+	commitments := []*big.Int{big.NewInt(1), big.NewInt(2)}
+	shares := []*big.Int{big.NewInt(3)}
+	data, err := contractAbi.Pack("broadcastShares", commitments, shares)
+	require.NoError(t, err)
+
+	method, inputs, err := DecodeCall(contractAbi, data)
+	require.NoError(t, err)
+	require.Equal(t, "broadcastShares", method.Name)
+	require.Equal(t, []interface{}{commitments, shares}, inputs)
+
+	data, err = contractAbi.Pack("disputeShare", uint16(2), shares)
+	require.NoError(t, err)
+	method, inputs, err = DecodeCall(contractAbi, data)
+	require.NoError(t, err)
+	require.Equal(t, "disputeShare", method.Name)
+	require.Equal(t, uint16(2), inputs[0])
+
+	_, _, err = DecodeCall(contractAbi, data[:3])
+	require.Error(t, err)
+	_, _, err = DecodeCall(contractAbi, []byte{0xde, 0xad, 0xbe, 0xef})
+	require.Error(t, err)
+	_, _, err = DecodeCall(contractAbi, data[:len(data)-1])
+	require.Error(t, err)
+}
+
+func TestPhaseString(t *testing.T) {
+	require.Equal(t, "BROADCAST_SUBMIT", PhaseBroadcastSubmit.String())
+	require.Equal(t, "Phase(7)", Phase(7).String())
+}
@@ -1,7 +1,7 @@
 package dkg
 
 import (
-	"client/internal/pkg/group/curve25519"
+	"client/pkg/zk"
 	"encoding/hex"
 	"errors"
 	"fmt"
@@ -25,10 +25,12 @@ func HexToScalar(suite suites.Suite, hexScalar string) (kyber.Scalar, error) {
 	return s, nil
 }
 
-func PointToBigUncompressed(point kyber.Point) ([2]*big.Int) {
-	p := point.(*curve25519.ProjPoint)
-	x, y := p.GetXY()
-	return [2]*big.Int{&x.V, &y.V}
+func PointToBigUncompressed(point kyber.Point) ([2]*big.Int, error) {
+	x, y, err := zk.Coordinates(point)
+	if err != nil {
+		return [2]*big.Int{}, err
+	}
+	return [2]*big.Int{x, y}, nil
 }
 
 func PointToBig(point kyber.Point) (*big.Int, error) {
@@ -55,7 +57,10 @@ func PointsToBig(points []kyber.Point) ([]*big.Int, error) {
 func BigToPoint(suite suites.Suite, p *big.Int) (kyber.Point, error) {
 	point := suite.Point().Base()
 
-	buf := make([]byte, 32)
+	buf := make([]byte, point.MarshalSize())
+	if p.Sign() < 0 || p.BitLen() > 8*len(buf) {
+		return nil, errors.New("point encoding out of range")
+	}
 	err := point.UnmarshalBinary(p.FillBytes(buf))
 	if err != nil {
 		return nil, err
@@ -63,6 +68,28 @@ func BigToPoint(suite suites.Suite, p *big.Int) (kyber.Point, error) {
 	return point, nil
 }
 
+func ScalarToBig(scalar kyber.Scalar) (*big.Int, error) {
+	b, err := scalar.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+
+	return new(big.Int).SetBytes(b), nil
+}
+
+func BigToScalar(suite suites.Suite, s *big.Int) (kyber.Scalar, error) {
+	scalar := suite.Scalar()
+
+	buf := make([]byte, scalar.MarshalSize())
+	if s.Sign() < 0 || s.BitLen() > 8*len(buf) {
+		return nil, errors.New("scalar encoding out of range")
+	}
+	if err := scalar.UnmarshalBinary(s.FillBytes(buf)); err != nil {
+		return nil, err
+	}
+	return scalar, nil
+}
+
 func BigToPoints(suite suites.Suite, p []*big.Int) ([]kyber.Point, error) {
 	points := make([]kyber.Point, 0)
 	for _, value := range p {
@@ -0,0 +1,87 @@
+package dkg
+
+import (
+	"client/internal/pkg/group/curve25519"
+	"encoding/hex"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Points and scalars are decoded from untrusted on-chain input and configuration files,
+// so malformed encodings have to be rejected without panicking.
+
+func FuzzBigToPoint(f *testing.F) {
+	suite := curve25519.NewBlakeSHA256BabyJubJub(false)
+	base, err := PointToBig(suite.Point().Base())
+	require.NoError(f, err)
+
+	f.Add(base.Bytes(), false)
+	f.Add(base.Bytes(), true)
+	f.Add([]byte{1}, false)
+	f.Add([]byte{}, false)
+	f.Add(make([]byte, 33), false)
+	f.Add(append([]byte{1}, base.Bytes()...), false)
+	f.Fuzz(func(t *testing.T, b []byte, negative bool) {
+		v := new(big.Int).SetBytes(b)
+		if negative {
+			v.Neg(v)
+		}
+
+		p, err := BigToPoint(suite, v)
+		if err != nil {
+			return
+		}
+		require.False(t, negative && v.Sign() != 0)
+
+		e, err := PointToBig(p)
+		require.NoError(t, err)
+		require.Zero(t, v.Cmp(e))
+	})
+}
+
+func FuzzPointToBig(f *testing.F) {
+	suite := curve25519.NewBlakeSHA256BabyJubJub(false)
+
+	f.Add([]byte{})
+	f.Add([]byte{1})
+	f.Add([]byte{0xff, 0xff, 0xff, 0xff})
+	f.Fuzz(func(t *testing.T, s []byte) {
+		p := suite.Point().Mul(suite.Scalar().SetBytes(s), nil)
+
+		v, err := PointToBig(p)
+		require.NoError(t, err)
+
+		q, err := BigToPoint(suite, v)
+		require.NoError(t, err)
+		require.True(t, p.Equal(q))
+
+		points, err := BigToPoints(suite, []*big.Int{v, v})
+		require.NoError(t, err)
+		values, err := PointsToBig(points)
+		require.NoError(t, err)
+		require.Equal(t, []*big.Int{v, v}, values)
+	})
+}
+
+func FuzzHexToScalar(f *testing.F) {
+	suite := curve25519.NewBlakeSHA256BabyJubJub(false)
+
+	f.Add("147f0309b0587059c68ae43949192c6dc2222210d5105777a512dcdd373ce1aa")
+	f.Add("")
+	f.Add("0")
+	f.Add("zz")
+	f.Add(strings.Repeat("ff", 32))
+	f.Fuzz(func(t *testing.T, h string) {
+		s, err := HexToScalar(suite, h)
+		if err != nil {
+			return
+		}
+
+		b, err := s.MarshalBinary()
+		require.NoError(t, err)
+		require.Equal(t, strings.ToLower(h), hex.EncodeToString(b))
+	})
+}
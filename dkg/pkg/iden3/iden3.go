@@ -0,0 +1,96 @@
+// Package iden3 converts points and keys between the BabyJubJub group
+// used by the DKG and the babyjub package of iden3, which is also used by circomlib.
+//
+// Both describe the same curve, but encode points differently:
+// the group package compresses a point to its big-endian y-coordinate with
+// the parity of x in the top bit, whereas iden3 uses the little-endian
+// y-coordinate with the top bit set if x > (p-1)/2.
+// Also note that iden3 derives public keys from the base point B8,
+// while the DKG and ZoKrates use a different generator of the same subgroup.
+// Hence a scalar yields different public keys in both systems,
+// use Base8 to compute public keys as iden3 does.
+package iden3
+
+import (
+	"fmt"
+	"math/big"
+
+	"client/pkg/zk"
+
+	"github.com/iden3/go-iden3-crypto/babyjub"
+	"go.dedis.ch/kyber/v3"
+)
+
+// FromPoint converts a point of the group to an iden3 point.
+func FromPoint(p kyber.Point) (*babyjub.Point, error) {
+	x, y, err := zk.Coordinates(p)
+	if err != nil {
+		return nil, err
+	}
+	return &babyjub.Point{X: x, Y: y}, nil
+}
+
+// ToPoint converts an iden3 point to a point of the group g.
+// Decoding rules of the group apply, e.g. points outside of the
+// prime-order subgroup are rejected if the group checks subgroup membership.
+func ToPoint(g kyber.Group, p *babyjub.Point) (kyber.Point, error) {
+	return zk.PointFromCoordinates(g, p.X, p.Y)
+}
+
+// PublicKey converts a point of the group to an iden3 public key.
+func PublicKey(p kyber.Point) (*babyjub.PublicKey, error) {
+	bp, err := FromPoint(p)
+	if err != nil {
+		return nil, err
+	}
+	return (*babyjub.PublicKey)(bp), nil
+}
+
+// FromPublicKey converts an iden3 public key to a point of the group g.
+func FromPublicKey(g kyber.Group, pk *babyjub.PublicKey) (kyber.Point, error) {
+	return ToPoint(g, pk.Point())
+}
+
+// Compress returns the iden3 compressed encoding of a point of the group.
+func Compress(p kyber.Point) (babyjub.PublicKeyComp, error) {
+	pk, err := PublicKey(p)
+	if err != nil {
+		return babyjub.PublicKeyComp{}, err
+	}
+	return pk.Compress(), nil
+}
+
+// Decompress decodes an iden3 compressed point to a point of the group g.
+func Decompress(g kyber.Group, comp babyjub.PublicKeyComp) (kyber.Point, error) {
+	pk, err := comp.Decompress()
+	if err != nil {
+		return nil, fmt.Errorf("decompress: %w", err)
+	}
+	return FromPublicKey(g, pk)
+}
+
+// Base8 returns the base point B8 of iden3 in the group g.
+func Base8(g kyber.Group) (kyber.Point, error) {
+	return ToPoint(g, babyjub.B8)
+}
+
+// Scalar converts an iden3 private key scalar to a scalar of the group g.
+func Scalar(g kyber.Group, s *babyjub.PrivKeyScalar) kyber.Scalar {
+	return g.Scalar().SetBytes(s.BigInt().Bytes())
+}
+
+// PrivateKeyScalar returns the scalar of an iden3 EdDSA private key as a scalar of the group g.
+// The key is expanded by hashing as done by iden3 for signing with EdDSA-Poseidon.
+func PrivateKeyScalar(g kyber.Group, k *babyjub.PrivateKey) kyber.Scalar {
+	return Scalar(g, k.Scalar())
+}
+
+// PrivKeyScalar converts a scalar of the group to an iden3 private key scalar.
+// The public key of the iden3 scalar is s*B8, see Base8.
+func PrivKeyScalar(s kyber.Scalar) (*babyjub.PrivKeyScalar, error) {
+	b, err := s.MarshalBinary()
+	if err != nil {
+		return nil, fmt.Errorf("marshal scalar: %w", err)
+	}
+	return babyjub.NewPrivKeyScalar(new(big.Int).SetBytes(b)), nil
+}
@@ -0,0 +1,76 @@
+package iden3_test
+
+import (
+	"client/internal/pkg/group/curve25519"
+	"client/pkg/iden3"
+	"math/big"
+	"testing"
+
+	"github.com/iden3/go-iden3-crypto/babyjub"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPointConversion(t *testing.T) {
+	suite := curve25519.NewBlakeSHA256BabyJubJub(false)
+
+	for i := 0; i < 10; i++ {
+		p := suite.Point().Pick(suite.RandomStream())
+
+		bp, err := iden3.FromPoint(p)
+		require.NoError(t, err)
+		require.True(t, bp.InCurve())
+		require.True(t, bp.InSubGroup())
+
+		q, err := iden3.ToPoint(suite, bp)
+		require.NoError(t, err)
+		require.True(t, p.Equal(q))
+
+		comp, err := iden3.Compress(p)
+		require.NoError(t, err)
+		require.Equal(t, bp.Compress(), [32]byte(comp))
+
+		q, err = iden3.Decompress(suite, comp)
+		require.NoError(t, err)
+		require.True(t, p.Equal(q))
+	}
+}
+
+func TestPointOutsideSubgroup(t *testing.T) {
+	suite := curve25519.NewBlakeSHA256BabyJubJub(false)
+
+	// (0,-1) has order 2
+	p := &babyjub.Point{X: big.NewInt(0), Y: new(big.Int).Sub(&suite.P, big.NewInt(1))}
+	require.True(t, p.InCurve())
+	_, err := iden3.ToPoint(suite, p)
+	require.Error(t, err)
+}
+
+func TestKeyConversion(t *testing.T) {
+	suite := curve25519.NewBlakeSHA256BabyJubJub(false)
+	b8, err := iden3.Base8(suite)
+	require.NoError(t, err)
+	require.False(t, b8.Equal(suite.Point().Base()), "DKG base point must differ from iden3 B8")
+
+	// iden3 keys map to the same points
+	k := babyjub.NewRandPrivKey()
+	s := iden3.PrivateKeyScalar(suite, &k)
+	pub, err := iden3.FromPublicKey(suite, k.Public())
+	require.NoError(t, err)
+	require.True(t, pub.Equal(suite.Point().Mul(s, b8)))
+
+	// and keys of the DKG sign for iden3
+	s = suite.Scalar().Pick(suite.RandomStream())
+	ks, err := iden3.PrivKeyScalar(s)
+	require.NoError(t, err)
+	require.True(t, iden3.Scalar(suite, ks).Equal(s))
+
+	pk, err := iden3.PublicKey(suite.Point().Mul(s, b8))
+	require.NoError(t, err)
+	require.Equal(t, ks.Public().Compress(), pk.Compress())
+
+	msg := big.NewInt(42)
+	sig := k.SignPoseidon(msg)
+	pk, err = iden3.PublicKey(pub)
+	require.NoError(t, err)
+	require.True(t, pk.VerifyPoseidon(msg, sig))
+}
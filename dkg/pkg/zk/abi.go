@@ -0,0 +1,60 @@
+package zk
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// ABI is the interface of a compiled program, as written to abi.json by zokrates compile.
+type ABI struct {
+	Inputs []ABIParameter `json:"inputs"`
+}
+
+// ABIParameter describes an argument of the main function of a program.
+type ABIParameter struct {
+	Name   string `json:"name"`
+	Public bool   `json:"public"`
+	ABIType
+}
+
+// ABIType is the type of an argument. Arrays describe their element type in Components.
+type ABIType struct {
+	Type       string   `json:"type"`
+	Size       int      `json:"size,omitempty"`
+	Components *ABIType `json:"components,omitempty"`
+}
+
+// ReadABI parses the abi.json of a compiled program.
+func ReadABI(r io.Reader) (*ABI, error) {
+	var abi ABI
+	if err := json.NewDecoder(r).Decode(&abi); err != nil {
+		return nil, fmt.Errorf("decode abi: %w", err)
+	}
+	return &abi, nil
+}
+
+// ArrayLen returns the length of the array argument with the given name.
+func (a *ABI) ArrayLen(name string) (int, error) {
+	for _, in := range a.Inputs {
+		if in.Name != name {
+			continue
+		}
+		if in.Type != "array" || in.Components == nil {
+			return 0, fmt.Errorf("argument %s is of type %s, not an array", name, in.Type)
+		}
+		return in.Components.Size, nil
+	}
+	return 0, fmt.Errorf("no argument %s", name)
+}
+
+// PolyEvalThreshold returns the number of commitments N poly_eval.zok was compiled for.
+// The program is compiled with N = PARTICIPANTS / 2 + 1, the minimum threshold of the contract.
+func PolyEvalThreshold(a *ABI) (int, error) {
+	return a.ArrayLen("commits")
+}
+
+// KeyDerivParticipants returns the number of participants PARTICIPANTS key_deriv.zok was compiled for.
+func KeyDerivParticipants(a *ABI) (int, error) {
+	return a.ArrayLen("firstCoefficients")
+}
@@ -0,0 +1,162 @@
+package zk
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"go.dedis.ch/kyber/v3"
+	"go.dedis.ch/kyber/v3/group/mod"
+)
+
+// affinePoint is implemented by the points of the curve25519 group package.
+type affinePoint interface {
+	GetXY() (x, y *mod.Int)
+}
+
+var errNotAffine = errors.New("point does not expose affine coordinates")
+
+// Coordinates returns copies of the affine coordinates of the given point.
+func Coordinates(p kyber.Point) (x, y *big.Int, err error) {
+	ap, ok := p.(affinePoint)
+	if !ok {
+		return nil, nil, errNotAffine
+	}
+	px, py := ap.GetXY()
+	return new(big.Int).Set(&px.V), new(big.Int).Set(&py.V), nil
+}
+
+// PointArguments flattens the given points into their affine coordinates,
+// which is how ZoKrates expects an argument of type field[N][2].
+func PointArguments(points ...kyber.Point) ([]*big.Int, error) {
+	args := make([]*big.Int, 0, 2*len(points))
+	for _, p := range points {
+		x, y, err := Coordinates(p)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, x, y)
+	}
+	return args, nil
+}
+
+// CompressPoints concatenates the compressed encodings of the given points.
+// Mirrors ecc/compressPoints.zok: each point is encoded as its big-endian y-coordinate
+// with the most significant bit replaced by the least significant bit of the x-coordinate,
+// which is also the encoding produced by MarshalBinary of the curve25519 points.
+func CompressPoints(points []kyber.Point) ([]byte, error) {
+	b := make([]byte, 0, len(points)*FieldSize)
+	for i, p := range points {
+		c, err := p.MarshalBinary()
+		if err != nil {
+			return nil, fmt.Errorf("marshal point %d: %w", i, err)
+		}
+		if len(c) != FieldSize {
+			return nil, fmt.Errorf("point %d: unexpected encoding length %d", i, len(c))
+		}
+		b = append(b, c...)
+	}
+	return b, nil
+}
+
+// PointFromCoordinates returns the point of the group with the given affine coordinates.
+// An error is returned if the coordinates don't denote a point on the curve.
+func PointFromCoordinates(g kyber.Group, x, y *big.Int) (kyber.Point, error) {
+	if x.Sign() < 0 || x.Cmp(FieldModulus) >= 0 || y.Sign() < 0 || y.Cmp(FieldModulus) >= 0 {
+		return nil, errors.New("coordinate out of range")
+	}
+
+	b := y.FillBytes(make([]byte, FieldSize))
+	b[0] |= byte(x.Bit(0) << 7)
+
+	p := g.Point()
+	if err := p.UnmarshalBinary(b); err != nil {
+		return nil, fmt.Errorf("unmarshal point: %w", err)
+	}
+
+	px, _, err := Coordinates(p)
+	if err != nil {
+		return nil, err
+	}
+	if px.Cmp(x) != 0 {
+		return nil, errors.New("point not on curve")
+	}
+	return p, nil
+}
+
+// Parameters of the twisted Edwards equation a*x^2 + y^2 = 1 + d*x^2*y^2 defining Baby Jubjub.
+var (
+	curveA = big.NewInt(168700)
+	curveD = big.NewInt(168696)
+)
+
+// signBit is the bit of a compressed point carrying the least significant bit of the x-coordinate.
+const signBit = 8*FieldSize - 1
+
+// Compress returns the compressed encoding of the point with the given affine coordinates
+// as an integer, as accepted by registerCompressed of ZKDKG.sol.
+// It equals the big-endian interpretation of CompressPoints for a single point.
+func Compress(x, y *big.Int) *big.Int {
+	c := new(big.Int).Mod(y, FieldModulus)
+	return c.SetBit(c, signBit, new(big.Int).Mod(x, FieldModulus).Bit(0))
+}
+
+// Decompress returns the affine coordinates of the Baby Jubjub point with the given compressed encoding.
+// The x-coordinate is recovered from x^2 = (1 - y^2) / (a - d*y^2) with FieldSqrt,
+// mirroring the decompression done by registerCompressed of ZKDKG.sol.
+func Decompress(c *big.Int) (x, y *big.Int, err error) {
+	if c.Sign() < 0 || c.BitLen() > signBit+1 {
+		return nil, nil, errors.New("compressed point out of range")
+	}
+
+	sign := c.Bit(signBit)
+	y = new(big.Int).SetBit(c, signBit, 0)
+	if y.Cmp(FieldModulus) >= 0 {
+		return nil, nil, errors.New("non-canonical y-coordinate")
+	}
+
+	p := FieldModulus
+	yy := new(big.Int).Mul(y, y)
+	yy.Mod(yy, p)
+
+	u := new(big.Int).Sub(one, yy)
+	v := new(big.Int).Mul(curveD, yy)
+	v.Sub(curveA, v).Mod(v, p)
+	if v.ModInverse(v, p) == nil {
+		return nil, nil, errors.New("point not on curve")
+	}
+	u.Mul(u, v).Mod(u, p)
+
+	x, ok := FieldSqrt(u)
+	if !ok {
+		return nil, nil, errors.New("point not on curve")
+	}
+	if x.Bit(0) != sign {
+		if x.Sign() == 0 {
+			return nil, nil, errors.New("non-canonical x-coordinate sign")
+		}
+		x.Sub(p, x)
+	}
+	return x, y, nil
+}
+
+// DecompressPoint returns the point of the group with the given compressed encoding.
+func DecompressPoint(g kyber.Group, c *big.Int) (kyber.Point, error) {
+	x, y, err := Decompress(c)
+	if err != nil {
+		return nil, err
+	}
+	return PointFromCoordinates(g, x, y)
+}
+
+func pointsFromArguments(g kyber.Group, args []*big.Int) ([]kyber.Point, error) {
+	points := make([]kyber.Point, len(args)/2)
+	for i := range points {
+		p, err := PointFromCoordinates(g, args[2*i], args[2*i+1])
+		if err != nil {
+			return nil, fmt.Errorf("point %d: %w", i, err)
+		}
+		points[i] = p
+	}
+	return points, nil
+}
@@ -0,0 +1,97 @@
+// Package zk mirrors the ZoKrates programs in the zk directory of the repository.
+//
+// The functions in this package compute the same values as their counterparts in
+// poly_eval.zok and key_deriv.zok, so the argument vectors handed to the prover
+// can be built and checked in Go before a proof is computed or submitted.
+package zk
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// FieldModulus is the order of the ALT_BN128 scalar field over which ZoKrates computes.
+// It equals the order of the underlying field of the Baby Jubjub curve.
+var FieldModulus, _ = new(big.Int).SetString("21888242871839275222246405745257275088548364400416034343698204186575808495617", 10)
+
+// FieldSize is the length in bytes of a serialized field element.
+const FieldSize = 32
+
+// KeccakToField interprets a keccak256 digest as a big-endian integer and reduces it to a field element.
+// Mirrors utils/casts/keccak_to_field.zok, where the reduction happens implicitly through field arithmetic.
+func KeccakToField(hash []byte) *big.Int {
+	return new(big.Int).Mod(new(big.Int).SetBytes(hash), FieldModulus)
+}
+
+// HashToField computes keccak256 over the concatenation of data and reduces the digest to a field element.
+func HashToField(data ...[]byte) *big.Int {
+	return KeccakToField(crypto.Keccak256(data...))
+}
+
+// FieldToBytes encodes a field element as 32 big-endian bytes.
+// Mirrors utils/casts/field_to_u8_array.zok.
+func FieldToBytes(f *big.Int) []byte {
+	return new(big.Int).Mod(f, FieldModulus).FillBytes(make([]byte, FieldSize))
+}
+
+// FieldsToBytes concatenates the 32 byte big-endian encodings of the given field elements.
+// Mirrors utils/casts/field_array_to_u8_array.zok.
+func FieldsToBytes(fs ...*big.Int) []byte {
+	b := make([]byte, 0, len(fs)*FieldSize)
+	for _, f := range fs {
+		b = append(b, FieldToBytes(f)...)
+	}
+	return b
+}
+
+var one = big.NewInt(1)
+
+// Constants of the Tonelli-Shanks algorithm for the field: FieldModulus - 1 = 2^sqrtS * sqrtQ with sqrtQ odd,
+// and sqrtZ = 5^sqrtQ, where 5 is the smallest quadratic non-residue.
+// The same constants are hard-coded in ZKDKG.sol.
+var (
+	sqrtS    = 28
+	sqrtQ, _ = new(big.Int).SetString("81540058820840996586704275553141814055101440848469862132140264610111", 10)
+	sqrtZ, _ = new(big.Int).SetString("19103219067921713944291392827692070036145651957329286315305642004821462161904", 10)
+)
+
+// FieldSqrt returns a square root of a modulo FieldModulus, or false if a is not a square.
+// It implements the Tonelli-Shanks algorithm step by step as done by sqrt in ZKDKG.sol,
+// so both return the same root.
+func FieldSqrt(a *big.Int) (*big.Int, bool) {
+	p := FieldModulus
+	a = new(big.Int).Mod(a, p)
+	if a.Sign() == 0 {
+		return a, true
+	}
+
+	m := sqrtS
+	c := new(big.Int).Set(sqrtZ)
+	t := new(big.Int).Exp(a, sqrtQ, p)
+	r := new(big.Int).Exp(a, new(big.Int).Rsh(new(big.Int).Add(sqrtQ, one), 1), p)
+
+	b := new(big.Int)
+	for t.Cmp(one) != 0 {
+		// Find the least i with t^(2^i) = 1, which is less than m iff a is a square
+		i := 0
+		for b.Set(t); b.Cmp(one) != 0; i++ {
+			if i+1 == m {
+				return nil, false
+			}
+			b.Mul(b, b).Mod(b, p)
+		}
+
+		// b = c^(2^(m-i-1))
+		b.Set(c)
+		for j := 0; j < m-i-1; j++ {
+			b.Mul(b, b).Mod(b, p)
+		}
+
+		m = i
+		c.Mul(b, b).Mod(c, p)
+		t.Mul(t, c).Mod(t, p)
+		r.Mul(r, b).Mod(r, p)
+	}
+	return r, true
+}
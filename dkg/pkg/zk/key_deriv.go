@@ -0,0 +1,99 @@
+package zk
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"go.dedis.ch/kyber/v3"
+)
+
+// KeyDerivInput holds the arguments of key_deriv.zok, the program used to submit the public key.
+type KeyDerivInput struct {
+	FirstCoefficients []kyber.Point // Commitments to the secrets of all dealers, ordered by index, private
+}
+
+// Hash computes the public input of the program.
+// It mirrors the hash the contract computes over firstCoefficients in submitPublicKey.
+func (in *KeyDerivInput) Hash() (*big.Int, error) {
+	compressed, err := CompressPoints(in.FirstCoefficients)
+	if err != nil {
+		return nil, fmt.Errorf("compress first coefficients: %w", err)
+	}
+	return KeccakToField(crypto.Keccak256(compressed)), nil
+}
+
+// Witness returns the arguments of key_deriv.zok, with the first coefficients marked as private.
+func (in *KeyDerivInput) Witness() (Witness, error) {
+	hash, err := in.Hash()
+	if err != nil {
+		return nil, err
+	}
+
+	coefficients, err := PointArguments(in.FirstCoefficients...)
+	if err != nil {
+		return nil, fmt.Errorf("first coefficients: %w", err)
+	}
+
+	var w Witness
+	w = w.add("firstCoefficients", true, coefficients...)
+	return w.add("hash", false, hash), nil
+}
+
+// Arguments returns the argument vector in the order expected by key_deriv.zok.
+func (in *KeyDerivInput) Arguments() ([]*big.Int, error) {
+	w, err := in.Witness()
+	if err != nil {
+		return nil, err
+	}
+	return w.Values(), nil
+}
+
+// PublicKey computes the output of the program, the sum of all first coefficients.
+func (in *KeyDerivInput) PublicKey(g kyber.Group) kyber.Point {
+	key := g.Point().Null()
+	for _, c := range in.FirstCoefficients {
+		key.Add(key, c)
+	}
+	return key
+}
+
+// Output returns the public key in the form the contract expects it in submitPublicKey.
+func (in *KeyDerivInput) Output(g kyber.Group) ([2]*big.Int, error) {
+	x, y, err := Coordinates(in.PublicKey(g))
+	if err != nil {
+		return [2]*big.Int{}, err
+	}
+	return [2]*big.Int{x, y}, nil
+}
+
+// KeyDerivArgumentsLen returns the length of the argument vector of key_deriv.zok for the given number of participants.
+func KeyDerivArgumentsLen(participants int) int {
+	return 2*participants + 1
+}
+
+// DecodeKeyDerivArguments parses an argument vector of key_deriv.zok back into its typed form
+// and checks that the public hash argument matches the private arguments.
+func DecodeKeyDerivArguments(g kyber.Group, args []*big.Int) (*KeyDerivInput, error) {
+	if len(args) < 3 || len(args)%2 != 1 {
+		return nil, fmt.Errorf("invalid number of arguments: %d", len(args))
+	}
+
+	coefficients, err := pointsFromArguments(g, args[:len(args)-1])
+	if err != nil {
+		return nil, fmt.Errorf("first coefficients: %w", err)
+	}
+
+	in := &KeyDerivInput{FirstCoefficients: coefficients}
+
+	hash, err := in.Hash()
+	if err != nil {
+		return nil, err
+	}
+	if hash.Cmp(args[len(args)-1]) != 0 {
+		return nil, errors.New("hash argument does not match the private arguments")
+	}
+
+	return in, nil
+}
@@ -0,0 +1,228 @@
+package zk
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/iden3/go-iden3-crypto/poseidon"
+	"go.dedis.ch/kyber/v3"
+)
+
+// PolyEvalInput holds the arguments of poly_eval.zok, the program used to defend a disputed share.
+type PolyEvalInput struct {
+	Commits        []kyber.Point // Commitments of the disputed dealer, private
+	SecretKey      kyber.Scalar  // Long-term key of the dealer, private
+	PubKeyProofer  kyber.Point   // Public key of the dealer, private
+	PubKeyDisputer kyber.Point   // Public key of the disputer, private
+	Index          uint16        // Participant index of the disputer, private
+	EncryptedShare *big.Int      // Encrypted share the dealer broadcast for the disputer, private
+}
+
+// CommitsHash returns keccak256 over the compressed commitments.
+// This is the value the contract stores in commitmentHashes for the dealer.
+func (in *PolyEvalInput) CommitsHash() ([]byte, error) {
+	compressed, err := CompressPoints(in.Commits)
+	if err != nil {
+		return nil, fmt.Errorf("compress commits: %w", err)
+	}
+	return crypto.Keccak256(compressed), nil
+}
+
+// Hash computes the public input of the program, given the hash of the commitments.
+// It mirrors the hash the contract computes in defendShare.
+func (in *PolyEvalInput) Hash(commitsHash []byte) (*big.Int, error) {
+	proofer, err := PointArguments(in.PubKeyProofer)
+	if err != nil {
+		return nil, fmt.Errorf("proofer public key: %w", err)
+	}
+	disputer, err := PointArguments(in.PubKeyDisputer)
+	if err != nil {
+		return nil, fmt.Errorf("disputer public key: %w", err)
+	}
+
+	return HashToField(
+		commitsHash,
+		FieldsToBytes(proofer...),
+		FieldsToBytes(disputer...),
+		FieldToBytes(big.NewInt(int64(in.Index))),
+		FieldToBytes(in.EncryptedShare),
+	), nil
+}
+
+// Witness returns the arguments of poly_eval.zok, with all but the public hash marked as private.
+func (in *PolyEvalInput) Witness() (Witness, error) {
+	commitsHash, err := in.CommitsHash()
+	if err != nil {
+		return nil, err
+	}
+
+	hash, err := in.Hash(commitsHash)
+	if err != nil {
+		return nil, err
+	}
+
+	commits, err := PointArguments(in.Commits...)
+	if err != nil {
+		return nil, fmt.Errorf("commits: %w", err)
+	}
+
+	sk, err := in.SecretKey.MarshalBinary()
+	if err != nil {
+		return nil, fmt.Errorf("marshal secret key: %w", err)
+	}
+
+	proofer, err := PointArguments(in.PubKeyProofer)
+	if err != nil {
+		return nil, fmt.Errorf("proofer public key: %w", err)
+	}
+	disputer, err := PointArguments(in.PubKeyDisputer)
+	if err != nil {
+		return nil, fmt.Errorf("disputer public key: %w", err)
+	}
+
+	var w Witness
+	w = w.add("commits", true, commits...)
+	w = w.add("secretKey", true, new(big.Int).SetBytes(sk))
+	w = w.add("pubKeyProofer", true, proofer...)
+	w = w.add("pubKeyDisputer", true, disputer...)
+	w = w.add("index", true, big.NewInt(int64(in.Index)))
+	w = w.add("encryptedShare", true, new(big.Int).Set(in.EncryptedShare))
+	return w.add("hash", false, hash), nil
+}
+
+// Arguments returns the argument vector in the order expected by poly_eval.zok.
+func (in *PolyEvalInput) Arguments() ([]*big.Int, error) {
+	w, err := in.Witness()
+	if err != nil {
+		return nil, err
+	}
+	return w.Values(), nil
+}
+
+// Validate runs the program on the input and reports an error if any of its assertions fail
+// or if it wouldn't return true, i.e. if no valid proof could be generated from the input.
+func (in *PolyEvalInput) Validate(g kyber.Group) error {
+	if !g.Point().Mul(in.SecretKey, nil).Equal(in.PubKeyProofer) {
+		return errors.New("secret key does not belong to the proofer's public key")
+	}
+
+	share, err := Decrypt(g, in.SecretKey, in.PubKeyDisputer, in.Commits, in.EncryptedShare)
+	if err != nil {
+		return fmt.Errorf("decrypt: %w", err)
+	}
+
+	s := g.Scalar().SetBytes(share.Bytes())
+	if !g.Point().Mul(s, nil).Equal(EvalPubPoly(g, in.Index, in.Commits)) {
+		return errors.New("share does not match the commitments")
+	}
+
+	return nil
+}
+
+// SharedKey derives the one-time pad of an encrypted share from a Diffie-Hellman exchange
+// and the first commitment of the dealer. Mirrors computeSharedKey of poly_eval.zok.
+func SharedKey(g kyber.Group, secretKey kyber.Scalar, pubKey kyber.Point, commits []kyber.Point) (*big.Int, error) {
+	if len(commits) == 0 {
+		return nil, errors.New("no commitments")
+	}
+
+	exchangedX, _, err := Coordinates(g.Point().Mul(secretKey, pubKey))
+	if err != nil {
+		return nil, fmt.Errorf("exchanged key: %w", err)
+	}
+
+	commitX, _, err := Coordinates(commits[0])
+	if err != nil {
+		return nil, fmt.Errorf("first commitment: %w", err)
+	}
+
+	key, err := poseidon.Hash([]*big.Int{exchangedX, commitX})
+	if err != nil {
+		return nil, fmt.Errorf("poseidon: %w", err)
+	}
+	return key, nil
+}
+
+// Encrypt adds the shared key to the share in the field. Inverse of Decrypt.
+func Encrypt(g kyber.Group, secretKey kyber.Scalar, pubKey kyber.Point, commits []kyber.Point, share *big.Int) (*big.Int, error) {
+	key, err := SharedKey(g, secretKey, pubKey, commits)
+	if err != nil {
+		return nil, err
+	}
+	return key.Add(key, share).Mod(key, FieldModulus), nil
+}
+
+// Decrypt subtracts the shared key from the encrypted share in the field. Mirrors decrypt of poly_eval.zok.
+func Decrypt(g kyber.Group, secretKey kyber.Scalar, pubKey kyber.Point, commits []kyber.Point, encryptedShare *big.Int) (*big.Int, error) {
+	key, err := SharedKey(g, secretKey, pubKey, commits)
+	if err != nil {
+		return nil, err
+	}
+	return key.Sub(encryptedShare, key).Mod(key, FieldModulus), nil
+}
+
+// EvalPubPoly evaluates the public commitment polynomial at the given one-based index.
+// Mirrors evalPubPoly of poly_eval.zok and is equivalent to share.PubPoly.Eval(index - 1).
+func EvalPubPoly(g kyber.Group, index uint16, commits []kyber.Point) kyber.Point {
+	x := g.Scalar().SetInt64(int64(index))
+	v := g.Point().Null()
+	for i := len(commits) - 1; i >= 0; i-- {
+		v.Mul(x, v)
+		v.Add(v, commits[i])
+	}
+	return v
+}
+
+// PolyEvalArgumentsLen returns the length of the argument vector of poly_eval.zok for the given number of participants.
+func PolyEvalArgumentsLen(participants int) int {
+	return 2*(participants/2+1) + 8
+}
+
+// DecodePolyEvalArguments parses an argument vector of poly_eval.zok back into its typed form
+// and checks that the public hash argument matches the private arguments.
+func DecodePolyEvalArguments(g kyber.Group, args []*big.Int) (*PolyEvalInput, error) {
+	if len(args) < 10 || len(args)%2 != 0 {
+		return nil, fmt.Errorf("invalid number of arguments: %d", len(args))
+	}
+
+	n := len(args) - 8
+	commits, err := pointsFromArguments(g, args[:n])
+	if err != nil {
+		return nil, fmt.Errorf("commits: %w", err)
+	}
+
+	keys, err := pointsFromArguments(g, args[n+1:n+5])
+	if err != nil {
+		return nil, fmt.Errorf("public keys: %w", err)
+	}
+
+	index := args[n+5]
+	if !index.IsUint64() || index.Uint64() == 0 || index.Uint64() > 0xffff {
+		return nil, fmt.Errorf("invalid index: %s", index)
+	}
+
+	in := &PolyEvalInput{
+		Commits:        commits,
+		SecretKey:      g.Scalar().SetBytes(args[n].Bytes()),
+		PubKeyProofer:  keys[0],
+		PubKeyDisputer: keys[1],
+		Index:          uint16(index.Uint64()),
+		EncryptedShare: new(big.Int).Set(args[n+6]),
+	}
+
+	commitsHash, err := in.CommitsHash()
+	if err != nil {
+		return nil, err
+	}
+	hash, err := in.Hash(commitsHash)
+	if err != nil {
+		return nil, err
+	}
+	if hash.Cmp(args[n+7]) != 0 {
+		return nil, errors.New("hash argument does not match the private arguments")
+	}
+
+	return in, nil
+}
@@ -0,0 +1,289 @@
+#!/usr/bin/env python3
+"""Generates the golden vectors of the zk package independently of its Go code.
+
+The values are computed by this reference implementation of poly_eval.zok and key_deriv.zok, written from the
+ZoKrates programs and the specifications of their building blocks rather than from the Go code:
+
+- Keccak-256 as specified for Ethereum, i.e. with the original Keccak padding,
+- Baby Jubjub with the parameters and base point of ecc/babyjubjubParams of the ZoKrates standard library,
+- Poseidon with the round constants and MDS matrix derived by the Grain LFSR of the reference implementation
+  (generate_parameters_grain.sage of https://extgit.iaik.tugraz.at/krypto/hadeshash), as used by circomlib and the
+  hashes/poseidon module of ZoKrates,
+- the byte layouts of compressPoints.zok and the casts in zk/utils.
+
+Each building block is checked against published test vectors before any vector is written.
+Run it from this directory with python3 generate.py, the vectors only change if the inputs below do.
+"""
+
+import hashlib
+import json
+
+# Order of the scalar field of ALT_BN128, the field ZoKrates computes over
+P = 21888242871839275222246405745257275088548364400416034343698204186575808495617
+
+# Baby Jubjub, see ecc/babyjubjubParams.zok
+A = 168700
+D = 168696
+G = (
+    16540640123574156134436876038791482806971768689494387082833631921987005038935,
+    20819045374670962167435360035096875258406992893633759881276124905556507972311,
+)
+INFINITY = (0, 1)
+# Order of the subgroup generated by G
+L = 2736030358979909402780800718157159386076813972158567259200215660948447373041
+
+
+# Keccak-256
+
+ROUND_CONSTANTS = [
+    0x0000000000000001, 0x0000000000008082, 0x800000000000808A, 0x8000000080008000,
+    0x000000000000808B, 0x0000000080000001, 0x8000000080008081, 0x8000000000008009,
+    0x000000000000008A, 0x0000000000000088, 0x0000000080008009, 0x000000008000000A,
+    0x000000008000808B, 0x800000000000008B, 0x8000000000008089, 0x8000000000008003,
+    0x8000000000008002, 0x8000000000000080, 0x000000000000800A, 0x800000008000000A,
+    0x8000000080008081, 0x8000000000008080, 0x0000000080000001, 0x8000000080008008,
+]
+ROTATIONS = [
+    [0, 36, 3, 41, 18],
+    [1, 44, 10, 45, 2],
+    [62, 6, 43, 15, 61],
+    [28, 55, 25, 21, 56],
+    [27, 20, 39, 8, 14],
+]
+MASK = (1 << 64) - 1
+
+
+def rotl(v, n):
+    return ((v << n) | (v >> (64 - n))) & MASK if n else v
+
+
+def keccak_f(a):
+    for rc in ROUND_CONSTANTS:
+        c = [a[x][0] ^ a[x][1] ^ a[x][2] ^ a[x][3] ^ a[x][4] for x in range(5)]
+        d = [c[(x - 1) % 5] ^ rotl(c[(x + 1) % 5], 1) for x in range(5)]
+        a = [[a[x][y] ^ d[x] for y in range(5)] for x in range(5)]
+        b = [[0] * 5 for _ in range(5)]
+        for x in range(5):
+            for y in range(5):
+                b[y][(2 * x + 3 * y) % 5] = rotl(a[x][y], ROTATIONS[x][y])
+        a = [[b[x][y] ^ (~b[(x + 1) % 5][y] & b[(x + 2) % 5][y]) for y in range(5)] for x in range(5)]
+        a[0][0] ^= rc
+    return a
+
+
+def keccak256(data):
+    rate = 136
+    padded = bytearray(data) + b"\x01" + bytes(-(len(data) + 1) % rate)
+    padded[-1] |= 0x80
+    a = [[0] * 5 for _ in range(5)]
+    for offset in range(0, len(padded), rate):
+        block = padded[offset:offset + rate]
+        for i in range(rate // 8):
+            a[i % 5][i // 5] ^= int.from_bytes(block[8 * i:8 * i + 8], "little")
+        a = keccak_f(a)
+    return b"".join(a[i % 5][i // 5].to_bytes(8, "little") for i in range(4))
+
+
+# Baby Jubjub
+
+def inv(v):
+    return pow(v, P - 2, P)
+
+
+def add(p, q):
+    (x1, y1), (x2, y2) = p, q
+    t = D * x1 * x2 * y1 * y2 % P
+    return (
+        (x1 * y2 + y1 * x2) * inv(1 + t) % P,
+        (y1 * y2 - A * x1 * x2) * inv(1 - t) % P,
+    )
+
+
+def mul(k, p):
+    r = INFINITY
+    for bit in bin(k)[2:]:
+        r = add(r, r)
+        if bit == "1":
+            r = add(r, p)
+    return r
+
+
+def on_curve(p):
+    x, y = p
+    return (A * x * x + y * y - 1 - D * x * x * y * y) % P == 0
+
+
+# Poseidon
+
+def grain(field_size, t, full_rounds, partial_rounds):
+    """Yields the bits of the Grain LFSR initialized like generate_parameters_grain.sage for a prime field and x^5."""
+    bits = [0, 1] + [0] * 4
+    for value, width in [(field_size, 12), (t, 12), (full_rounds, 10), (partial_rounds, 10)]:
+        bits += [int(b) for b in format(value, "0%db" % width)]
+    bits += [1] * 30
+
+    def step():
+        bit = bits[62] ^ bits[51] ^ bits[38] ^ bits[23] ^ bits[13] ^ bits[0]
+        bits.pop(0)
+        bits.append(bit)
+        return bit
+
+    for _ in range(160):
+        step()
+    while True:
+        # Self-shrinking: a pair of bits yields its second one if the first is set
+        while step() == 0:
+            step()
+        yield step()
+
+
+def poseidon_parameters(t, full_rounds=8, partial_rounds=None):
+    partial_rounds = partial_rounds or [56, 57, 56, 60, 60, 63, 64, 63][t - 2]
+    bits = grain(254, t, full_rounds, partial_rounds)
+
+    def random_int():
+        return int("".join(str(next(bits)) for _ in range(254)), 2)
+
+    constants = []
+    while len(constants) < (full_rounds + partial_rounds) * t:
+        v = random_int()
+        if v < P:
+            constants.append(v)
+
+    while True:
+        xy = [random_int() % P for _ in range(2 * t)]
+        if len(set(xy)) == 2 * t and all((x + y) % P for x in xy[:t] for y in xy[t:]):
+            break
+    matrix = [[inv(x + y) for y in xy[t:]] for x in xy[:t]]
+    return constants, matrix, full_rounds, partial_rounds
+
+
+def poseidon(inputs):
+    t = len(inputs) + 1
+    constants, matrix, full_rounds, partial_rounds = poseidon_parameters(t)
+    state = [0] + list(inputs)
+    for r in range(full_rounds + partial_rounds):
+        state = [(s + constants[r * t + i]) % P for i, s in enumerate(state)]
+        if full_rounds // 2 <= r < full_rounds // 2 + partial_rounds:
+            state[0] = pow(state[0], 5, P)
+        else:
+            state = [pow(s, 5, P) for s in state]
+        state = [sum(matrix[i][j] * s for j, s in enumerate(state)) % P for i in range(t)]
+    return state[0]
+
+
+# Layouts of the programs
+
+def field_to_bytes(f):
+    """utils/casts/field_to_u8_array.zok: the bits of unpack256, most significant first."""
+    return (f % P).to_bytes(32, "big")
+
+
+def keccak_to_field(digest):
+    """utils/casts/keccak_to_field.zok: the bits of the digest as a big-endian number, reduced by the field."""
+    return int.from_bytes(digest, "big") % P
+
+
+def compress_points(points):
+    """ecc/compressPoints.zok: the bits of y, most significant first, with the first replaced by the last one of x."""
+    out = b""
+    for x, y in points:
+        out += ((y % P) | ((x & 1) << 255)).to_bytes(32, "big")
+    return out
+
+
+def poly_eval(participants, coefficients, secret_key, disputer_secret_key, index):
+    commits = [mul(c, G) for c in coefficients]
+    pub_proofer = mul(secret_key, G)
+    pub_disputer = mul(disputer_secret_key, G)
+
+    share = sum(c * index ** i for i, c in enumerate(coefficients)) % L
+    exchanged = mul(secret_key, pub_disputer)
+    shared_key = poseidon([exchanged[0], commits[0][0]])
+    encrypted_share = (share + shared_key) % P
+
+    commits_hash = keccak256(compress_points(commits))
+    hash_ = keccak_to_field(keccak256(
+        commits_hash
+        + field_to_bytes(pub_proofer[0]) + field_to_bytes(pub_proofer[1])
+        + field_to_bytes(pub_disputer[0]) + field_to_bytes(pub_disputer[1])
+        + field_to_bytes(index)
+        + field_to_bytes(encrypted_share)
+    ))
+
+    # The program returns true iff share * G equals the evaluation of the public polynomial at the index
+    expected = INFINITY
+    for c in reversed(commits):
+        expected = add(mul(index, expected), c)
+    assert mul(share, G) == expected
+
+    arguments = [v for c in commits for v in c] + [secret_key, *pub_proofer, *pub_disputer, index, encrypted_share, hash_]
+    return {
+        "participants": participants,
+        "coefficients": coefficients,
+        "secretKey": secret_key,
+        "disputerSecretKey": disputer_secret_key,
+        "index": index,
+        "share": share,
+        "sharedKey": shared_key,
+        "encryptedShare": encrypted_share,
+        "commitsHash": commits_hash.hex(),
+        "arguments": arguments,
+    }
+
+
+def key_deriv(participants, scalars):
+    # A zero scalar stands for an excluded dealer, whose first coefficient is the point at infinity
+    coefficients = [mul(s, G) for s in scalars]
+    hash_ = keccak_to_field(keccak256(compress_points(coefficients)))
+
+    key = INFINITY
+    for c in coefficients:
+        key = add(key, c)
+
+    return {
+        "participants": participants,
+        "scalars": scalars,
+        "arguments": [v for c in coefficients for v in c] + [hash_],
+        "output": list(key),
+    }
+
+
+def scalar(label):
+    """Derives a scalar of the subgroup from a label, so the inputs aren't trivially small."""
+    return int.from_bytes(hashlib.sha256(label.encode()).digest(), "big") % L
+
+
+def self_check():
+    assert keccak256(b"").hex() == "c5d2460186f7233c927e7db2dcc703c0e500b653ca82273b7bfad8045d85a470"
+    assert keccak256(b"abc").hex() == "4e03657aea45a94fc7d47ba826c8d667c0d1e6e33a64a036ec44f58fa12d6c45"
+    # Spans two blocks like the hash of poly_eval, as computed by go-ethereum
+    assert keccak256(b"a" * 200).hex() == "96ea54061def936c4be90b518992fdc6f12f535068a256229aca54267b4d084d"
+
+    assert on_curve(G) and mul(L, G) == INFINITY
+
+    # Test vectors of circomlib and go-iden3-crypto
+    assert poseidon([1]) == 18586133768512220936620570745912940619677854269274689475585506675881198879027
+    assert poseidon([1, 2]) == 7853200120776062878684798364095072458815029376092732009249414926327459813530
+
+
+def write(name, vector):
+    with open(name + ".golden.json", "w") as f:
+        json.dump(vector, f, indent=2)
+        f.write("\n")
+
+
+if __name__ == "__main__":
+    self_check()
+
+    write("poly_eval", poly_eval(
+        participants=4,
+        coefficients=[scalar("zkDKG poly_eval coefficient %d" % i) for i in range(3)],
+        secret_key=scalar("zkDKG poly_eval proofer"),
+        disputer_secret_key=scalar("zkDKG poly_eval disputer"),
+        index=2,
+    ))
+    write("key_deriv", key_deriv(
+        participants=4,
+        scalars=[scalar("zkDKG key_deriv dealer %d" % i) for i in range(3)] + [0],
+    ))
@@ -0,0 +1,94 @@
+package zk
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"strings"
+)
+
+// Redacted replaces the value of private arguments in all output except WriteTo.
+const Redacted = "[redacted]"
+
+// Argument is a single field element of the argument vector of a program.
+type Argument struct {
+	Name    string // Parameter of main the element belongs to
+	Value   *big.Int
+	Private bool // Private arguments are secret witness data, e.g. the long-term key of the proofer
+}
+
+func (a Argument) String() string {
+	if a.Private {
+		return a.Name + "=" + Redacted
+	}
+	return a.Name + "=" + a.Value.String()
+}
+
+// Format prints the redacted form for every verb, so that no formatting of a log call can reveal a private value.
+func (a Argument) Format(f fmt.State, _ rune) {
+	io.WriteString(f, a.String())
+}
+
+func (a Argument) MarshalJSON() ([]byte, error) {
+	v := Redacted
+	if !a.Private {
+		v = a.Value.String()
+	}
+	return json.Marshal(struct {
+		Name    string `json:"name"`
+		Value   string `json:"value"`
+		Private bool   `json:"private"`
+	}{a.Name, v, a.Private})
+}
+
+// Witness is the flattened argument vector of a program, in the order expected by zokrates compute-witness.
+type Witness []Argument
+
+func (w Witness) add(name string, private bool, values ...*big.Int) Witness {
+	for _, v := range values {
+		w = append(w, Argument{Name: name, Value: v, Private: private})
+	}
+	return w
+}
+
+// Values returns the plain argument vector.
+func (w Witness) Values() []*big.Int {
+	values := make([]*big.Int, len(w))
+	for i, a := range w {
+		values[i] = a.Value
+	}
+	return values
+}
+
+func (w Witness) String() string {
+	s := make([]string, len(w))
+	for i, a := range w {
+		s[i] = a.String()
+	}
+	return "[" + strings.Join(s, " ") + "]"
+}
+
+// Format prints the redacted form for every verb, see Argument.Format.
+func (w Witness) Format(f fmt.State, _ rune) {
+	io.WriteString(f, w.String())
+}
+
+// WriteTo writes the values separated by spaces, as read by zokrates compute-witness --stdin.
+// It is the only output that includes private values, the writer must not be logged or persisted.
+func (w Witness) WriteTo(dst io.Writer) (int64, error) {
+	bw := bufio.NewWriter(dst)
+	var n int64
+	for i, a := range w {
+		if i > 0 {
+			bw.WriteByte(' ')
+			n++
+		}
+		m, _ := bw.WriteString(a.Value.String())
+		n += int64(m)
+	}
+	bw.WriteByte('\n')
+	n++
+	return n, bw.Flush()
+}
@@ -0,0 +1,465 @@
+package zk_test
+
+import (
+	"client/internal/pkg/group/curve25519"
+	"client/pkg/zk"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.dedis.ch/kyber/v3"
+	"go.dedis.ch/kyber/v3/share"
+)
+
+const participants = 4
+
+func newSuite() *curve25519.SuiteBabyJubJub {
+	return curve25519.NewBlakeSHA256BabyJubJub(false)
+}
+
+func polyEvalInput(t *testing.T, suite *curve25519.SuiteBabyJubJub) *zk.PolyEvalInput {
+	stream := suite.XOF([]byte("zkDKG poly_eval test vector"))
+
+	secret := suite.Scalar().Pick(stream)
+	priPoly := share.NewPriPoly(suite, participants/2+1, secret, stream)
+	_, commits := priPoly.Commit(nil).Info()
+
+	sk := suite.Scalar().Pick(stream)
+	skDisputer := suite.Scalar().Pick(stream)
+	pubDisputer := suite.Point().Mul(skDisputer, nil)
+
+	const index = 2
+	s, err := priPoly.Eval(index - 1).V.MarshalBinary()
+	require.NoError(t, err)
+
+	encrypted, err := zk.Encrypt(suite, sk, pubDisputer, commits, new(big.Int).SetBytes(s))
+	require.NoError(t, err)
+
+	return &zk.PolyEvalInput{
+		Commits:        commits,
+		SecretKey:      sk,
+		PubKeyProofer:  suite.Point().Mul(sk, nil),
+		PubKeyDisputer: pubDisputer,
+		Index:          index,
+		EncryptedShare: encrypted,
+	}
+}
+
+func keyDerivInput(suite *curve25519.SuiteBabyJubJub) *zk.KeyDerivInput {
+	stream := suite.XOF([]byte("zkDKG key_deriv test vector"))
+
+	coefficients := make([]kyber.Point, participants)
+	for i := range coefficients {
+		coefficients[i] = suite.Point().Pick(stream)
+	}
+	// Excluded dealers are represented by the point at infinity
+	coefficients[participants-1].Null()
+
+	return &zk.KeyDerivInput{FirstCoefficients: coefficients}
+}
+
+// zokParameter is a flattened parameter of the main function of a zokrates program.
+type zokParameter struct {
+	name    string
+	private bool
+}
+
+// zokSignature parses the main function of the program in the zk directory of the repository
+// and flattens its parameters for the given number of participants, like zokrates compute-witness expects them.
+func zokSignature(t *testing.T, program string, participants int) (params []zokParameter, output int) {
+	b, err := os.ReadFile(filepath.Join("..", "..", "..", "zk", program+".zok"))
+	require.NoError(t, err)
+	src := string(b)
+
+	consts := map[string]int{"PARTICIPANTS": participants}
+	for _, m := range regexp.MustCompile(`(?m)^const u32 (\w+) = (.+);$`).FindAllStringSubmatch(src, -1) {
+		consts[m[1]] = evalConst(t, consts, m[2])
+	}
+
+	size := func(typ string) int {
+		n := 1
+		for _, dim := range regexp.MustCompile(`\[(\w+)\]`).FindAllStringSubmatch(typ, -1) {
+			n *= evalConst(t, consts, dim[1])
+		}
+		return n
+	}
+
+	m := regexp.MustCompile(`def main\(([^)]*)\) -> ([\w\[\]]+)`).FindStringSubmatch(src)
+	require.NotNil(t, m, "no main function in %s.zok", program)
+
+	for _, param := range strings.Split(m[1], ",") {
+		fields := strings.Fields(param)
+		private := fields[0] == "private"
+		if private {
+			fields = fields[1:]
+		}
+		require.Len(t, fields, 2, "parameter %q of %s.zok", param, program)
+		for i := 0; i < size(fields[0]); i++ {
+			params = append(params, zokParameter{name: fields[1], private: private})
+		}
+	}
+	return params, size(m[2])
+}
+
+// evalConst evaluates a constant expression of sums of products and quotients, the only ones the programs use.
+func evalConst(t *testing.T, consts map[string]int, expr string) int {
+	operand := func(token string) int {
+		if v, ok := consts[token]; ok {
+			return v
+		}
+		v, err := strconv.Atoi(token)
+		require.NoError(t, err, "constant expression %q", expr)
+		return v
+	}
+
+	sum := 0
+	for _, term := range strings.Split(expr, "+") {
+		tokens := strings.Fields(strings.NewReplacer("*", " * ", "/", " / ").Replace(term))
+		value := operand(tokens[0])
+		for i := 1; i+1 < len(tokens); i += 2 {
+			if tokens[i] == "*" {
+				value *= operand(tokens[i+1])
+			} else {
+				value /= operand(tokens[i+1])
+			}
+		}
+		sum += value
+	}
+	return sum
+}
+
+// checkSignature compares the layout of the witness with the parameters of the program.
+func checkSignature(t *testing.T, program string, w zk.Witness) {
+	params, _ := zokSignature(t, program, participants)
+
+	actual := make([]zokParameter, len(w))
+	for i, a := range w {
+		actual[i] = zokParameter{name: a.Name, private: a.Private}
+	}
+	require.Equal(t, params, actual, "witness does not match the main function of %s.zok", program)
+}
+
+func TestPolyEvalSignature(t *testing.T) {
+	suite := newSuite()
+	in := polyEvalInput(t, suite)
+
+	w, err := in.Witness()
+	require.NoError(t, err)
+	require.Len(t, w, zk.PolyEvalArgumentsLen(participants))
+	checkSignature(t, "poly_eval", w)
+
+	_, output := zokSignature(t, "poly_eval", participants)
+	require.Equal(t, 1, output)
+}
+
+// readGolden reads a vector of testdata, which generate.py computes independently of this package.
+func readGolden(t *testing.T, program string, v interface{}) {
+	b, err := os.ReadFile(filepath.Join("testdata", program+".golden.json"))
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(b, v))
+}
+
+func decimals(values []*big.Int) []string {
+	s := make([]string, len(values))
+	for i, v := range values {
+		s[i] = v.String()
+	}
+	return s
+}
+
+func TestPolyEvalGolden(t *testing.T) {
+	var golden struct {
+		Participants      int        `json:"participants"`
+		Coefficients      []*big.Int `json:"coefficients"`
+		SecretKey         *big.Int   `json:"secretKey"`
+		DisputerSecretKey *big.Int   `json:"disputerSecretKey"`
+		Index             uint16     `json:"index"`
+		Share             *big.Int   `json:"share"`
+		SharedKey         *big.Int   `json:"sharedKey"`
+		EncryptedShare    *big.Int   `json:"encryptedShare"`
+		CommitsHash       string     `json:"commitsHash"`
+		Arguments         []*big.Int `json:"arguments"`
+	}
+	readGolden(t, "poly_eval", &golden)
+
+	suite := newSuite()
+	coefficients := make([]kyber.Scalar, len(golden.Coefficients))
+	for i, c := range golden.Coefficients {
+		coefficients[i] = suite.Scalar().SetBytes(c.Bytes())
+	}
+	priPoly := share.CoefficientsToPriPoly(suite, coefficients)
+	_, commits := priPoly.Commit(nil).Info()
+
+	sk := suite.Scalar().SetBytes(golden.SecretKey.Bytes())
+	pubDisputer := suite.Point().Mul(suite.Scalar().SetBytes(golden.DisputerSecretKey.Bytes()), nil)
+
+	s, err := priPoly.Eval(int(golden.Index) - 1).V.MarshalBinary()
+	require.NoError(t, err)
+	require.Equal(t, golden.Share.String(), new(big.Int).SetBytes(s).String())
+
+	sharedKey, err := zk.SharedKey(suite, sk, pubDisputer, commits)
+	require.NoError(t, err)
+	require.Equal(t, golden.SharedKey.String(), sharedKey.String(), "Poseidon inputs differ from poly_eval.zok")
+
+	encrypted, err := zk.Encrypt(suite, sk, pubDisputer, commits, golden.Share)
+	require.NoError(t, err)
+	require.Equal(t, golden.EncryptedShare.String(), encrypted.String())
+
+	in := &zk.PolyEvalInput{
+		Commits:        commits,
+		SecretKey:      sk,
+		PubKeyProofer:  suite.Point().Mul(sk, nil),
+		PubKeyDisputer: pubDisputer,
+		Index:          golden.Index,
+		EncryptedShare: encrypted,
+	}
+	commitsHash, err := in.CommitsHash()
+	require.NoError(t, err)
+	require.Equal(t, golden.CommitsHash, hex.EncodeToString(commitsHash), "compressed commitments differ from compressPoints.zok")
+
+	args, err := in.Arguments()
+	require.NoError(t, err)
+	require.Len(t, args, zk.PolyEvalArgumentsLen(golden.Participants))
+	require.Equal(t, decimals(golden.Arguments), decimals(args), "arguments differ from the golden vector of poly_eval.zok")
+	require.NoError(t, in.Validate(suite))
+}
+
+func TestWitnessRedaction(t *testing.T) {
+	suite := newSuite()
+	in := polyEvalInput(t, suite)
+
+	w, err := in.Witness()
+	require.NoError(t, err)
+	args, err := in.Arguments()
+	require.NoError(t, err)
+	require.Equal(t, args, w.Values())
+
+	sk, err := in.SecretKey.MarshalBinary()
+	require.NoError(t, err)
+	secret := new(big.Int).SetBytes(sk).String()
+	hash := args[len(args)-1].String()
+
+	b, err := json.Marshal(w)
+	require.NoError(t, err)
+	for _, s := range []string{
+		w.String(), fmt.Sprintf("%v", w), fmt.Sprintf("%d", w), fmt.Sprintf("%+v", w), fmt.Sprintf("%#v", w),
+		fmt.Sprint(w[len(w)-8]), string(b),
+	} {
+		require.NotContains(t, s, secret)
+		require.Contains(t, s, zk.Redacted)
+	}
+	require.Contains(t, w.String(), "hash="+hash)
+
+	var stdin strings.Builder
+	_, err = w.WriteTo(&stdin)
+	require.NoError(t, err)
+	values := strings.Fields(stdin.String())
+	require.Len(t, values, len(args))
+	for i, arg := range args {
+		require.Equal(t, arg.String(), values[i])
+	}
+}
+
+func TestPolyEvalValidate(t *testing.T) {
+	suite := newSuite()
+	in := polyEvalInput(t, suite)
+	require.NoError(t, in.Validate(suite))
+
+	in.EncryptedShare.Add(in.EncryptedShare, big.NewInt(1))
+	require.Error(t, in.Validate(suite))
+
+	in = polyEvalInput(t, suite)
+	in.SecretKey = suite.Scalar().One()
+	require.Error(t, in.Validate(suite))
+}
+
+func TestDecodePolyEvalArguments(t *testing.T) {
+	suite := newSuite()
+	in := polyEvalInput(t, suite)
+
+	args, err := in.Arguments()
+	require.NoError(t, err)
+
+	decoded, err := zk.DecodePolyEvalArguments(suite, args)
+	require.NoError(t, err)
+	require.NoError(t, decoded.Validate(suite))
+
+	decodedArgs, err := decoded.Arguments()
+	require.NoError(t, err)
+	require.Equal(t, args, decodedArgs)
+
+	args[len(args)-2].Add(args[len(args)-2], big.NewInt(1))
+	_, err = zk.DecodePolyEvalArguments(suite, args)
+	require.Error(t, err)
+}
+
+func TestEvalPubPoly(t *testing.T) {
+	suite := newSuite()
+	in := polyEvalInput(t, suite)
+	pubPoly := share.NewPubPoly(suite, nil, in.Commits)
+
+	for i := uint16(1); i <= participants; i++ {
+		require.True(t, pubPoly.Eval(int(i)-1).V.Equal(zk.EvalPubPoly(suite, i, in.Commits)))
+	}
+}
+
+func TestKeyDerivSignature(t *testing.T) {
+	suite := newSuite()
+	in := keyDerivInput(suite)
+
+	w, err := in.Witness()
+	require.NoError(t, err)
+	require.Len(t, w, zk.KeyDerivArgumentsLen(participants))
+	checkSignature(t, "key_deriv", w)
+
+	output, err := in.Output(suite)
+	require.NoError(t, err)
+	_, n := zokSignature(t, "key_deriv", participants)
+	require.Len(t, output, n)
+}
+
+func TestKeyDerivGolden(t *testing.T) {
+	var golden struct {
+		Participants int        `json:"participants"`
+		Scalars      []*big.Int `json:"scalars"`
+		Arguments    []*big.Int `json:"arguments"`
+		Output       []*big.Int `json:"output"`
+	}
+	readGolden(t, "key_deriv", &golden)
+
+	suite := newSuite()
+	in := &zk.KeyDerivInput{FirstCoefficients: make([]kyber.Point, len(golden.Scalars))}
+	for i, s := range golden.Scalars {
+		// A zero scalar yields the point at infinity of an excluded dealer
+		in.FirstCoefficients[i] = suite.Point().Mul(suite.Scalar().SetBytes(s.Bytes()), nil)
+	}
+
+	args, err := in.Arguments()
+	require.NoError(t, err)
+	require.Len(t, args, zk.KeyDerivArgumentsLen(golden.Participants))
+	require.Equal(t, decimals(golden.Arguments), decimals(args), "arguments differ from the golden vector of key_deriv.zok")
+
+	output, err := in.Output(suite)
+	require.NoError(t, err)
+	require.Equal(t, decimals(golden.Output), decimals(output[:]))
+}
+
+func TestDecodeKeyDerivArguments(t *testing.T) {
+	suite := newSuite()
+	in := keyDerivInput(suite)
+
+	args, err := in.Arguments()
+	require.NoError(t, err)
+
+	decoded, err := zk.DecodeKeyDerivArguments(suite, args)
+	require.NoError(t, err)
+	require.True(t, in.PublicKey(suite).Equal(decoded.PublicKey(suite)))
+
+	args[0].Add(args[0], big.NewInt(1))
+	_, err = zk.DecodeKeyDerivArguments(suite, args)
+	require.Error(t, err)
+}
+
+func TestFieldSqrt(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i++ {
+		a := new(big.Int).Rand(rng, zk.FieldModulus)
+
+		r, ok := zk.FieldSqrt(a)
+		if new(big.Int).ModSqrt(a, zk.FieldModulus) == nil {
+			require.False(t, ok)
+			continue
+		}
+		require.True(t, ok)
+		require.Zero(t, new(big.Int).Exp(r, big.NewInt(2), zk.FieldModulus).Cmp(a))
+	}
+
+	r, ok := zk.FieldSqrt(big.NewInt(0))
+	require.True(t, ok)
+	require.Zero(t, r.Sign())
+
+	// 5 is the smallest quadratic non-residue
+	_, ok = zk.FieldSqrt(big.NewInt(5))
+	require.False(t, ok)
+}
+
+func TestDecompress(t *testing.T) {
+	suite := newSuite()
+	for i := 0; i < 20; i++ {
+		p := suite.Point().Pick(suite.RandomStream())
+		x, y, err := zk.Coordinates(p)
+		require.NoError(t, err)
+
+		c := zk.Compress(x, y)
+		b, err := p.MarshalBinary()
+		require.NoError(t, err)
+		require.Equal(t, b, c.FillBytes(make([]byte, zk.FieldSize)))
+
+		dx, dy, err := zk.Decompress(c)
+		require.NoError(t, err)
+		require.Zero(t, x.Cmp(dx))
+		require.Zero(t, y.Cmp(dy))
+
+		q, err := zk.DecompressPoint(suite, c)
+		require.NoError(t, err)
+		require.True(t, p.Equal(q))
+	}
+
+	// The identity is encoded as 1
+	x, y, err := zk.Decompress(big.NewInt(1))
+	require.NoError(t, err)
+	require.Zero(t, x.Sign())
+	require.Zero(t, y.Cmp(big.NewInt(1)))
+
+	// x = 0 must have a clear sign bit
+	_, _, err = zk.Decompress(new(big.Int).SetBit(big.NewInt(1), 255, 1))
+	require.Error(t, err)
+
+	// y = 2 doesn't belong to a point on the curve
+	_, _, err = zk.Decompress(big.NewInt(2))
+	require.Error(t, err)
+
+	_, _, err = zk.Decompress(zk.FieldModulus)
+	require.Error(t, err)
+
+	_, _, err = zk.Decompress(new(big.Int).Lsh(big.NewInt(1), 256))
+	require.Error(t, err)
+}
+
+// abiJSON returns the abi.json zokrates compile writes for key_deriv.zok compiled for the given number of participants.
+func abiJSON(participants int) string {
+	return fmt.Sprintf(`{
+  "inputs": [
+    {"name": "firstCoefficients", "public": false, "type": "array", "components": {"size": %d, "type": "array", "components": {"size": 2, "type": "field"}}},
+    {"name": "hash", "public": true, "type": "field"}
+  ],
+  "output": {"type": "array", "components": {"size": 2, "type": "field"}}
+}`, participants)
+}
+
+func TestReadABI(t *testing.T) {
+	abi, err := zk.ReadABI(strings.NewReader(abiJSON(participants)))
+	require.NoError(t, err)
+
+	n, err := zk.KeyDerivParticipants(abi)
+	require.NoError(t, err)
+	require.Equal(t, participants, n)
+
+	_, err = zk.PolyEvalThreshold(abi)
+	require.Error(t, err)
+
+	_, err = abi.ArrayLen("hash")
+	require.Error(t, err)
+
+	_, err = zk.ReadABI(strings.NewReader("{"))
+	require.Error(t, err)
+}
@@ -5,6 +5,7 @@ cd "$(dirname "$0")"/.. || exit 1
 participants=$1
 
 zokratesTag=0.8.2
+solcTag=0.8.4
 rootDir="$(pwd)"
 buildRoot="$rootDir"/build/$participants/zk
 contracts="$rootDir"/contracts/contracts
@@ -62,3 +63,13 @@ for name in ${!inputs[@]}; do
     verifier=$(sed -ne "s/Verifier/$contractName/" -e "/^contract $contractName/,/^}/p" $buildDir/verifier.sol)
     echo -e "${prefixWithImport}${verifier}" > $contracts/$contractName.sol
 done
+
+# Compile the contracts for zkdkg deploy, which deploys them without Hardhat
+bytecodeDir="$rootDir"/build/$participants/contracts
+mkdir -p "$bytecodeDir"
+docker run --user=$(id -u):$(id -g) --mount type=bind,source="$contracts",target=/sources,readonly --mount type=bind,source="$bytecodeDir",target=/build \
+    ethereum/solc:$solcTag --bin --overwrite -o /build /sources/ZKDKG.sol \
+    || { echo "compiling the contracts failed with exit code $?" >&2; exit 1; }
+
+# Record the checksums of the artifacts, which the client verifies at startup
+(cd "$rootDir"/dkg && go run ./cmd/zkdkg artifacts record -dir "$buildRoot" -participants $participants) || { echo "recording the artifact manifest failed" >&2; exit 1; }